@@ -0,0 +1,206 @@
+// Command inventoryctl is the operators' client for the inventory service.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+const usageHeader = `Usage: inventoryctl [flags] <command> [command flags] [arguments]
+
+Connection settings default to the INVENTORY_* environment variables.
+
+Commands:
+`
+
+// command runs one subcommand with the arguments following its name.
+type command struct {
+	usage string
+	run   func(ctx context.Context, c *client, args []string) error
+}
+
+var commands = map[string]command{
+	"add":          {"add a product", addProduct},
+	"get":          {"show a product by id", getProduct},
+	"list":         {"list products", listProducts},
+	"update":       {"change fields of a product", updateProduct},
+	"adjust":       {"adjust the stock of a product under a reason code", adjustStock},
+	"import":       {"add or update products from a CSV or JSON file", importProducts},
+	"export":       {"write products to a CSV or JSON file", exportProducts},
+	"reservations": {"list bundle reservations", listReservations},
+	"reservation":  {"show a reservation by id", getReservation},
+	"valuation":    {"value the stock on hand", valuation},
+}
+
+// client holds the connection and the settings shared by every command.
+type client struct {
+	inventory  pb.InventoryServiceClient
+	bundles    pb.BundleServiceClient
+	valuations pb.ValuationServiceClient
+	out        *printer
+	actor      string
+	timeout    time.Duration
+}
+
+// call returns a context for one RPC, carrying the actor and the timeout.
+func (c *client) call(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.actor != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-actor", c.actor)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if s, ok := status.FromError(err); ok {
+			fmt.Fprintf(os.Stderr, "inventoryctl: %s: %s\n", s.Code(), s.Message())
+		} else {
+			fmt.Fprintf(os.Stderr, "inventoryctl: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("inventoryctl", flag.ContinueOnError)
+	addr := fs.String("addr", envOr("INVENTORY_ADDR", "localhost:50051"), "gRPC address of the service (env INVENTORY_ADDR)")
+	useTLS := fs.Bool("tls", envBool("INVENTORY_TLS"), "connect with TLS (env INVENTORY_TLS)")
+	caFile := fs.String("ca-file", os.Getenv("INVENTORY_CA_FILE"), "PEM file of the CA to trust instead of the system pool; implies -tls (env INVENTORY_CA_FILE)")
+	certFile := fs.String("cert-file", os.Getenv("INVENTORY_CERT_FILE"), "PEM client certificate for mutual TLS; implies -tls (env INVENTORY_CERT_FILE)")
+	keyFile := fs.String("key-file", os.Getenv("INVENTORY_KEY_FILE"), "PEM private key of the client certificate (env INVENTORY_KEY_FILE)")
+	timeout := fs.Duration("timeout", envDuration("INVENTORY_TIMEOUT", 10*time.Second), "timeout of a single call (env INVENTORY_TIMEOUT)")
+	output := fs.String("o", envOr("INVENTORY_OUTPUT", "table"), "output format, table or json (env INVENTORY_OUTPUT)")
+	actor := fs.String("actor", envOr("INVENTORY_ACTOR", os.Getenv("USER")), "name recorded as the actor of changes unless a client certificate names it (env INVENTORY_ACTOR)")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usageHeader)
+		names := make([]string, 0, len(commands))
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(fs.Output(), "  %-13s %s\n", name, commands[name].usage)
+		}
+		fmt.Fprintln(fs.Output(), "\nFlags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("no command given")
+	}
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		return fmt.Errorf("unknown command %q", fs.Arg(0))
+	}
+	out, err := newPrinter(os.Stdout, *output)
+	if err != nil {
+		return err
+	}
+
+	creds := insecure.NewCredentials()
+	if *useTLS || *caFile != "" || *certFile != "" {
+		cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+		if *certFile != "" {
+			cert, err := tls.LoadX509KeyPair(*certFile, *keyFile)
+			if err != nil {
+				return err
+			}
+			cfg.Certificates = []tls.Certificate{cert}
+		}
+		if *caFile != "" {
+			pem, err := os.ReadFile(*caFile)
+			if err != nil {
+				return err
+			}
+			cfg.RootCAs = x509.NewCertPool()
+			if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+				return fmt.Errorf("no certificates found in %s", *caFile)
+			}
+		}
+		creds = credentials.NewTLS(cfg)
+	}
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err = cmd.run(ctx, &client{
+		inventory:  pb.NewInventoryServiceClient(conn),
+		bundles:    pb.NewBundleServiceClient(conn),
+		valuations: pb.NewValuationServiceClient(conn),
+		out:        out,
+		actor:      *actor,
+		timeout:    *timeout,
+	}, fs.Args()[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	return err
+}
+
+// parseFlags parses the flags of a command and checks it got exactly nargs
+// positional arguments, which are named in the usage line.
+func parseFlags(fs *flag.FlagSet, args []string, positional ...string) error {
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: inventoryctl %s [flags]", fs.Name())
+		for _, p := range positional {
+			fmt.Fprintf(fs.Output(), " <%s>", p)
+		}
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != len(positional) {
+		fs.Usage()
+		return fmt.Errorf("%s takes %d argument(s), got %d", fs.Name(), len(positional), fs.NArg())
+	}
+	return nil
+}
+
+func envOr(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return def
+}
+
+func envBool(key string) bool {
+	v, _ := strconv.ParseBool(os.Getenv(key))
+	return v
+}
+
+func envDuration(key string, def time.Duration) time.Duration {
+	if v, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return v
+	}
+	return def
+}
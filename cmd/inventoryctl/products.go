@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strings"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+func addProduct(ctx context.Context, c *client, args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	req := &pb.ProductRequest{}
+	fs.StringVar(&req.Name, "name", "", "product name (required)")
+	fs.StringVar(&req.Sku, "sku", "", "stock keeping unit")
+	fs.StringVar(&req.Description, "description", "", "description")
+	fs.Float64Var(&req.Price, "price", 0, "sale price")
+	stock := fs.Int("stock", 0, "initial stock")
+	fs.StringVar(&req.CategoryId, "category", "", "category id")
+	reorderPoint := fs.Int("reorder-point", 0, "stock level at which to reorder")
+	reorderQuantity := fs.Int("reorder-quantity", 0, "quantity to reorder")
+	variantAttributes := fs.String("variant-attributes", "", "comma-separated attributes distinguishing variants, e.g. size,colour")
+	fs.BoolVar(&req.LotTracked, "lot-tracked", false, "stock the product through lots")
+	fs.BoolVar(&req.Serialized, "serialized", false, "stock the product as serial-numbered units")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	req.Stock = int32(*stock)
+	req.ReorderPoint, req.ReorderQuantity = int32(*reorderPoint), int32(*reorderQuantity)
+	if *variantAttributes != "" {
+		req.VariantAttributes = strings.Split(*variantAttributes, ",")
+	}
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	p, err := c.inventory.AddProduct(ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.printProduct(p)
+}
+
+func getProduct(ctx context.Context, c *client, args []string) error {
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	includeDeleted := fs.Bool("include-deleted", false, "also find archived products (admins only)")
+	if err := parseFlags(fs, args, "id"); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	p, err := c.inventory.GetProduct(ctx, &pb.GetProductRequest{Id: fs.Arg(0), IncludeDeleted: *includeDeleted})
+	if err != nil {
+		return err
+	}
+	return c.printProduct(p)
+}
+
+func listProducts(ctx context.Context, c *client, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	includeDeleted := fs.Bool("include-deleted", false, "include archived products (admins only)")
+	lowStock := fs.Bool("low-stock", false, "only products at or below their reorder point")
+	query := fs.String("q", "", "only products whose name, description or SKU contains every word")
+	limit := fs.Int("limit", 0, "with -q, the most products to return (0 means the server's batch size)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	var list *pb.ProductList
+	var err error
+	switch {
+	case *query != "":
+		list, err = c.inventory.SearchProducts(ctx, &pb.SearchProductsRequest{Query: *query, Limit: int32(*limit)})
+	case *lowStock:
+		list, err = c.inventory.ListLowStockProducts(ctx, &pb.ListLowStockProductsRequest{})
+	default:
+		list, err = c.inventory.ListProducts(ctx, &pb.ListProductsRequest{IncludeDeleted: *includeDeleted})
+	}
+	if err != nil {
+		return err
+	}
+	if c.out.json {
+		return c.out.message(list)
+	}
+	rows := make([][]string, 0, len(list.Products))
+	for _, p := range list.Products {
+		rows = append(rows, []string{
+			p.Id, p.Sku, p.Name, formatMoney(p.Price), formatInt(p.AvailableStock),
+			formatInt(p.DamagedStock), formatInt(p.ReorderPoint), p.CategoryId, formatTime(p.DeletedAt),
+		})
+	}
+	return c.out.table([]string{"ID", "SKU", "NAME", "PRICE", "STOCK", "DAMAGED", "REORDER AT", "CATEGORY", "ARCHIVED"}, rows)
+}
+
+func updateProduct(ctx context.Context, c *client, args []string) error {
+	fs := flag.NewFlagSet("update", flag.ContinueOnError)
+	name := fs.String("name", "", "product name")
+	description := fs.String("description", "", "description")
+	price := fs.Float64("price", 0, "sale price")
+	category := fs.String("category", "", "category id")
+	reorderPoint := fs.Int("reorder-point", 0, "stock level at which to reorder")
+	reorderQuantity := fs.Int("reorder-quantity", 0, "quantity to reorder")
+	if err := parseFlags(fs, args, "id"); err != nil {
+		return err
+	}
+	// Only the flags given on the command line are sent, so that a field
+	// can be set to its zero value.
+	req := &pb.UpdateProductRequest{Id: fs.Arg(0)}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "name":
+			req.Name = name
+		case "description":
+			req.Description = description
+		case "price":
+			req.Price = price
+		case "category":
+			req.CategoryId = category
+		case "reorder-point":
+			v := int32(*reorderPoint)
+			req.ReorderPoint = &v
+		case "reorder-quantity":
+			v := int32(*reorderQuantity)
+			req.ReorderQuantity = &v
+		}
+	})
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	p, err := c.inventory.UpdateProduct(ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.printProduct(p)
+}
+
+func adjustStock(ctx context.Context, c *client, args []string) error {
+	fs := flag.NewFlagSet("adjust", flag.ContinueOnError)
+	delta := fs.Int("delta", 0, "signed change of stock (required)")
+	reason := fs.String("reason", "correction", "reason code: cycle_count, damage, loss, found or correction")
+	reference := fs.String("reference", "", "reference recorded on the stock movement")
+	variantID := fs.String("variant", "", "id of the variant to adjust; required for products with variants")
+	if err := parseFlags(fs, args, "product-id"); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	p, err := c.inventory.AdjustStock(ctx, &pb.AdjustStockRequest{
+		ProductId: fs.Arg(0),
+		VariantId: *variantID,
+		Delta:     int32(*delta),
+		Reason:    *reason,
+		Reference: *reference,
+	})
+	if err != nil {
+		return err
+	}
+	return c.printProduct(p)
+}
+
+func (c *client) printProduct(p *pb.ProductResponse) error {
+	if c.out.json {
+		return c.out.message(p)
+	}
+	fields := [][2]string{
+		{"ID", p.Id},
+		{"SKU", p.Sku},
+		{"Name", p.Name},
+		{"Description", p.Description},
+		{"Price", formatMoney(p.Price)},
+		{"Stock", formatInt(p.Stock)},
+		{"Available", formatInt(p.AvailableStock)},
+		{"Damaged", formatInt(p.DamagedStock)},
+		{"Category", p.CategoryId},
+		{"Reorder point", formatInt(p.ReorderPoint)},
+		{"Reorder quantity", formatInt(p.ReorderQuantity)},
+	}
+	switch {
+	case p.LotTracked:
+		fields = append(fields, [2]string{"Tracking", "lots"})
+	case p.Serialized:
+		fields = append(fields, [2]string{"Tracking", "serial numbers"})
+	}
+	if len(p.VariantAttributes) > 0 {
+		fields = append(fields, [2]string{"Variant attributes", strings.Join(p.VariantAttributes, ", ")})
+	}
+	if p.DeletedAt != nil {
+		fields = append(fields, [2]string{"Archived", formatTime(p.DeletedAt) + " by " + p.DeletedBy})
+	}
+	if err := c.out.fields(fields); err != nil {
+		return err
+	}
+	if len(p.Variants) == 0 {
+		return nil
+	}
+	rows := make([][]string, 0, len(p.Variants))
+	for _, v := range p.Variants {
+		attrs := make([]string, 0, len(v.Attributes))
+		for _, name := range p.VariantAttributes {
+			attrs = append(attrs, name+"="+v.Attributes[name])
+		}
+		rows = append(rows, []string{v.Id, v.Sku, strings.Join(attrs, " "), formatMoney(v.Price), formatInt(v.Stock)})
+	}
+	c.out.w.Write([]byte("\n"))
+	return c.out.table([]string{"VARIANT", "SKU", "ATTRIBUTES", "PRICE", "STOCK"}, rows)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+var reservationStatuses = map[string]pb.ReservationStatus{
+	"":         pb.ReservationStatus_RESERVATION_STATUS_UNSPECIFIED,
+	"active":   pb.ReservationStatus_RESERVATION_STATUS_ACTIVE,
+	"released": pb.ReservationStatus_RESERVATION_STATUS_RELEASED,
+}
+
+func listReservations(ctx context.Context, c *client, args []string) error {
+	fs := flag.NewFlagSet("reservations", flag.ContinueOnError)
+	statusName := fs.String("status", "active", "active or released; empty for both")
+	product := fs.String("product", "", "only reservations holding this product")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	st, ok := reservationStatuses[*statusName]
+	if !ok {
+		return fmt.Errorf("unknown reservation status %q", *statusName)
+	}
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	list, err := c.bundles.ListReservations(ctx, &pb.ListReservationsRequest{Status: st, ProductId: *product})
+	if err != nil {
+		return err
+	}
+	if c.out.json {
+		return c.out.message(list)
+	}
+	rows := make([][]string, 0, len(list.Reservations))
+	for _, r := range list.Reservations {
+		rows = append(rows, []string{
+			r.Id, r.BundleId, formatInt(r.Quantity), r.Reference, reservationStatus(r.Status), formatTime(r.CreatedAt), formatLines(r.Lines),
+		})
+	}
+	return c.out.table([]string{"ID", "BUNDLE", "QUANTITY", "REFERENCE", "STATUS", "CREATED", "PRODUCTS"}, rows)
+}
+
+func getReservation(ctx context.Context, c *client, args []string) error {
+	fs := flag.NewFlagSet("reservation", flag.ContinueOnError)
+	if err := parseFlags(fs, args, "id"); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	r, err := c.bundles.GetReservation(ctx, &pb.ReservationID{Id: fs.Arg(0)})
+	if err != nil {
+		return err
+	}
+	if c.out.json {
+		return c.out.message(r)
+	}
+	if err := c.out.fields([][2]string{
+		{"ID", r.Id},
+		{"Bundle", r.BundleId},
+		{"Quantity", formatInt(r.Quantity)},
+		{"Reference", r.Reference},
+		{"Status", reservationStatus(r.Status)},
+		{"Created", formatTime(r.CreatedAt)},
+		{"Released", formatTime(r.ReleasedAt)},
+	}); err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(r.Lines))
+	for _, l := range r.Lines {
+		rows = append(rows, []string{l.ProductId, formatInt(l.Quantity)})
+	}
+	fmt.Fprintln(c.out.w)
+	return c.out.table([]string{"PRODUCT", "QUANTITY"}, rows)
+}
+
+func reservationStatus(s pb.ReservationStatus) string {
+	for name, v := range reservationStatuses {
+		if v == s && name != "" {
+			return name
+		}
+	}
+	return s.String()
+}
+
+func formatLines(lines []*pb.ReservationLine) string {
+	parts := make([]string, 0, len(lines))
+	for _, l := range lines {
+		parts = append(parts, fmt.Sprintf("%s×%d", l.ProductId, l.Quantity))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+// csvColumns are the columns of exported CSV files. Imported files need a
+// header naming a subset of them, in any order.
+var csvColumns = []string{
+	"id", "sku", "name", "description", "price", "stock", "category_id", "reorder_point", "reorder_quantity",
+}
+
+// productRecord is a product as exported and imported. Missing fields of an
+// imported record are left unchanged when it updates a product.
+type productRecord struct {
+	ID              string   `json:"id,omitempty"`
+	SKU             string   `json:"sku,omitempty"`
+	Name            *string  `json:"name,omitempty"`
+	Description     *string  `json:"description,omitempty"`
+	Price           *float64 `json:"price,omitempty"`
+	Stock           *int32   `json:"stock,omitempty"`
+	CategoryID      *string  `json:"category_id,omitempty"`
+	ReorderPoint    *int32   `json:"reorder_point,omitempty"`
+	ReorderQuantity *int32   `json:"reorder_quantity,omitempty"`
+}
+
+func newProductRecord(p *pb.ProductResponse) productRecord {
+	return productRecord{
+		ID:              p.Id,
+		SKU:             p.Sku,
+		Name:            &p.Name,
+		Description:     &p.Description,
+		Price:           &p.Price,
+		Stock:           &p.Stock,
+		CategoryID:      &p.CategoryId,
+		ReorderPoint:    &p.ReorderPoint,
+		ReorderQuantity: &p.ReorderQuantity,
+	}
+}
+
+func exportProducts(ctx context.Context, c *client, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "", "csv or json; taken from the file extension by default")
+	includeDeleted := fs.Bool("include-deleted", false, "include archived products (admins only)")
+	if err := parseFlags(fs, args, "file"); err != nil {
+		return err
+	}
+	path := fs.Arg(0)
+	f, err := fileFormat(path, *format)
+	if err != nil {
+		return err
+	}
+
+	callCtx, cancel := c.call(ctx)
+	defer cancel()
+	list, err := c.inventory.ListProducts(callCtx, &pb.ListProductsRequest{IncludeDeleted: *includeDeleted})
+	if err != nil {
+		return err
+	}
+	records := make([]productRecord, 0, len(list.Products))
+	for _, p := range list.Products {
+		records = append(records, newProductRecord(p))
+	}
+
+	w := io.Writer(os.Stdout)
+	if path != "-" {
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	if f == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(records)
+	} else {
+		err = writeCSV(w, records)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "exported %d products\n", len(records))
+	return nil
+}
+
+// importProducts adds the records without an id and updates the products
+// named by the others. Stock is only used for new products; the stock of
+// existing ones is changed with adjust. Failed records are reported and
+// skipped.
+func importProducts(ctx context.Context, c *client, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := fs.String("format", "", "csv or json; taken from the file extension by default")
+	if err := parseFlags(fs, args, "file"); err != nil {
+		return err
+	}
+	path := fs.Arg(0)
+	f, err := fileFormat(path, *format)
+	if err != nil {
+		return err
+	}
+	r := io.Reader(os.Stdin)
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+	var records []productRecord
+	if f == "json" {
+		err = json.NewDecoder(r).Decode(&records)
+	} else {
+		records, err = readCSV(r)
+	}
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+
+	var added, updated, failed int
+	for i, rec := range records {
+		var err error
+		if rec.ID == "" {
+			err = c.importAdd(ctx, rec)
+		} else {
+			err = c.importUpdate(ctx, rec)
+		}
+		switch {
+		case err != nil:
+			failed++
+			fmt.Fprintf(os.Stderr, "record %d: %v\n", i+1, err)
+		case rec.ID == "":
+			added++
+		default:
+			updated++
+		}
+	}
+	fmt.Fprintf(os.Stderr, "added %d, updated %d, failed %d\n", added, updated, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d records failed", failed, len(records))
+	}
+	return nil
+}
+
+func (c *client) importAdd(ctx context.Context, rec productRecord) error {
+	req := &pb.ProductRequest{Sku: rec.SKU}
+	if rec.Name != nil {
+		req.Name = *rec.Name
+	}
+	if rec.Description != nil {
+		req.Description = *rec.Description
+	}
+	if rec.Price != nil {
+		req.Price = *rec.Price
+	}
+	if rec.Stock != nil {
+		req.Stock = *rec.Stock
+	}
+	if rec.CategoryID != nil {
+		req.CategoryId = *rec.CategoryID
+	}
+	if rec.ReorderPoint != nil {
+		req.ReorderPoint = *rec.ReorderPoint
+	}
+	if rec.ReorderQuantity != nil {
+		req.ReorderQuantity = *rec.ReorderQuantity
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	_, err := c.inventory.AddProduct(ctx, req)
+	return err
+}
+
+func (c *client) importUpdate(ctx context.Context, rec productRecord) error {
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	_, err := c.inventory.UpdateProduct(ctx, &pb.UpdateProductRequest{
+		Id:              rec.ID,
+		Name:            rec.Name,
+		Description:     rec.Description,
+		Price:           rec.Price,
+		CategoryId:      rec.CategoryID,
+		ReorderPoint:    rec.ReorderPoint,
+		ReorderQuantity: rec.ReorderQuantity,
+	})
+	return err
+}
+
+func fileFormat(path, format string) (string, error) {
+	if format == "" {
+		if filepath.Ext(path) == ".json" {
+			return "json", nil
+		}
+		return "csv", nil
+	}
+	if format != "csv" && format != "json" {
+		return "", fmt.Errorf("unknown file format %q: must be csv or json", format)
+	}
+	return format, nil
+}
+
+func writeCSV(w io.Writer, records []productRecord) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvColumns); err != nil {
+		return err
+	}
+	for _, r := range records {
+		err := cw.Write([]string{
+			r.ID, r.SKU, *r.Name, *r.Description,
+			strconv.FormatFloat(*r.Price, 'f', -1, 64), formatInt(*r.Stock), *r.CategoryID,
+			formatInt(*r.ReorderPoint), formatInt(*r.ReorderQuantity),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// readCSV reads records whose columns are named by the header line. Empty
+// cells are treated as missing.
+func readCSV(r io.Reader) ([]productRecord, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	known := make(map[string]bool, len(csvColumns))
+	for _, c := range csvColumns {
+		known[c] = true
+	}
+	for _, h := range header {
+		if !known[h] {
+			return nil, fmt.Errorf("unknown column %q", h)
+		}
+	}
+
+	var records []productRecord
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var rec productRecord
+		for i, v := range row {
+			if v == "" {
+				continue
+			}
+			if err := rec.set(header[i], v); err != nil {
+				return nil, fmt.Errorf("line %d: %s: %w", line, header[i], err)
+			}
+		}
+		records = append(records, rec)
+	}
+}
+
+func (r *productRecord) set(column, v string) error {
+	switch column {
+	case "id":
+		r.ID = v
+	case "sku":
+		r.SKU = v
+	case "name":
+		r.Name = &v
+	case "description":
+		r.Description = &v
+	case "category_id":
+		r.CategoryID = &v
+	case "price":
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return err
+		}
+		r.Price = &f
+	case "stock", "reorder_point", "reorder_quantity":
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return err
+		}
+		i := int32(n)
+		switch column {
+		case "stock":
+			r.Stock = &i
+		case "reorder_point":
+			r.ReorderPoint = &i
+		default:
+			r.ReorderQuantity = &i
+		}
+	}
+	return nil
+}
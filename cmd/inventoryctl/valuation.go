@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+var valuationMethods = map[string]pb.ValuationMethod{
+	"fifo":             pb.ValuationMethod_VALUATION_METHOD_FIFO,
+	"weighted_average": pb.ValuationMethod_VALUATION_METHOD_WEIGHTED_AVERAGE,
+}
+
+func valuation(ctx context.Context, c *client, args []string) error {
+	fs := flag.NewFlagSet("valuation", flag.ContinueOnError)
+	methodName := fs.String("method", "fifo", "fifo or weighted_average")
+	asOf := fs.String("as-of", "", "date (2006-01-02) or RFC 3339 time to value the stock at; now by default")
+	category := fs.String("category", "", "only products of this category")
+	byCategory := fs.Bool("by-category", false, "show totals per category instead of per product")
+	byWarehouse := fs.Bool("by-warehouse", false, "show totals per serial unit location instead of per product; only without -as-of")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *byCategory && *byWarehouse {
+		return errors.New("-by-category and -by-warehouse cannot be combined")
+	}
+	if *byWarehouse && *asOf != "" {
+		return errors.New("-by-warehouse is only available for the present stock")
+	}
+	method, ok := valuationMethods[*methodName]
+	if !ok {
+		return fmt.Errorf("unknown valuation method %q", *methodName)
+	}
+	req := &pb.ValuationRequest{Method: method, CategoryId: *category}
+	if *asOf != "" {
+		t, err := parseTime(*asOf)
+		if err != nil {
+			return err
+		}
+		req.AsOf = timestamppb.New(t)
+	}
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	r, err := c.valuations.GetValuation(ctx, req)
+	if err != nil {
+		return err
+	}
+	if c.out.json {
+		return c.out.message(r)
+	}
+	fmt.Fprintf(c.out.w, "Stock value as of %s (%s): %s for %d units\n\n", formatTime(r.AsOf), *methodName, formatMoney(r.Value), r.Quantity)
+	if *byCategory {
+		rows := make([][]string, 0, len(r.Categories))
+		for _, v := range r.Categories {
+			rows = append(rows, []string{v.CategoryId, formatInt(v.Quantity), formatMoney(v.Value)})
+		}
+		return c.out.table([]string{"CATEGORY", "QUANTITY", "VALUE"}, rows)
+	}
+	if *byWarehouse {
+		rows := make([][]string, 0, len(r.Warehouses))
+		for _, v := range r.Warehouses {
+			location := v.Location
+			if location == "" {
+				location = "(unassigned)"
+			}
+			rows = append(rows, []string{location, formatInt(v.Quantity), formatMoney(v.Value)})
+		}
+		return c.out.table([]string{"WAREHOUSE", "QUANTITY", "VALUE"}, rows)
+	}
+	rows := make([][]string, 0, len(r.Products))
+	for _, v := range r.Products {
+		rows = append(rows, []string{
+			v.ProductId, v.Sku, v.Name, v.CategoryId, formatInt(v.Quantity), formatInt(v.UncostedQuantity),
+			formatMoney(v.UnitCost), formatMoney(v.Value),
+		})
+	}
+	return c.out.table([]string{"ID", "SKU", "NAME", "CATEGORY", "QUANTITY", "UNCOSTED", "UNIT COST", "VALUE"}, rows)
+}
+
+// parseTime accepts a date, taken as the end of that day in local time, or
+// an RFC 3339 time.
+func parseTime(s string) (time.Time, error) {
+	if d, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return d.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
@@ -1,2 +1,282 @@
 // cmd/server/main.go
 package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/facelessEmptiness/inventory_service/internal/audit"
+	"github.com/facelessEmptiness/inventory_service/internal/cache"
+	"github.com/facelessEmptiness/inventory_service/internal/config"
+	grpcdelivery "github.com/facelessEmptiness/inventory_service/internal/delivery/grpc"
+	httpdelivery "github.com/facelessEmptiness/inventory_service/internal/delivery/http"
+	"github.com/facelessEmptiness/inventory_service/internal/event"
+	"github.com/facelessEmptiness/inventory_service/internal/forecast"
+	"github.com/facelessEmptiness/inventory_service/internal/health"
+	"github.com/facelessEmptiness/inventory_service/internal/metrics"
+	"github.com/facelessEmptiness/inventory_service/internal/migrate"
+	"github.com/facelessEmptiness/inventory_service/internal/ratelimit"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+	"github.com/facelessEmptiness/inventory_service/internal/usecase"
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+func main() {
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: cfg.LogLevel})))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.TracingExporter, cfg.OTLPEndpoint)
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	reg := metrics.NewRegistry()
+	m := metrics.New(reg)
+
+	var repos repositories
+	var ping func(context.Context) error
+	switch cfg.ProductStore {
+	case "bolt":
+		boltDB, err := bolt.Open(cfg.BoltPath, 0o600, &bolt.Options{Timeout: cfg.BoltOpenTimeout})
+		if err != nil {
+			log.Fatalf("failed to open %s: %v", cfg.BoltPath, err)
+		}
+		defer boltDB.Close()
+		if repos, err = boltRepositories(boltDB); err != nil {
+			log.Fatalf("failed to prepare %s: %v", cfg.BoltPath, err)
+		}
+		ping = func(context.Context) error { return boltDB.View(func(*bolt.Tx) error { return nil }) }
+	case "postgres":
+		connectCtx, cancel := context.WithTimeout(ctx, cfg.Postgres.ConnectTimeout)
+		pool, err := pgxpool.New(connectCtx, cfg.Postgres.URL)
+		if err == nil {
+			err = pool.Ping(connectCtx)
+		}
+		cancel()
+		if err != nil {
+			log.Fatalf("failed to connect to PostgreSQL: %v", err)
+		}
+		defer pool.Close()
+		if cfg.MigrateOnStart {
+			applied, err := migrate.UpPostgres(ctx, pool, migrate.PostgresMigrations())
+			for _, mig := range applied {
+				log.Printf("migrate: applied postgres %d %s", mig.Version, mig.Description)
+			}
+			if err != nil {
+				log.Fatalf("failed to migrate PostgreSQL: %v", err)
+			}
+		}
+		repos = postgresRepositories(pool, cfg.Postgres.Timeout)
+		ping = pool.Ping
+	default:
+		connectCtx, cancel := context.WithTimeout(ctx, cfg.Mongo.ConnectTimeout)
+		client, err := mongo.Connect(connectCtx, options.Client().
+			ApplyURI(cfg.Mongo.URI).
+			SetMonitor(m.MongoMonitor()))
+		cancel()
+		if err != nil {
+			log.Fatalf("failed to connect to MongoDB: %v", err)
+		}
+		defer client.Disconnect(context.Background())
+
+		db := client.Database(cfg.Mongo.Database)
+		if cfg.MigrateOnStart {
+			migrator, err := migrate.New(db, cfg.Mongo.ProductsCollection, migrate.Migrations(cfg.Mongo.ProductsCollection))
+			if err != nil {
+				log.Fatalf("invalid migrations: %v", err)
+			}
+			applied, err := migrator.Up(ctx)
+			for _, mig := range applied {
+				log.Printf("migrate: applied %d %s", mig.Version, mig.Description)
+			}
+			if err != nil {
+				log.Fatalf("failed to migrate MongoDB: %v", err)
+			}
+		}
+		repos = mongoRepositories(db, cfg.Mongo.ProductsCollection, cfg.Mongo.Timeout)
+		ping = func(ctx context.Context) error { return client.Ping(ctx, readpref.Primary()) }
+	}
+	repo := audit.NewProductRepository(repos.products, repos.auditEvents)
+	switch cfg.Cache.Backend {
+	case cache.BackendMemory:
+		repo = cache.NewProductRepository(repo, cache.NewMemoryStore(cfg.Cache.Size, cfg.Cache.TTL), cfg.Cache.Backend, m)
+	case cache.BackendRedis:
+		opts, err := redis.ParseURL(cfg.Cache.RedisURL)
+		if err != nil {
+			log.Fatalf("invalid REDIS_URL: %v", err)
+		}
+		rdb := redis.NewClient(opts)
+		defer rdb.Close()
+		repo = cache.NewProductRepository(repo, cache.NewRedisStore(rdb, cfg.Cache.TTL), cfg.Cache.Backend, m)
+	}
+	reg.MustRegister(metrics.NewStockCollector(repo))
+
+	var publisher event.Publisher = event.LogPublisher{}
+	if cfg.Alerts.WebhookURL != "" {
+		publisher = event.NewWebhookPublisher(cfg.Alerts.WebhookURL, cfg.Alerts.WebhookTimeout)
+	}
+	lowStock := usecase.NewLowStockMonitor(repo, publisher, cfg.Alerts.QueueSize)
+	go lowStock.Run(ctx)
+
+	ledger := usecase.NewStockLedger(repo, repos.variants, repos.movements, lowStock)
+	references := usecase.NewProductReferences(repos.variants, repos.lots, repos.serials, repos.productSuppliers, repos.bundles, repos.reservations)
+	uc := usecase.NewProductUseCase(repo, repos.variants, repos.priceHistory, references, lowStock, cfg.MaxBatchSize, cfg.TLS.AdminSubjects)
+	purchaseOrderUC := usecase.NewPurchaseOrderUseCase(repos.purchaseOrders, repo, repos.suppliers, ledger, cfg.OverDeliveryTolerance)
+	supplierUC := usecase.NewSupplierUseCase(repos.suppliers, repos.productSuppliers, repo)
+	bundleUC := usecase.NewBundleUseCase(repos.bundles, repos.reservations, repo, ledger)
+	lotUC := usecase.NewLotUseCase(repos.lots, repo, ledger)
+	stockUC := usecase.NewStockUseCase(repo, repos.variants, ledger, lotUC)
+	serialUC := usecase.NewSerialUseCase(repos.serials, repo, ledger)
+	auditUC := usecase.NewAuditUseCase(repos.auditEvents)
+	priceUC := usecase.NewPriceUseCase(repo, repos.priceHistory, repos.scheduledPrices)
+	cycleCountUC := usecase.NewCycleCountUseCase(repos.cycleCounts, repo, ledger)
+	returnUC := usecase.NewReturnUseCase(repos.returns, repo, ledger)
+	valuationUC := usecase.NewValuationUseCase(repo, repos.movements, repos.serials)
+	forecastUC := usecase.NewForecastUseCase(repo, repos.movements, repos.purchaseOrders, repos.productSuppliers, forecast.Params{
+		Method:       forecast.Method(cfg.Forecast.Method),
+		HistoryDays:  cfg.Forecast.HistoryDays,
+		WindowDays:   cfg.Forecast.WindowDays,
+		Alpha:        cfg.Forecast.Alpha,
+		ServiceLevel: cfg.Forecast.ServiceLevel,
+		LeadTimeDays: float64(cfg.Forecast.LeadTimeDays),
+		ReviewDays:   float64(cfg.Forecast.ReviewDays),
+	})
+	go lotUC.RunExpiryChecks(ctx, cfg.LotExpiryCheckInterval)
+	go uc.RunPurge(ctx, cfg.PurgeInterval, cfg.ArchiveRetention)
+	go priceUC.RunScheduler(ctx, cfg.PriceScheduleInterval)
+
+	serverOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(grpcdelivery.CallerInterceptor(), m.UnaryServerInterceptor()),
+	}
+	if cfg.RateLimit.RPS > 0 || len(cfg.RateLimit.Methods) > 0 {
+		methods := make(map[string]ratelimit.Limit, len(cfg.RateLimit.Methods))
+		for name, lim := range cfg.RateLimit.Methods {
+			methods[name] = ratelimit.Limit{Rate: lim.RPS, Burst: lim.Burst}
+		}
+		limiter := ratelimit.New(ratelimit.Limit{Rate: cfg.RateLimit.RPS, Burst: cfg.RateLimit.Burst}, methods, m)
+		// Throttled calls still pass the metrics interceptor, so they are
+		// counted as ResourceExhausted.
+		serverOpts = append(serverOpts, grpc.ChainUnaryInterceptor(limiter.UnaryServerInterceptor()))
+	}
+	if cfg.TLS.Enabled() {
+		tlsConfig, err := serverTLSConfig(cfg.TLS)
+		if err != nil {
+			log.Fatalf("failed to load TLS credentials: %v", err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	grpcServer := grpc.NewServer(serverOpts...)
+	pb.RegisterInventoryServiceServer(grpcServer, grpcdelivery.NewProductHandler(uc, stockUC))
+	pb.RegisterPurchaseOrderServiceServer(grpcServer, grpcdelivery.NewPurchaseOrderHandler(purchaseOrderUC))
+	pb.RegisterSupplierServiceServer(grpcServer, grpcdelivery.NewSupplierHandler(supplierUC))
+	pb.RegisterBundleServiceServer(grpcServer, grpcdelivery.NewBundleHandler(bundleUC))
+	pb.RegisterLotServiceServer(grpcServer, grpcdelivery.NewLotHandler(lotUC))
+	pb.RegisterSerialServiceServer(grpcServer, grpcdelivery.NewSerialHandler(serialUC))
+	pb.RegisterAuditServiceServer(grpcServer, grpcdelivery.NewAuditHandler(auditUC))
+	pb.RegisterPriceServiceServer(grpcServer, grpcdelivery.NewPriceHandler(priceUC))
+	pb.RegisterCycleCountServiceServer(grpcServer, grpcdelivery.NewCycleCountHandler(cycleCountUC))
+	pb.RegisterReturnServiceServer(grpcServer, grpcdelivery.NewReturnHandler(returnUC))
+	pb.RegisterValuationServiceServer(grpcServer, grpcdelivery.NewValuationHandler(valuationUC))
+	pb.RegisterForecastServiceServer(grpcServer, grpcdelivery.NewForecastHandler(forecastUC))
+
+	var services []string
+	for name := range grpcServer.GetServiceInfo() {
+		services = append(services, name)
+	}
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	checker := health.NewChecker(healthServer, ping, cfg.HealthCheckInterval, services...)
+	go checker.Run(ctx)
+
+	if cfg.Reflection {
+		reflection.Register(grpcServer)
+	}
+
+	gin.SetMode(gin.ReleaseMode)
+	httpServer := &http.Server{Addr: cfg.HTTPAddr, Handler: httpdelivery.NewRouter(reg, checker, cfg.MetricsEnabled)}
+
+	lis, err := net.Listen("tcp", cfg.GRPCAddr)
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+
+	go func() {
+		log.Printf("gRPC server listening on %s", lis.Addr())
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("gRPC server stopped: %v", err)
+		}
+	}()
+	go func() {
+		log.Printf("HTTP server listening on %s", httpServer.Addr)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("HTTP server stopped: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	_ = httpServer.Shutdown(shutdownCtx)
+	grpcServer.GracefulStop()
+}
+
+// serverTLSConfig loads the server certificate and, for mutual TLS, the CAs
+// client certificates are verified against.
+func serverTLSConfig(c config.TLSConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if c.ClientCAFile != "" {
+		pem, err := os.ReadFile(c.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in %s", c.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,152 @@
+// Command migrate applies or lists the schema migrations of the store the
+// inventory service is configured with: MongoDB, or PostgreSQL when
+// PRODUCT_STORE is postgres. The embedded bolt store has no migrations, so
+// with it the command does nothing. It takes the service's configuration flags and
+// environment, followed by an optional action:
+//
+//	migrate [flags] [up|status]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/facelessEmptiness/inventory_service/internal/config"
+	"github.com/facelessEmptiness/inventory_service/internal/migrate"
+)
+
+func main() {
+	args := os.Args[1:]
+	action := "up"
+	if n := len(args); n > 0 && (args[n-1] == "up" || args[n-1] == "status") {
+		action, args = args[n-1], args[:n-1]
+	}
+	cfg, err := config.Load(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	switch cfg.ProductStore {
+	case "bolt":
+		fmt.Println("nothing to migrate: PRODUCT_STORE is bolt")
+		return
+	case "postgres":
+		err = migratePostgres(ctx, cfg, action)
+	default:
+		err = migrateMongo(ctx, cfg, action)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func migrateMongo(ctx context.Context, cfg *config.Config, action string) error {
+	connectCtx, cancel := context.WithTimeout(ctx, cfg.Mongo.ConnectTimeout)
+	client, err := mongo.Connect(connectCtx, options.Client().ApplyURI(cfg.Mongo.URI))
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	migrator, err := migrate.New(client.Database(cfg.Mongo.Database), cfg.Mongo.ProductsCollection, migrate.Migrations(cfg.Mongo.ProductsCollection))
+	if err != nil {
+		return fmt.Errorf("invalid migrations: %w", err)
+	}
+	if action == "status" {
+		return status(ctx, migrator)
+	}
+	return up(ctx, migrator)
+}
+
+func migratePostgres(ctx context.Context, cfg *config.Config, action string) error {
+	connectCtx, cancel := context.WithTimeout(ctx, cfg.Postgres.ConnectTimeout)
+	pool, err := pgxpool.New(connectCtx, cfg.Postgres.URL)
+	if err == nil {
+		err = pool.Ping(connectCtx)
+	}
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+	defer pool.Close()
+
+	if action == "status" {
+		return statusPostgres(ctx, pool)
+	}
+	applied, err := migrate.UpPostgres(ctx, pool, migrate.PostgresMigrations())
+	for _, mig := range applied {
+		fmt.Printf("applied %d %s\n", mig.Version, mig.Description)
+	}
+	if err == nil && len(applied) == 0 {
+		fmt.Println("schema is up to date")
+	}
+	return err
+}
+
+func statusPostgres(ctx context.Context, pool *pgxpool.Pool) error {
+	applied, err := migrate.AppliedPostgres(ctx, pool)
+	if err != nil {
+		return err
+	}
+	done := make(map[int]bool, len(applied))
+	for _, r := range applied {
+		done[r.Version] = true
+		fmt.Printf("applied %d %s (%s)\n", r.Version, r.Description, r.AppliedAt.Format(time.RFC3339))
+	}
+	for _, mig := range migrate.PostgresMigrations() {
+		if !done[mig.Version] {
+			fmt.Printf("pending %d %s\n", mig.Version, mig.Description)
+		}
+	}
+	return nil
+}
+
+func up(ctx context.Context, m *migrate.Migrator) error {
+	applied, err := m.Up(ctx)
+	for _, mig := range applied {
+		fmt.Printf("applied %d %s\n", mig.Version, mig.Description)
+	}
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		fmt.Println("schema is up to date")
+	}
+	return nil
+}
+
+func status(ctx context.Context, m *migrate.Migrator) error {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return err
+	}
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range applied {
+		fmt.Printf("applied %d %s (%s)\n", r.Version, r.Description, r.AppliedAt.Format(time.RFC3339))
+	}
+	for _, mig := range pending {
+		fmt.Printf("pending %d %s\n", mig.Version, mig.Description)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+)
+
+// repositories holds the store of every collection the service keeps.
+type repositories struct {
+	products         repository.ProductRepository
+	auditEvents      repository.AuditRepository
+	movements        repository.StockMovementRepository
+	purchaseOrders   repository.PurchaseOrderRepository
+	suppliers        repository.SupplierRepository
+	productSuppliers repository.ProductSupplierRepository
+	variants         repository.VariantRepository
+	bundles          repository.BundleRepository
+	reservations     repository.ReservationRepository
+	lots             repository.LotRepository
+	serials          repository.SerialRepository
+	priceHistory     repository.PriceHistoryRepository
+	scheduledPrices  repository.ScheduledPriceRepository
+	cycleCounts      repository.CycleCountRepository
+	returns          repository.ReturnRepository
+}
+
+func mongoRepositories(db *mongo.Database, productsCollection string, timeout time.Duration) repositories {
+	return repositories{
+		products:         repository.NewMongoProductRepository(db, productsCollection, timeout),
+		auditEvents:      repository.NewMongoAuditRepository(db, timeout),
+		movements:        repository.NewMongoStockMovementRepository(db, timeout),
+		purchaseOrders:   repository.NewMongoPurchaseOrderRepository(db, timeout),
+		suppliers:        repository.NewMongoSupplierRepository(db, timeout),
+		productSuppliers: repository.NewMongoProductSupplierRepository(db, timeout),
+		variants:         repository.NewMongoVariantRepository(db, timeout),
+		bundles:          repository.NewMongoBundleRepository(db, timeout),
+		reservations:     repository.NewMongoReservationRepository(db, timeout),
+		lots:             repository.NewMongoLotRepository(db, timeout),
+		serials:          repository.NewMongoSerialRepository(db, timeout),
+		priceHistory:     repository.NewMongoPriceHistoryRepository(db, timeout),
+		scheduledPrices:  repository.NewMongoScheduledPriceRepository(db, timeout),
+		cycleCounts:      repository.NewMongoCycleCountRepository(db, timeout),
+		returns:          repository.NewMongoReturnRepository(db, timeout),
+	}
+}
+
+// postgresRepositories keeps every collection in PostgreSQL, so the service
+// runs without MongoDB.
+func postgresRepositories(pool *pgxpool.Pool, timeout time.Duration) repositories {
+	return repositories{
+		products:         repository.NewPostgresProductRepository(pool, timeout),
+		auditEvents:      repository.NewPostgresAuditRepository(pool, timeout),
+		movements:        repository.NewPostgresStockMovementRepository(pool, timeout),
+		purchaseOrders:   repository.NewPostgresPurchaseOrderRepository(pool, timeout),
+		suppliers:        repository.NewPostgresSupplierRepository(pool, timeout),
+		productSuppliers: repository.NewPostgresProductSupplierRepository(pool, timeout),
+		variants:         repository.NewPostgresVariantRepository(pool, timeout),
+		bundles:          repository.NewPostgresBundleRepository(pool, timeout),
+		reservations:     repository.NewPostgresReservationRepository(pool, timeout),
+		lots:             repository.NewPostgresLotRepository(pool, timeout),
+		serials:          repository.NewPostgresSerialRepository(pool, timeout),
+		priceHistory:     repository.NewPostgresPriceHistoryRepository(pool, timeout),
+		scheduledPrices:  repository.NewPostgresScheduledPriceRepository(pool, timeout),
+		cycleCounts:      repository.NewPostgresCycleCountRepository(pool, timeout),
+		returns:          repository.NewPostgresReturnRepository(pool, timeout),
+	}
+}
+
+// boltRepositories keeps every collection in one embedded file, so the
+// service runs without MongoDB.
+func boltRepositories(db *bolt.DB) (repositories, error) {
+	var err error
+	r := repositories{
+		products:         boltRepository(db, &err, repository.NewBoltProductRepository),
+		auditEvents:      boltRepository(db, &err, repository.NewBoltAuditRepository),
+		movements:        boltRepository(db, &err, repository.NewBoltStockMovementRepository),
+		purchaseOrders:   boltRepository(db, &err, repository.NewBoltPurchaseOrderRepository),
+		suppliers:        boltRepository(db, &err, repository.NewBoltSupplierRepository),
+		productSuppliers: boltRepository(db, &err, repository.NewBoltProductSupplierRepository),
+		variants:         boltRepository(db, &err, repository.NewBoltVariantRepository),
+		bundles:          boltRepository(db, &err, repository.NewBoltBundleRepository),
+		reservations:     boltRepository(db, &err, repository.NewBoltReservationRepository),
+		lots:             boltRepository(db, &err, repository.NewBoltLotRepository),
+		serials:          boltRepository(db, &err, repository.NewBoltSerialRepository),
+		priceHistory:     boltRepository(db, &err, repository.NewBoltPriceHistoryRepository),
+		scheduledPrices:  boltRepository(db, &err, repository.NewBoltScheduledPriceRepository),
+		cycleCounts:      boltRepository(db, &err, repository.NewBoltCycleCountRepository),
+		returns:          boltRepository(db, &err, repository.NewBoltReturnRepository),
+	}
+	return r, err
+}
+
+// boltRepository creates a repository unless an earlier one failed.
+func boltRepository[R any](db *bolt.DB, err *error, create func(*bolt.DB) (R, error)) R {
+	var r R
+	if *err == nil {
+		r, *err = create(db)
+	}
+	return r
+}
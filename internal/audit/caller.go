@@ -0,0 +1,52 @@
+// Package audit records who changed which product and how.
+package audit
+
+import (
+	"context"
+	"fmt"
+)
+
+// SystemActor is recorded for changes made by the service itself, such as
+// scheduled jobs, rather than on behalf of a caller.
+const SystemActor = "system"
+
+// Caller describes the request a change is made for. Actor is the subject of
+// the client certificate when Verified is set; otherwise it is only what the
+// caller claimed in its metadata, and Peer, its network address, is the one
+// thing known for certain.
+type Caller struct {
+	Actor     string
+	Verified  bool
+	Peer      string
+	RPC       string
+	RequestID string
+}
+
+// Attribution names the caller in records kept with the data, such as who
+// archived a product. An unverified claim is marked as such and paired with
+// the peer address, so it cannot pass for a certificate subject.
+func (c Caller) Attribution() string {
+	if c.Verified || c.Peer == "" {
+		return c.Actor
+	}
+	actor := c.Actor
+	if actor == "" {
+		actor = "unknown"
+	}
+	return fmt.Sprintf("%s (unverified, from %s)", actor, c.Peer)
+}
+
+type callerKey struct{}
+
+func WithCaller(ctx context.Context, c Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, c)
+}
+
+// CallerFrom returns the caller stored in ctx, or the system actor when the
+// change is not made on behalf of a request.
+func CallerFrom(ctx context.Context) Caller {
+	if c, ok := ctx.Value(callerKey{}).(Caller); ok {
+		return c
+	}
+	return Caller{Actor: SystemActor}
+}
@@ -0,0 +1,52 @@
+package audit
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+// Diff returns the fields that differ between two versions of a product. A
+// nil before or after stands for a product that does not exist.
+func Diff(before, after *domain.Product) []domain.FieldChange {
+	b, a := productFields(before), productFields(after)
+	var changes []domain.FieldChange
+	for i := range a {
+		if b[i].value != a[i].value {
+			changes = append(changes, domain.FieldChange{Field: a[i].name, Before: b[i].value, After: a[i].value})
+		}
+	}
+	return changes
+}
+
+type field struct {
+	name, value string
+}
+
+func productFields(p *domain.Product) []field {
+	if p == nil {
+		p = &domain.Product{}
+	}
+	var deletedAt string
+	if p.IsDeleted() {
+		deletedAt = p.DeletedAt.Format(time.RFC3339Nano)
+	}
+	return []field{
+		{"sku", p.SKU},
+		{"name", p.Name},
+		{"description", p.Description},
+		{"price", strconv.FormatFloat(p.Price, 'f', -1, 64)},
+		{"stock", strconv.Itoa(int(p.Stock))},
+		{"damaged_stock", strconv.Itoa(int(p.DamagedStock))},
+		{"category_id", p.CategoryID},
+		{"reorder_point", strconv.Itoa(int(p.ReorderPoint))},
+		{"reorder_quantity", strconv.Itoa(int(p.ReorderQuantity))},
+		{"variant_attributes", strings.Join(p.VariantAttributes, ",")},
+		{"lot_tracked", strconv.FormatBool(p.LotTracked)},
+		{"serialized", strconv.FormatBool(p.Serialized)},
+		{"deleted_at", deletedAt},
+		{"deleted_by", p.DeletedBy},
+	}
+}
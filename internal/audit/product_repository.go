@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+)
+
+// productRepo records an audit event for every product mutation made through
+// the wrapped repository. Recording is best effort: a failure is logged and
+// does not undo the mutation, which has already been committed.
+type productRepo struct {
+	repository.ProductRepository
+	events repository.AuditRepository
+}
+
+func NewProductRepository(r repository.ProductRepository, events repository.AuditRepository) repository.ProductRepository {
+	return &productRepo{ProductRepository: r, events: events}
+}
+
+func (r *productRepo) Create(ctx context.Context, p *domain.Product) (string, error) {
+	id, err := r.ProductRepository.Create(ctx, p)
+	if err != nil {
+		return "", err
+	}
+	r.record(ctx, id, domain.AuditCreate, nil, p)
+	return id, nil
+}
+
+func (r *productRepo) Update(ctx context.Context, id string, u *domain.ProductUpdate) (*domain.Product, *domain.Product, error) {
+	before, after, err := r.ProductRepository.Update(ctx, id, u)
+	if err != nil {
+		return nil, nil, err
+	}
+	r.record(ctx, id, domain.AuditUpdate, before, after)
+	return before, after, nil
+}
+
+func (r *productRepo) AdjustStock(ctx context.Context, id string, delta int32) (*domain.Product, error) {
+	after, err := r.ProductRepository.AdjustStock(ctx, id, delta)
+	if err != nil {
+		return nil, err
+	}
+	before := *after
+	before.Stock -= delta
+	r.record(ctx, id, domain.AuditStockChange, &before, after)
+	return after, nil
+}
+
+func (r *productRepo) AdjustDamagedStock(ctx context.Context, id string, delta int32) (*domain.Product, error) {
+	after, err := r.ProductRepository.AdjustDamagedStock(ctx, id, delta)
+	if err != nil {
+		return nil, err
+	}
+	before := *after
+	before.DamagedStock -= delta
+	r.record(ctx, id, domain.AuditStockChange, &before, after)
+	return after, nil
+}
+
+func (r *productRepo) SoftDelete(ctx context.Context, id, deletedBy string, at time.Time) (*domain.Product, error) {
+	after, err := r.ProductRepository.SoftDelete(ctx, id, deletedBy, at)
+	if err != nil {
+		return nil, err
+	}
+	before := *after
+	before.DeletedAt, before.DeletedBy = time.Time{}, ""
+	r.record(ctx, id, domain.AuditDelete, &before, after)
+	return after, nil
+}
+
+func (r *productRepo) Restore(ctx context.Context, id string) (*domain.Product, error) {
+	before, err := r.ProductRepository.GetByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	after, err := r.ProductRepository.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.record(ctx, id, domain.AuditRestore, before, after)
+	return after, nil
+}
+
+// PurgeDeleted records an event for each product actually removed, with the
+// product as it was listed just before.
+func (r *productRepo) PurgeDeleted(ctx context.Context, ids []string, before time.Time) ([]string, error) {
+	archived, err := r.ProductRepository.ListDeleted(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+	purged, err := r.ProductRepository.PurgeDeleted(ctx, ids, before)
+	byID := make(map[string]*domain.Product, len(archived))
+	for _, p := range archived {
+		byID[p.ID] = p
+	}
+	// Products removed before a failure are gone all the same.
+	for _, id := range purged {
+		r.record(ctx, id, domain.AuditPurge, byID[id], nil)
+	}
+	return purged, err
+}
+
+func (r *productRepo) record(ctx context.Context, productID string, action domain.AuditAction, before, after *domain.Product) {
+	c := CallerFrom(ctx)
+	e := &domain.AuditEvent{
+		ProductID:     productID,
+		Action:        action,
+		Actor:         c.Actor,
+		ActorVerified: c.Verified,
+		Peer:          c.Peer,
+		RPC:           c.RPC,
+		RequestID:     c.RequestID,
+		OccurredAt:    time.Now().UTC(),
+		Changes:       Diff(before, after),
+	}
+	// The event is recorded even when the request was cancelled after the
+	// mutation went through.
+	if err := r.events.Record(context.WithoutCancel(ctx), e); err != nil {
+		slog.ErrorContext(ctx, "audit: record event", "action", action, "product_id", productID, "err", err)
+	}
+}
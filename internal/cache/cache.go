@@ -0,0 +1,41 @@
+// Package cache keeps recently read products close to the service.
+package cache
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+const (
+	BackendNone   = "none"
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+// Store holds cached products by id. Implementations must hand out copies,
+// as callers are free to modify the products they get.
+type Store interface {
+	Get(ctx context.Context, id string) (*domain.Product, bool, error)
+	// Version returns a token that every Delete of the product changes.
+	Version(ctx context.Context, id string) (int64, error)
+	// Set caches p unless it has been deleted since version was read, so
+	// that a load racing with a change, on any replica, does not put the
+	// old product back.
+	Set(ctx context.Context, p *domain.Product, version int64) error
+	Delete(ctx context.Context, id string) error
+}
+
+// Recorder counts cache lookups.
+type Recorder interface {
+	CacheResult(backend string, hit bool)
+}
+
+// clone copies a product for caching. Variants are loaded separately by the
+// use cases and are never cached.
+func clone(p *domain.Product) *domain.Product {
+	c := *p
+	c.VariantAttributes = append([]string(nil), p.VariantAttributes...)
+	c.Variants = nil
+	return &c
+}
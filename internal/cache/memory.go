@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+// MemoryStore is an in-process LRU cache whose entries expire after a TTL.
+// Each replica has its own, so a change made through another replica is only
+// seen once the entry expires.
+type MemoryStore struct {
+	lru *expirable.LRU[string, *domain.Product]
+
+	mu sync.Mutex
+	// version counts deletions of any product; one counter is coarser than
+	// one per product but takes no memory per id.
+	version int64
+}
+
+func NewMemoryStore(size int, ttl time.Duration) *MemoryStore {
+	return &MemoryStore{lru: expirable.NewLRU[string, *domain.Product](size, nil, ttl)}
+}
+
+func (s *MemoryStore) Get(_ context.Context, id string) (*domain.Product, bool, error) {
+	p, ok := s.lru.Get(id)
+	if !ok {
+		return nil, false, nil
+	}
+	return clone(p), true, nil
+}
+
+func (s *MemoryStore) Version(_ context.Context, _ string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.version, nil
+}
+
+func (s *MemoryStore) Set(_ context.Context, p *domain.Product, version int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if version == s.version {
+		s.lru.Add(p.ID, clone(p))
+	}
+	return nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.version++
+	s.lru.Remove(id)
+	return nil
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// productRepo serves GetByID from a Store and drops a product from it
+// whenever the product changes. Concurrent misses for the same product share
+// one load. Cache failures are logged and fall through to the repository.
+type productRepo struct {
+	repository.ProductRepository
+	store    Store
+	backend  string
+	recorder Recorder
+	loads    singleflight.Group
+}
+
+func NewProductRepository(r repository.ProductRepository, store Store, backend string, recorder Recorder) repository.ProductRepository {
+	return &productRepo{
+		ProductRepository: r,
+		store:             store,
+		backend:           backend,
+		recorder:          recorder,
+	}
+}
+
+func (r *productRepo) GetByID(ctx context.Context, id string) (*domain.Product, error) {
+	p, ok, err := r.store.Get(ctx, id)
+	if err != nil {
+		slog.WarnContext(ctx, "cache: get product", "product_id", id, "err", err)
+	}
+	r.recorder.CacheResult(r.backend, ok)
+	if ok {
+		return p, nil
+	}
+
+	v, err, _ := r.loads.Do(id, func() (interface{}, error) {
+		// The load is shared, so one caller giving up must not fail the
+		// others; the repository timeout still bounds it.
+		ctx := context.WithoutCancel(ctx)
+		// The version is read before loading, so that a change made
+		// meanwhile keeps the loaded product out of the cache.
+		version, verr := r.store.Version(ctx, id)
+		if verr != nil {
+			slog.WarnContext(ctx, "cache: read product version", "product_id", id, "err", verr)
+		}
+		p, err := r.ProductRepository.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if verr == nil {
+			if err := r.store.Set(ctx, p, version); err != nil {
+				slog.WarnContext(ctx, "cache: set product", "product_id", id, "err", err)
+			}
+		}
+		return p, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Every waiter gets its own copy of the shared result.
+	return clone(v.(*domain.Product)), nil
+}
+
+func (r *productRepo) Update(ctx context.Context, id string, u *domain.ProductUpdate) (*domain.Product, *domain.Product, error) {
+	defer r.invalidate(ctx, id)
+	return r.ProductRepository.Update(ctx, id, u)
+}
+
+func (r *productRepo) AdjustStock(ctx context.Context, id string, delta int32) (*domain.Product, error) {
+	defer r.invalidate(ctx, id)
+	return r.ProductRepository.AdjustStock(ctx, id, delta)
+}
+
+func (r *productRepo) AdjustDamagedStock(ctx context.Context, id string, delta int32) (*domain.Product, error) {
+	defer r.invalidate(ctx, id)
+	return r.ProductRepository.AdjustDamagedStock(ctx, id, delta)
+}
+
+func (r *productRepo) SoftDelete(ctx context.Context, id, deletedBy string, at time.Time) (*domain.Product, error) {
+	defer r.invalidate(ctx, id)
+	return r.ProductRepository.SoftDelete(ctx, id, deletedBy, at)
+}
+
+func (r *productRepo) Restore(ctx context.Context, id string) (*domain.Product, error) {
+	defer r.invalidate(ctx, id)
+	return r.ProductRepository.Restore(ctx, id)
+}
+
+// invalidate drops a product from the cache. It runs after the change has
+// been attempted, failed or not, as a failed write may still have applied.
+func (r *productRepo) invalidate(ctx context.Context, id string) {
+	if err := r.store.Delete(context.WithoutCancel(ctx), id); err != nil {
+		slog.ErrorContext(ctx, "cache: invalidate product", "product_id", id, "err", err)
+	}
+}
@@ -0,0 +1,205 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+)
+
+// fakeRepo holds one product. While gate is set, GetByID reads the product
+// and then waits for gate to close, which lets a change slip in between.
+type fakeRepo struct {
+	repository.ProductRepository
+
+	mu      sync.Mutex
+	product domain.Product
+	loads   int
+	gate    chan struct{}
+	loading chan struct{}
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{product: domain.Product{ID: "p1", Name: "Widget", Stock: 10, VariantAttributes: []string{"size"}}}
+}
+
+func (f *fakeRepo) GetByID(_ context.Context, id string) (*domain.Product, error) {
+	f.mu.Lock()
+	if id != f.product.ID {
+		f.mu.Unlock()
+		return nil, domain.ErrNotFound
+	}
+	f.loads++
+	p := f.product
+	p.VariantAttributes = append([]string(nil), f.product.VariantAttributes...)
+	gate, loading := f.gate, f.loading
+	f.mu.Unlock()
+	if gate != nil {
+		if loading != nil {
+			loading <- struct{}{}
+		}
+		<-gate
+	}
+	return &p, nil
+}
+
+func (f *fakeRepo) AdjustStock(_ context.Context, id string, delta int32) (*domain.Product, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.product.Stock += delta
+	p := f.product
+	return &p, nil
+}
+
+func (f *fakeRepo) loadCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.loads
+}
+
+type fakeRecorder struct {
+	mu           sync.Mutex
+	hits, misses int
+}
+
+func (r *fakeRecorder) CacheResult(_ string, hit bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if hit {
+		r.hits++
+	} else {
+		r.misses++
+	}
+}
+
+func TestProductRepositoryServesCopiesFromCache(t *testing.T) {
+	ctx := context.Background()
+	repo, rec := newFakeRepo(), &fakeRecorder{}
+	cached := NewProductRepository(repo, NewMemoryStore(10, time.Minute), BackendMemory, rec)
+
+	p, err := cached.GetByID(ctx, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Name, p.VariantAttributes[0] = "changed", "changed"
+
+	again, err := cached.GetByID(ctx, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Name != "Widget" || again.VariantAttributes[0] != "size" {
+		t.Errorf("cached product = %+v, want it untouched by the caller", again)
+	}
+	if n := repo.loadCount(); n != 1 {
+		t.Errorf("repository loads = %d, want 1", n)
+	}
+	if rec.hits != 1 || rec.misses != 1 {
+		t.Errorf("hits %d, misses %d; want 1 and 1", rec.hits, rec.misses)
+	}
+	if _, err := cached.GetByID(ctx, "missing"); err != domain.ErrNotFound {
+		t.Errorf("GetByID(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestProductRepositoryInvalidatesOnChange(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeRepo()
+	cached := NewProductRepository(repo, NewMemoryStore(10, time.Minute), BackendMemory, &fakeRecorder{})
+
+	if _, err := cached.GetByID(ctx, "p1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cached.AdjustStock(ctx, "p1", -3); err != nil {
+		t.Fatal(err)
+	}
+	p, err := cached.GetByID(ctx, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Stock != 7 || repo.loadCount() != 2 {
+		t.Errorf("after change: stock %d, loads %d; want 7 and 2", p.Stock, repo.loadCount())
+	}
+}
+
+// testStaleLoad has a load through one decorator race with a change through
+// another sharing the same store, as two replicas would, and checks that the
+// product read before the change is not cached.
+func testStaleLoad(t *testing.T, store Store) {
+	ctx := context.Background()
+	repo := newFakeRepo()
+	replicaA := NewProductRepository(repo, store, "test", &fakeRecorder{})
+	replicaB := NewProductRepository(repo, store, "test", &fakeRecorder{})
+
+	repo.gate, repo.loading = make(chan struct{}), make(chan struct{})
+	done := make(chan *domain.Product)
+	go func() {
+		p, err := replicaA.GetByID(ctx, "p1")
+		if err != nil {
+			t.Error(err)
+		}
+		done <- p
+	}()
+	<-repo.loading
+	if _, err := replicaB.AdjustStock(ctx, "p1", -4); err != nil {
+		t.Fatal(err)
+	}
+	close(repo.gate)
+	if p := <-done; p.Stock != 10 {
+		t.Fatalf("racing load returned stock %d, want the 10 it read", p.Stock)
+	}
+
+	repo.mu.Lock()
+	repo.gate, repo.loading = nil, nil
+	repo.mu.Unlock()
+	p, err := replicaA.GetByID(ctx, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Stock != 6 {
+		t.Errorf("stock after the race = %d, want 6: the stale load was cached", p.Stock)
+	}
+}
+
+func TestProductRepositoryDoesNotCacheStaleLoad(t *testing.T) {
+	testStaleLoad(t, NewMemoryStore(10, time.Minute))
+}
+
+func TestProductRepositorySharesConcurrentLoads(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeRepo()
+	repo.gate = make(chan struct{})
+	cached := NewProductRepository(repo, NewMemoryStore(10, time.Minute), BackendMemory, &fakeRecorder{})
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make([]*domain.Product, callers)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p, err := cached.GetByID(ctx, "p1")
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = p
+		}()
+	}
+	// Give every caller time to join the load before it completes.
+	time.Sleep(50 * time.Millisecond)
+	close(repo.gate)
+	wg.Wait()
+
+	if n := repo.loadCount(); n != 1 {
+		t.Errorf("repository loads = %d, want 1", n)
+	}
+	for i, p := range results {
+		for j := range i {
+			if p == results[j] {
+				t.Fatalf("callers %d and %d got the same product value", j, i)
+			}
+		}
+	}
+}
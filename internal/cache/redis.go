@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// A product and its version share a hash tag, so that they live on the same
+// cluster node and one script can check the one and set the other.
+const (
+	redisKeyPrefix     = "inventory:product:"
+	redisVersionPrefix = "inventory:product-version:"
+)
+
+// redisVersionTTL keeps a version long enough to outlive any load that read
+// it; once it has expired it reads as zero again, which a load that read it
+// before no longer matches.
+const redisVersionTTL = time.Hour
+
+// redisSetIfVersion sets KEYS[1] to ARGV[2], expiring in ARGV[3]
+// milliseconds, when the version at KEYS[2] is still ARGV[1].
+var redisSetIfVersion = redis.NewScript(`
+if (redis.call('GET', KEYS[2]) or '0') ~= ARGV[1] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+return 1
+`)
+
+// RedisStore caches products as JSON in any Redis-compatible server, shared
+// by all replicas. Every product has a version there too, which Delete
+// increments and Set checks.
+type RedisStore struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+}
+
+func NewRedisStore(client redis.UniversalClient, ttl time.Duration) *RedisStore {
+	return &RedisStore{client: client, ttl: ttl}
+}
+
+func (s *RedisStore) Get(ctx context.Context, id string) (*domain.Product, bool, error) {
+	b, err := s.client.Get(ctx, productKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var p domain.Product
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, false, err
+	}
+	return &p, true, nil
+}
+
+func (s *RedisStore) Version(ctx context.Context, id string) (int64, error) {
+	v, err := s.client.Get(ctx, versionKey(id)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return v, err
+}
+
+func (s *RedisStore) Set(ctx context.Context, p *domain.Product, version int64) error {
+	b, err := json.Marshal(clone(p))
+	if err != nil {
+		return err
+	}
+	return redisSetIfVersion.Run(ctx, s.client, []string{productKey(p.ID), versionKey(p.ID)},
+		version, b, s.ttl.Milliseconds()).Err()
+}
+
+func (s *RedisStore) Delete(ctx context.Context, id string) error {
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Incr(ctx, versionKey(id))
+		pipe.Expire(ctx, versionKey(id), redisVersionTTL)
+		pipe.Del(ctx, productKey(id))
+		return nil
+	})
+	return err
+}
+
+func productKey(id string) string {
+	return redisKeyPrefix + "{" + id + "}"
+}
+
+func versionKey(id string) string {
+	return redisVersionPrefix + "{" + id + "}"
+}
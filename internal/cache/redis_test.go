@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+func newTestRedisStore(t *testing.T, ttl time.Duration) (*RedisStore, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisStore(client, ttl), server
+}
+
+func TestRedisStore(t *testing.T) {
+	ctx := context.Background()
+	store, server := newTestRedisStore(t, time.Minute)
+	p := &domain.Product{ID: "p1", Name: "Widget", Stock: 10, VariantAttributes: []string{"size"}}
+
+	if _, ok, err := store.Get(ctx, "p1"); err != nil || ok {
+		t.Fatalf("Get(empty) = %v, %v; want a miss", ok, err)
+	}
+	v, err := store.Version(ctx, "p1")
+	if err != nil || v != 0 {
+		t.Fatalf("Version(new) = %d, %v; want 0", v, err)
+	}
+	if err := store.Set(ctx, p, v); err != nil {
+		t.Fatal(err)
+	}
+	got, ok, err := store.Get(ctx, "p1")
+	if err != nil || !ok || got.Name != "Widget" || got.Stock != 10 || got.VariantAttributes[0] != "size" {
+		t.Fatalf("Get = %+v, %v, %v; want the product", got, ok, err)
+	}
+
+	if err := store.Delete(ctx, "p1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := store.Get(ctx, "p1"); ok {
+		t.Error("Get after Delete hit")
+	}
+	// A load that read the version before the delete must not be cached.
+	if err := store.Set(ctx, p, v); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := store.Get(ctx, "p1"); ok {
+		t.Error("Set with a stale version was cached")
+	}
+	if v, err = store.Version(ctx, "p1"); err != nil || v != 1 {
+		t.Fatalf("Version after Delete = %d, %v; want 1", v, err)
+	}
+	if err := store.Set(ctx, p, v); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := store.Get(ctx, "p1"); !ok {
+		t.Error("Set with the current version was not cached")
+	}
+
+	server.FastForward(time.Minute + time.Second)
+	if _, ok, _ := store.Get(ctx, "p1"); ok {
+		t.Error("Get after the TTL hit")
+	}
+}
+
+func TestRedisStoreDoesNotCacheStaleLoad(t *testing.T) {
+	store, _ := newTestRedisStore(t, time.Minute)
+	testStaleLoad(t, store)
+}
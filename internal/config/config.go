@@ -0,0 +1,573 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+type Config struct {
+	GRPCAddr string
+	HTTPAddr string
+
+	Mongo MongoConfig
+	TLS   TLSConfig
+
+	// ProductStore is where data is kept: mongo, postgres or bolt. Each
+	// holds every collection, so only the settings of the chosen store are
+	// used; bolt is an embedded file at BoltPath.
+	ProductStore    string
+	Postgres        PostgresConfig
+	BoltPath        string
+	BoltOpenTimeout time.Duration
+	// MigrateOnStart applies pending schema migrations of the chosen store
+	// before serving.
+	MigrateOnStart bool
+
+	LogLevel slog.Level
+
+	Reflection          bool
+	MetricsEnabled      bool
+	TracingExporter     string
+	OTLPEndpoint        string
+	HealthCheckInterval time.Duration
+	ShutdownTimeout     time.Duration
+
+	Alerts AlertsConfig
+
+	// OverDeliveryTolerance is the fraction of an ordered quantity that may
+	// be received on top of it before a receipt is rejected.
+	OverDeliveryTolerance float64
+
+	// LotExpiryCheckInterval is how often expired lots are quarantined.
+	LotExpiryCheckInterval time.Duration
+	// ArchiveRetention is how long soft-deleted products are kept before
+	// PurgeInterval's sweep removes them for good.
+	ArchiveRetention time.Duration
+	PurgeInterval    time.Duration
+	// PriceScheduleInterval is how often due scheduled price changes are
+	// applied, and so how late after its start one may take effect.
+	PriceScheduleInterval time.Duration
+	Cache                 CacheConfig
+	// MaxBatchSize caps the ids and SKUs of one GetProducts call.
+	MaxBatchSize int
+	Forecast     ForecastConfig
+	RateLimit    RateLimitConfig
+}
+
+// RateLimitConfig bounds how fast each client may call each RPC; an RPS of 0
+// turns limiting off, which is the default: without client certificates
+// callers are told apart by address only, and everyone behind one proxy or
+// NAT would share a bucket. Methods overrides the limit per RPC, keyed by
+// full method or bare RPC name.
+type RateLimitConfig struct {
+	RPS     float64
+	Burst   int
+	Methods map[string]RateLimit
+}
+
+type RateLimit struct {
+	RPS   float64
+	Burst int
+}
+
+// ForecastConfig holds the defaults for demand forecasts and reorder
+// suggestions; requests may override each of them. Method is moving_average
+// or exponential_smoothing.
+type ForecastConfig struct {
+	Method       string
+	HistoryDays  int
+	WindowDays   int
+	Alpha        float64
+	ServiceLevel float64
+	// LeadTimeDays applies to products without a supplier lead time.
+	LeadTimeDays int
+	ReviewDays   int
+}
+
+// CacheConfig configures the product cache. Backend is none, memory or redis;
+// it is none by default, as each replica's memory cache serves stock that
+// another replica changed for up to TTL.
+type CacheConfig struct {
+	Backend  string
+	TTL      time.Duration
+	Size     int
+	RedisURL string
+}
+
+type MongoConfig struct {
+	URI                string
+	Database           string
+	ProductsCollection string
+	Timeout            time.Duration
+	ConnectTimeout     time.Duration
+}
+
+type PostgresConfig struct {
+	URL            string
+	Timeout        time.Duration
+	ConnectTimeout time.Duration
+}
+
+type AlertsConfig struct {
+	WebhookURL     string
+	WebhookTimeout time.Duration
+	QueueSize      int
+}
+
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile makes clients present a certificate signed by one of its
+	// CAs; the certificate subject then identifies the caller.
+	ClientCAFile string
+	// AdminSubjects are the client certificate subjects allowed to read
+	// archived products.
+	AdminSubjects []string
+}
+
+func (t TLSConfig) Enabled() bool {
+	return t.CertFile != "" || t.KeyFile != ""
+}
+
+func defaults() *Config {
+	return &Config{
+		GRPCAddr: ":50051",
+		HTTPAddr: ":8080",
+		Mongo: MongoConfig{
+			Database:           "inventory",
+			ProductsCollection: "products",
+			Timeout:            5 * time.Second,
+			ConnectTimeout:     10 * time.Second,
+		},
+		ProductStore:    "mongo",
+		BoltPath:        "inventory.db",
+		BoltOpenTimeout: 10 * time.Second,
+		MigrateOnStart:  true,
+		Postgres: PostgresConfig{
+			Timeout:        5 * time.Second,
+			ConnectTimeout: 10 * time.Second,
+		},
+		LogLevel:               slog.LevelInfo,
+		MetricsEnabled:         true,
+		TracingExporter:        "none",
+		HealthCheckInterval:    5 * time.Second,
+		ShutdownTimeout:        10 * time.Second,
+		LotExpiryCheckInterval: time.Hour,
+		ArchiveRetention:       90 * 24 * time.Hour,
+		PurgeInterval:          24 * time.Hour,
+		PriceScheduleInterval:  time.Minute,
+		MaxBatchSize:           100,
+		Alerts: AlertsConfig{
+			WebhookTimeout: 5 * time.Second,
+			QueueSize:      1024,
+		},
+		Cache: CacheConfig{
+			Backend: "none",
+			TTL:     30 * time.Second,
+			Size:    10000,
+		},
+		Forecast: ForecastConfig{
+			Method:       "moving_average",
+			HistoryDays:  90,
+			WindowDays:   28,
+			Alpha:        0.3,
+			ServiceLevel: 0.95,
+			LeadTimeDays: 7,
+			ReviewDays:   7,
+		},
+		RateLimit: RateLimitConfig{
+			Burst: 200,
+		},
+	}
+}
+
+// setting binds one configuration value to its environment variable and
+// command-line flag.
+type setting struct {
+	env   string
+	flag  string
+	usage string
+	parse func(string) error
+	def   string
+}
+
+func (c *Config) settings() []setting {
+	return []setting{
+		stringSetting("GRPC_ADDR", "grpc-addr", "gRPC listen address", &c.GRPCAddr),
+		stringSetting("HTTP_ADDR", "http-addr", "HTTP listen address for metrics and probes", &c.HTTPAddr),
+		stringSetting("MONGO_URI", "mongo-uri", "MongoDB connection URI (required when PRODUCT_STORE is mongo)", &c.Mongo.URI),
+		stringSetting("MONGO_DATABASE", "mongo-database", "MongoDB database name", &c.Mongo.Database),
+		stringSetting("MONGO_PRODUCTS_COLLECTION", "mongo-products-collection", "MongoDB collection holding products", &c.Mongo.ProductsCollection),
+		durationSetting("MONGO_TIMEOUT", "mongo-timeout", "timeout for a single MongoDB operation", &c.Mongo.Timeout),
+		durationSetting("MONGO_CONNECT_TIMEOUT", "mongo-connect-timeout", "timeout for the initial MongoDB connection", &c.Mongo.ConnectTimeout),
+		boolSetting("MIGRATE_ON_START", "migrate-on-start", "apply pending migrations of the chosen store at startup", &c.MigrateOnStart),
+		stringSetting("PRODUCT_STORE", "product-store", "where data is kept (mongo, postgres or bolt)", &c.ProductStore),
+		stringSetting("POSTGRES_URL", "postgres-url", "postgres:// URL of the database when PRODUCT_STORE is postgres", &c.Postgres.URL),
+		durationSetting("POSTGRES_TIMEOUT", "postgres-timeout", "timeout for a single PostgreSQL statement", &c.Postgres.Timeout),
+		durationSetting("POSTGRES_CONNECT_TIMEOUT", "postgres-connect-timeout", "timeout for the initial PostgreSQL connection", &c.Postgres.ConnectTimeout),
+		stringSetting("BOLT_PATH", "bolt-path", "data file of the embedded store when PRODUCT_STORE is bolt", &c.BoltPath),
+		durationSetting("BOLT_OPEN_TIMEOUT", "bolt-open-timeout", "how long to wait for another process to release the bolt file", &c.BoltOpenTimeout),
+		stringSetting("TLS_CERT_FILE", "tls-cert-file", "PEM certificate for the gRPC server; enables TLS together with the key", &c.TLS.CertFile),
+		stringSetting("TLS_KEY_FILE", "tls-key-file", "PEM private key for the gRPC server", &c.TLS.KeyFile),
+		stringSetting("TLS_CLIENT_CA_FILE", "tls-client-ca-file", "PEM CAs client certificates must be signed by; enables mutual TLS", &c.TLS.ClientCAFile),
+		{
+			env: "ADMIN_SUBJECTS", flag: "admin-subjects",
+			usage: "comma-separated client certificate subjects allowed to read archived products",
+			parse: func(s string) error {
+				c.TLS.AdminSubjects = nil
+				for _, subject := range strings.Split(s, ",") {
+					if subject = strings.TrimSpace(subject); subject != "" {
+						c.TLS.AdminSubjects = append(c.TLS.AdminSubjects, subject)
+					}
+				}
+				return nil
+			},
+		},
+		{
+			env: "LOG_LEVEL", flag: "log-level", usage: "log level (debug, info, warn, error)",
+			parse: func(s string) error { return c.LogLevel.UnmarshalText([]byte(s)) },
+			def:   strings.ToLower(c.LogLevel.String()),
+		},
+		boolSetting("GRPC_REFLECTION", "grpc-reflection", "register the gRPC reflection service", &c.Reflection),
+		boolSetting("METRICS_ENABLED", "metrics-enabled", "serve Prometheus metrics on /metrics", &c.MetricsEnabled),
+		stringSetting("TRACING_EXPORTER", "tracing-exporter", "trace exporter (none, stdout, otlp)", &c.TracingExporter),
+		stringSetting("OTLP_ENDPOINT", "otlp-endpoint", "OTLP gRPC collector endpoint", &c.OTLPEndpoint),
+		durationSetting("HEALTH_CHECK_INTERVAL", "health-check-interval", "interval between MongoDB health checks", &c.HealthCheckInterval),
+		durationSetting("SHUTDOWN_TIMEOUT", "shutdown-timeout", "grace period for in-flight requests on shutdown", &c.ShutdownTimeout),
+		stringSetting("ALERT_WEBHOOK_URL", "alert-webhook-url", "URL receiving low-stock alerts; alerts are only logged when empty", &c.Alerts.WebhookURL),
+		durationSetting("ALERT_WEBHOOK_TIMEOUT", "alert-webhook-timeout", "timeout for a single webhook delivery", &c.Alerts.WebhookTimeout),
+		floatSetting("PO_OVER_DELIVERY_TOLERANCE", "po-over-delivery-tolerance", "fraction of an ordered quantity accepted on top of it when receiving goods", &c.OverDeliveryTolerance),
+		durationSetting("LOT_EXPIRY_CHECK_INTERVAL", "lot-expiry-check-interval", "how often expired lots are moved to quarantine", &c.LotExpiryCheckInterval),
+		durationSetting("ARCHIVE_RETENTION", "archive-retention", "how long soft-deleted products are kept before they are purged", &c.ArchiveRetention),
+		durationSetting("PURGE_INTERVAL", "purge-interval", "how often archived products past their retention are purged", &c.PurgeInterval),
+		durationSetting("PRICE_SCHEDULE_INTERVAL", "price-schedule-interval", "how often due scheduled price changes are applied", &c.PriceScheduleInterval),
+		intSetting("ALERT_QUEUE_SIZE", "alert-queue-size", "number of pending stock evaluations buffered for the low-stock monitor", &c.Alerts.QueueSize),
+		intSetting("MAX_BATCH_SIZE", "max-batch-size", "maximum number of ids and SKUs in one GetProducts call", &c.MaxBatchSize),
+		stringSetting("CACHE_BACKEND", "cache-backend", "product cache backend (none, memory, redis)", &c.Cache.Backend),
+		durationSetting("CACHE_TTL", "cache-ttl", "how long a cached product is served", &c.Cache.TTL),
+		intSetting("CACHE_SIZE", "cache-size", "maximum number of products in the memory cache", &c.Cache.Size),
+		stringSetting("REDIS_URL", "redis-url", "redis:// URL of the cache server when CACHE_BACKEND is redis", &c.Cache.RedisURL),
+		stringSetting("FORECAST_METHOD", "forecast-method", "demand forecast method (moving_average, exponential_smoothing)", &c.Forecast.Method),
+		intSetting("FORECAST_HISTORY_DAYS", "forecast-history-days", "days of sales history a forecast is based on", &c.Forecast.HistoryDays),
+		intSetting("FORECAST_WINDOW_DAYS", "forecast-window-days", "days averaged by the moving average forecast", &c.Forecast.WindowDays),
+		floatSetting("FORECAST_ALPHA", "forecast-alpha", "smoothing factor of the exponential smoothing forecast", &c.Forecast.Alpha),
+		floatSetting("FORECAST_SERVICE_LEVEL", "forecast-service-level", "target probability of not running out of stock between replenishments", &c.Forecast.ServiceLevel),
+		intSetting("FORECAST_LEAD_TIME_DAYS", "forecast-lead-time-days", "replenishment lead time of products without a supplier lead time", &c.Forecast.LeadTimeDays),
+		intSetting("FORECAST_REVIEW_DAYS", "forecast-review-days", "days of demand a suggested order covers beyond the reorder point", &c.Forecast.ReviewDays),
+		floatSetting("RATE_LIMIT_RPS", "rate-limit-rps", "calls per second each client, by certificate subject or else address, may make of each RPC; 0 (the default) disables rate limiting", &c.RateLimit.RPS),
+		intSetting("RATE_LIMIT_BURST", "rate-limit-burst", "calls a client may make of an RPC in a burst above RATE_LIMIT_RPS", &c.RateLimit.Burst),
+		{
+			env: "RATE_LIMIT_METHODS", flag: "rate-limit-methods",
+			usage: "per-RPC limits as Method=rps[:burst],...; an rps of 0 leaves the RPC unlimited",
+			parse: func(s string) (err error) {
+				c.RateLimit.Methods, err = parseRateLimits(s)
+				return err
+			},
+		},
+	}
+}
+
+// Load builds the configuration from defaults, the environment (including an
+// optional .env file) and command-line flags, in increasing order of
+// precedence. Every invalid or missing value is reported, not just the first.
+func Load(args []string) (*Config, error) {
+	cfg := defaults()
+	settings := cfg.settings()
+
+	fs := flag.NewFlagSet("inventory_service", flag.ContinueOnError)
+	envFile := fs.String("env-file", ".env", "file with environment variables; ignored when the default is missing")
+	for _, s := range settings {
+		fs.String(s.flag, s.def, fmt.Sprintf("%s (env %s)", s.usage, s.env))
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	if err := godotenv.Load(*envFile); err != nil {
+		if !errors.Is(err, os.ErrNotExist) || explicit["env-file"] {
+			return nil, fmt.Errorf("load env file %s: %w", *envFile, err)
+		}
+	}
+
+	var errs []error
+	for _, s := range settings {
+		raw, ok := "", false
+		if explicit[s.flag] {
+			raw, ok = fs.Lookup(s.flag).Value.String(), true
+		} else {
+			raw, ok = os.LookupEnv(s.env)
+		}
+		if !ok {
+			continue
+		}
+		if err := s.parse(raw); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s %q: %w", s.env, raw, err))
+		}
+	}
+	errs = append(errs, cfg.validate()...)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return cfg, nil
+}
+
+func (c *Config) validate() []error {
+	var errs []error
+	for _, a := range []struct{ name, addr string }{
+		{"GRPC_ADDR", c.GRPCAddr},
+		{"HTTP_ADDR", c.HTTPAddr},
+	} {
+		if _, _, err := net.SplitHostPort(a.addr); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s %q: %w", a.name, a.addr, err))
+		}
+	}
+
+	if c.ProductStore == "mongo" {
+		switch {
+		case c.Mongo.URI == "":
+			errs = append(errs, errors.New("MONGO_URI is required"))
+		case !strings.HasPrefix(c.Mongo.URI, "mongodb://") && !strings.HasPrefix(c.Mongo.URI, "mongodb+srv://"):
+			errs = append(errs, fmt.Errorf("invalid MONGO_URI: must start with mongodb:// or mongodb+srv://"))
+		}
+		if c.Mongo.Database == "" {
+			errs = append(errs, errors.New("MONGO_DATABASE must not be empty"))
+		}
+		if c.Mongo.ProductsCollection == "" {
+			errs = append(errs, errors.New("MONGO_PRODUCTS_COLLECTION must not be empty"))
+		}
+	}
+
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"MONGO_TIMEOUT", c.Mongo.Timeout},
+		{"MONGO_CONNECT_TIMEOUT", c.Mongo.ConnectTimeout},
+		{"POSTGRES_TIMEOUT", c.Postgres.Timeout},
+		{"POSTGRES_CONNECT_TIMEOUT", c.Postgres.ConnectTimeout},
+		{"BOLT_OPEN_TIMEOUT", c.BoltOpenTimeout},
+		{"HEALTH_CHECK_INTERVAL", c.HealthCheckInterval},
+		{"SHUTDOWN_TIMEOUT", c.ShutdownTimeout},
+		{"ALERT_WEBHOOK_TIMEOUT", c.Alerts.WebhookTimeout},
+		{"LOT_EXPIRY_CHECK_INTERVAL", c.LotExpiryCheckInterval},
+		{"ARCHIVE_RETENTION", c.ArchiveRetention},
+		{"PURGE_INTERVAL", c.PurgeInterval},
+		{"PRICE_SCHEDULE_INTERVAL", c.PriceScheduleInterval},
+	} {
+		if d.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %s", d.name, d.value))
+		}
+	}
+
+	if c.TLS.Enabled() {
+		if c.TLS.CertFile == "" || c.TLS.KeyFile == "" {
+			errs = append(errs, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together"))
+		}
+		for _, f := range []struct{ name, path string }{
+			{"TLS_CERT_FILE", c.TLS.CertFile},
+			{"TLS_KEY_FILE", c.TLS.KeyFile},
+			{"TLS_CLIENT_CA_FILE", c.TLS.ClientCAFile},
+		} {
+			if f.path == "" {
+				continue
+			}
+			if _, err := os.Stat(f.path); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", f.name, err))
+			}
+		}
+	}
+
+	if c.TLS.ClientCAFile != "" && !c.TLS.Enabled() {
+		errs = append(errs, errors.New("TLS_CLIENT_CA_FILE requires TLS_CERT_FILE and TLS_KEY_FILE"))
+	}
+	if len(c.TLS.AdminSubjects) > 0 && c.TLS.ClientCAFile == "" {
+		errs = append(errs, errors.New("ADMIN_SUBJECTS requires TLS_CLIENT_CA_FILE, as only verified certificates name admins"))
+	}
+
+	if c.Alerts.WebhookURL != "" {
+		if u, err := url.Parse(c.Alerts.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid ALERT_WEBHOOK_URL %q: must be an absolute http(s) URL", c.Alerts.WebhookURL))
+		}
+	}
+	if c.Alerts.QueueSize <= 0 {
+		errs = append(errs, fmt.Errorf("ALERT_QUEUE_SIZE must be positive, got %d", c.Alerts.QueueSize))
+	}
+
+	if c.MaxBatchSize <= 0 {
+		errs = append(errs, fmt.Errorf("MAX_BATCH_SIZE must be positive, got %d", c.MaxBatchSize))
+	}
+
+	switch c.Forecast.Method {
+	case "moving_average", "exponential_smoothing":
+	default:
+		errs = append(errs, fmt.Errorf("invalid FORECAST_METHOD %q: must be moving_average or exponential_smoothing", c.Forecast.Method))
+	}
+	for _, d := range []struct {
+		name  string
+		value int
+	}{
+		{"FORECAST_HISTORY_DAYS", c.Forecast.HistoryDays},
+		{"FORECAST_WINDOW_DAYS", c.Forecast.WindowDays},
+		{"FORECAST_LEAD_TIME_DAYS", c.Forecast.LeadTimeDays},
+		{"FORECAST_REVIEW_DAYS", c.Forecast.ReviewDays},
+	} {
+		if d.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %d", d.name, d.value))
+		}
+	}
+	if c.Forecast.Alpha <= 0 || c.Forecast.Alpha > 1 {
+		errs = append(errs, fmt.Errorf("FORECAST_ALPHA must be in (0, 1], got %g", c.Forecast.Alpha))
+	}
+	if c.Forecast.ServiceLevel <= 0 || c.Forecast.ServiceLevel >= 1 {
+		errs = append(errs, fmt.Errorf("FORECAST_SERVICE_LEVEL must be between 0 and 1, got %g", c.Forecast.ServiceLevel))
+	}
+
+	if c.OverDeliveryTolerance < 0 {
+		errs = append(errs, fmt.Errorf("PO_OVER_DELIVERY_TOLERANCE must not be negative, got %g", c.OverDeliveryTolerance))
+	}
+
+	if c.RateLimit.RPS < 0 {
+		errs = append(errs, fmt.Errorf("RATE_LIMIT_RPS must not be negative, got %g", c.RateLimit.RPS))
+	}
+	if c.RateLimit.RPS > 0 && c.RateLimit.Burst < 1 {
+		errs = append(errs, fmt.Errorf("RATE_LIMIT_BURST must be positive, got %d", c.RateLimit.Burst))
+	}
+
+	switch c.ProductStore {
+	case "mongo":
+	case "postgres":
+		switch {
+		case c.Postgres.URL == "":
+			errs = append(errs, errors.New("POSTGRES_URL is required when PRODUCT_STORE is postgres"))
+		case !strings.HasPrefix(c.Postgres.URL, "postgres://") && !strings.HasPrefix(c.Postgres.URL, "postgresql://"):
+			errs = append(errs, errors.New("invalid POSTGRES_URL: must start with postgres:// or postgresql://"))
+		}
+	case "bolt":
+		if c.BoltPath == "" {
+			errs = append(errs, errors.New("BOLT_PATH must not be empty when PRODUCT_STORE is bolt"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid PRODUCT_STORE %q: must be mongo, postgres or bolt", c.ProductStore))
+	}
+
+	switch c.Cache.Backend {
+	case "none":
+	case "memory":
+		if c.Cache.Size <= 0 {
+			errs = append(errs, fmt.Errorf("CACHE_SIZE must be positive, got %d", c.Cache.Size))
+		}
+	case "redis":
+		if c.Cache.RedisURL == "" {
+			errs = append(errs, errors.New("REDIS_URL is required when CACHE_BACKEND is redis"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid CACHE_BACKEND %q: must be none, memory or redis", c.Cache.Backend))
+	}
+	if c.Cache.Backend != "none" && c.Cache.TTL <= 0 {
+		errs = append(errs, fmt.Errorf("CACHE_TTL must be positive, got %s", c.Cache.TTL))
+	}
+
+	switch c.TracingExporter {
+	case "none", "stdout", "otlp":
+	default:
+		errs = append(errs, fmt.Errorf("invalid TRACING_EXPORTER %q: must be none, stdout or otlp", c.TracingExporter))
+	}
+	return errs
+}
+
+func stringSetting(env, name, usage string, dst *string) setting {
+	return setting{env: env, flag: name, usage: usage, def: *dst, parse: func(s string) error {
+		*dst = s
+		return nil
+	}}
+}
+
+func boolSetting(env, name, usage string, dst *bool) setting {
+	return setting{env: env, flag: name, usage: usage, def: strconv.FormatBool(*dst), parse: func(s string) error {
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		*dst = v
+		return nil
+	}}
+}
+
+func durationSetting(env, name, usage string, dst *time.Duration) setting {
+	return setting{env: env, flag: name, usage: usage, def: dst.String(), parse: func(s string) error {
+		v, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		*dst = v
+		return nil
+	}}
+}
+
+func intSetting(env, name, usage string, dst *int) setting {
+	return setting{env: env, flag: name, usage: usage, def: strconv.Itoa(*dst), parse: func(s string) error {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		*dst = v
+		return nil
+	}}
+}
+
+func floatSetting(env, name, usage string, dst *float64) setting {
+	return setting{env: env, flag: name, usage: usage, def: strconv.FormatFloat(*dst, 'g', -1, 64), parse: func(s string) error {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		*dst = v
+		return nil
+	}}
+}
+
+// parseRateLimits reads per-RPC limits written as Method=rps[:burst] and
+// separated by commas. The burst defaults to the rate, rounded up.
+func parseRateLimits(s string) (map[string]RateLimit, error) {
+	limits := make(map[string]RateLimit)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		method, value, ok := strings.Cut(entry, "=")
+		if !ok || method == "" {
+			return nil, fmt.Errorf("%q: want Method=rps[:burst]", entry)
+		}
+		rps, burst, hasBurst := strings.Cut(value, ":")
+		var (
+			lim RateLimit
+			err error
+		)
+		if lim.RPS, err = strconv.ParseFloat(rps, 64); err != nil || lim.RPS < 0 {
+			return nil, fmt.Errorf("%q: rate must be a non-negative number", entry)
+		}
+		lim.Burst = max(int(math.Ceil(lim.RPS)), 1)
+		if hasBurst {
+			if lim.Burst, err = strconv.Atoi(burst); err != nil || lim.Burst < 1 {
+				return nil, fmt.Errorf("%q: burst must be a positive integer", entry)
+			}
+		}
+		limits[method] = lim
+	}
+	return limits, nil
+}
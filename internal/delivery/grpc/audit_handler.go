@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/usecase"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+type AuditHandler struct {
+	pb.UnimplementedAuditServiceServer
+	uc *usecase.AuditUseCase
+}
+
+func NewAuditHandler(uc *usecase.AuditUseCase) *AuditHandler {
+	return &AuditHandler{uc: uc}
+}
+
+func (h *AuditHandler) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.AuditEventList, error) {
+	events, err := h.uc.ListAuditEvents(ctx, domain.AuditFilter{
+		ProductID: req.ProductId,
+		Actor:     req.Actor,
+		From:      fromTimestamp(req.From),
+		To:        fromTimestamp(req.To),
+		Limit:     int64(req.Limit),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &pb.AuditEventList{Events: make([]*pb.AuditEvent, 0, len(events))}
+	for _, e := range events {
+		ev := &pb.AuditEvent{
+			Id:            e.ID,
+			ProductId:     e.ProductID,
+			Action:        string(e.Action),
+			Actor:         e.Actor,
+			ActorVerified: e.ActorVerified,
+			Peer:          e.Peer,
+			Rpc:           e.RPC,
+			RequestId:     e.RequestID,
+			OccurredAt:    toTimestamp(e.OccurredAt),
+		}
+		for _, c := range e.Changes {
+			ev.Changes = append(ev.Changes, &pb.FieldChange{Field: c.Field, Before: c.Before, After: c.After})
+		}
+		resp.Events = append(resp.Events, ev)
+	}
+	return resp, nil
+}
@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/usecase"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+type BundleHandler struct {
+	pb.UnimplementedBundleServiceServer
+	uc *usecase.BundleUseCase
+}
+
+func NewBundleHandler(uc *usecase.BundleUseCase) *BundleHandler {
+	return &BundleHandler{uc: uc}
+}
+
+func (h *BundleHandler) CreateBundle(ctx context.Context, req *pb.CreateBundleRequest) (*pb.Bundle, error) {
+	b := &domain.Bundle{
+		Name:       req.Name,
+		SKU:        req.Sku,
+		Components: make([]domain.BundleComponent, 0, len(req.Components)),
+	}
+	for _, c := range req.Components {
+		b.Components = append(b.Components, domain.BundleComponent{ProductID: c.ProductId, Quantity: c.Quantity})
+	}
+	b, available, err := h.uc.CreateBundle(ctx, b)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toBundleResponse(b, available), nil
+}
+
+func (h *BundleHandler) GetBundle(ctx context.Context, req *pb.BundleID) (*pb.Bundle, error) {
+	b, available, err := h.uc.GetBundle(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toBundleResponse(b, available), nil
+}
+
+func (h *BundleHandler) ReserveBundle(ctx context.Context, req *pb.ReserveBundleRequest) (*pb.Reservation, error) {
+	r, err := h.uc.ReserveBundle(ctx, req.BundleId, req.Quantity, req.Reference)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toReservationResponse(r), nil
+}
+
+func (h *BundleHandler) ReleaseReservation(ctx context.Context, req *pb.ReservationID) (*pb.Reservation, error) {
+	r, err := h.uc.ReleaseReservation(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toReservationResponse(r), nil
+}
+
+func (h *BundleHandler) GetReservation(ctx context.Context, req *pb.ReservationID) (*pb.Reservation, error) {
+	r, err := h.uc.GetReservation(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toReservationResponse(r), nil
+}
+
+func (h *BundleHandler) ListReservations(ctx context.Context, req *pb.ListReservationsRequest) (*pb.ReservationList, error) {
+	var status domain.ReservationStatus
+	if req.Status != pb.ReservationStatus_RESERVATION_STATUS_UNSPECIFIED {
+		var err error
+		if status, err = fromReservationStatus(req.Status); err != nil {
+			return nil, toStatusError(err)
+		}
+	}
+	reservations, err := h.uc.ListReservations(ctx, status, req.ProductId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &pb.ReservationList{Reservations: make([]*pb.Reservation, 0, len(reservations))}
+	for _, r := range reservations {
+		resp.Reservations = append(resp.Reservations, toReservationResponse(r))
+	}
+	return resp, nil
+}
+
+func toBundleResponse(b *domain.Bundle, available int32) *pb.Bundle {
+	resp := &pb.Bundle{
+		Id:                b.ID,
+		Name:              b.Name,
+		Sku:               b.SKU,
+		Components:        make([]*pb.BundleComponent, 0, len(b.Components)),
+		AvailableQuantity: available,
+		CreatedAt:         toTimestamp(b.CreatedAt),
+	}
+	for _, c := range b.Components {
+		resp.Components = append(resp.Components, &pb.BundleComponent{ProductId: c.ProductID, Quantity: c.Quantity})
+	}
+	return resp
+}
+
+var reservationStatuses = map[domain.ReservationStatus]pb.ReservationStatus{
+	domain.ReservationActive:   pb.ReservationStatus_RESERVATION_STATUS_ACTIVE,
+	domain.ReservationReleased: pb.ReservationStatus_RESERVATION_STATUS_RELEASED,
+}
+
+func fromReservationStatus(s pb.ReservationStatus) (domain.ReservationStatus, error) {
+	for status, v := range reservationStatuses {
+		if v == s {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown reservation status %s", domain.ErrInvalidArgument, s)
+}
+
+func toReservationResponse(r *domain.Reservation) *pb.Reservation {
+	resp := &pb.Reservation{
+		Id:         r.ID,
+		BundleId:   r.BundleID,
+		Quantity:   r.Quantity,
+		Lines:      make([]*pb.ReservationLine, 0, len(r.Lines)),
+		Reference:  r.Reference,
+		Status:     reservationStatuses[r.Status],
+		CreatedAt:  toTimestamp(r.CreatedAt),
+		ReleasedAt: toTimestamp(r.ReleasedAt),
+	}
+	for _, l := range r.Lines {
+		resp.Lines = append(resp.Lines, &pb.ReservationLine{ProductId: l.ProductID, Quantity: l.Quantity, Released: l.Released})
+	}
+	return resp
+}
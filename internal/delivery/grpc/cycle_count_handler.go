@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/usecase"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+type CycleCountHandler struct {
+	pb.UnimplementedCycleCountServiceServer
+	uc *usecase.CycleCountUseCase
+}
+
+func NewCycleCountHandler(uc *usecase.CycleCountUseCase) *CycleCountHandler {
+	return &CycleCountHandler{uc: uc}
+}
+
+func (h *CycleCountHandler) OpenCycleCount(ctx context.Context, req *pb.OpenCycleCountRequest) (*pb.CycleCount, error) {
+	c, err := h.uc.OpenCycleCount(ctx, req.ProductIds)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toCycleCountResponse(c), nil
+}
+
+func (h *CycleCountHandler) GetCycleCount(ctx context.Context, req *pb.CycleCountID) (*pb.CycleCount, error) {
+	c, err := h.uc.GetCycleCount(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toCycleCountResponse(c), nil
+}
+
+func (h *CycleCountHandler) SubmitCounts(ctx context.Context, req *pb.SubmitCountsRequest) (*pb.CycleCount, error) {
+	entries := make([]domain.CountEntry, 0, len(req.Counts))
+	for _, e := range req.Counts {
+		entries = append(entries, domain.CountEntry{
+			ProductID: e.ProductId,
+			Counted:   e.CountedQuantity,
+			Reason:    domain.MovementReason(e.Reason),
+		})
+	}
+	c, err := h.uc.SubmitCounts(ctx, req.Id, entries)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toCycleCountResponse(c), nil
+}
+
+func (h *CycleCountHandler) PreviewCycleCount(ctx context.Context, req *pb.CycleCountID) (*pb.CycleCountPreview, error) {
+	c, current, err := h.uc.PreviewCycleCount(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &pb.CycleCountPreview{
+		CycleCount: toCycleCountResponse(c),
+		Variances:  make([]*pb.VariancePreview, 0, len(c.Lines)),
+	}
+	for _, l := range c.Lines {
+		v := &pb.VariancePreview{
+			ProductId:       l.ProductID,
+			Counted:         l.IsCounted(),
+			CountedQuantity: l.Counted,
+			SystemStock:     l.SystemStock,
+			CurrentStock:    current[l.ProductID],
+			ResultingStock:  current[l.ProductID],
+			Applied:         l.Applied,
+		}
+		if l.IsCounted() {
+			v.Variance = l.Variance()
+			if !l.Applied {
+				v.ResultingStock += l.Variance()
+			}
+		}
+		resp.Variances = append(resp.Variances, v)
+	}
+	return resp, nil
+}
+
+func (h *CycleCountHandler) ApproveCycleCount(ctx context.Context, req *pb.CycleCountID) (*pb.CycleCount, error) {
+	c, err := h.uc.ApproveCycleCount(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toCycleCountResponse(c), nil
+}
+
+func (h *CycleCountHandler) CancelCycleCount(ctx context.Context, req *pb.CycleCountID) (*pb.CycleCount, error) {
+	c, err := h.uc.CancelCycleCount(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toCycleCountResponse(c), nil
+}
+
+var cycleCountStatuses = map[domain.CycleCountStatus]pb.CycleCountStatus{
+	domain.CycleCountOpen:      pb.CycleCountStatus_CYCLE_COUNT_STATUS_OPEN,
+	domain.CycleCountApproved:  pb.CycleCountStatus_CYCLE_COUNT_STATUS_APPROVED,
+	domain.CycleCountCancelled: pb.CycleCountStatus_CYCLE_COUNT_STATUS_CANCELLED,
+}
+
+func toCycleCountResponse(c *domain.CycleCount) *pb.CycleCount {
+	resp := &pb.CycleCount{
+		Id:         c.ID,
+		Status:     cycleCountStatuses[c.Status],
+		Lines:      make([]*pb.CycleCountLine, 0, len(c.Lines)),
+		CreatedAt:  toTimestamp(c.CreatedAt),
+		CreatedBy:  c.CreatedBy,
+		ApprovedAt: toTimestamp(c.ApprovedAt),
+		ApprovedBy: c.ApprovedBy,
+	}
+	for _, l := range c.Lines {
+		line := &pb.CycleCountLine{
+			ProductId:       l.ProductID,
+			Counted:         l.IsCounted(),
+			CountedQuantity: l.Counted,
+			SystemStock:     l.SystemStock,
+			Reason:          string(l.Reason),
+			Applied:         l.Applied,
+			CountedAt:       toTimestamp(l.CountedAt),
+		}
+		if l.IsCounted() {
+			line.Variance = l.Variance()
+		}
+		resp.Lines = append(resp.Lines, line)
+	}
+	return resp
+}
@@ -0,0 +1,34 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatusError maps domain errors onto gRPC status codes. Errors that are
+// not recognised become Internal.
+func toStatusError(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, domain.ErrInvalidArgument):
+		code = codes.InvalidArgument
+	case errors.Is(err, domain.ErrFailedPrecondition), errors.Is(err, domain.ErrInsufficientStock):
+		code = codes.FailedPrecondition
+	case errors.Is(err, domain.ErrConflict):
+		code = codes.Aborted
+	case errors.Is(err, domain.ErrPermissionDenied):
+		code = codes.PermissionDenied
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	}
+	return status.Error(code, err.Error())
+}
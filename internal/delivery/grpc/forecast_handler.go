@@ -0,0 +1,97 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/forecast"
+	"github.com/facelessEmptiness/inventory_service/internal/usecase"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+type ForecastHandler struct {
+	pb.UnimplementedForecastServiceServer
+	uc *usecase.ForecastUseCase
+}
+
+func NewForecastHandler(uc *usecase.ForecastUseCase) *ForecastHandler {
+	return &ForecastHandler{uc: uc}
+}
+
+func (h *ForecastHandler) GetDemandForecast(ctx context.Context, req *pb.DemandForecastRequest) (*pb.DemandForecast, error) {
+	params, err := fromForecastParams(req.Params)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	d, params, err := h.uc.ForecastDemand(ctx, req.ProductId, params)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.DemandForecast{
+		ProductId:   req.ProductId,
+		Method:      forecastMethods[params.Method],
+		DailyDemand: d.Daily,
+		DailyStdDev: d.StdDev,
+		HistoryDays: int32(params.HistoryDays),
+	}, nil
+}
+
+func (h *ForecastHandler) SuggestReorders(ctx context.Context, req *pb.SuggestReordersRequest) (*pb.ReorderSuggestions, error) {
+	params, err := fromForecastParams(req.Params)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	suggestions, err := h.uc.SuggestReorders(ctx, req.ProductIds, params)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &pb.ReorderSuggestions{Suggestions: make([]*pb.ReorderSuggestion, 0, len(suggestions))}
+	for _, s := range suggestions {
+		resp.Suggestions = append(resp.Suggestions, &pb.ReorderSuggestion{
+			ProductId:         s.Product.ID,
+			Sku:               s.Product.SKU,
+			Name:              s.Product.Name,
+			Stock:             s.Product.Stock,
+			OnOrder:           s.OnOrder,
+			DailyDemand:       s.Demand.Daily,
+			DailyStdDev:       s.Demand.StdDev,
+			LeadTimeDays:      s.LeadTime,
+			SafetyStock:       s.Plan.SafetyStock,
+			ReorderPoint:      s.Plan.ReorderPoint,
+			SuggestedQuantity: s.Plan.Quantity,
+			SupplierId:        s.SupplierID,
+		})
+	}
+	return resp, nil
+}
+
+var forecastMethods = map[forecast.Method]pb.ForecastMethod{
+	forecast.MovingAverage:        pb.ForecastMethod_FORECAST_METHOD_MOVING_AVERAGE,
+	forecast.ExponentialSmoothing: pb.ForecastMethod_FORECAST_METHOD_EXPONENTIAL_SMOOTHING,
+}
+
+func fromForecastParams(p *pb.ForecastParams) (forecast.Params, error) {
+	if p == nil {
+		return forecast.Params{}, nil
+	}
+	params := forecast.Params{
+		HistoryDays:  int(p.HistoryDays),
+		WindowDays:   int(p.WindowDays),
+		Alpha:        p.Alpha,
+		ServiceLevel: p.ServiceLevel,
+		LeadTimeDays: p.LeadTimeDays,
+		ReviewDays:   p.ReviewDays,
+	}
+	if p.Method == pb.ForecastMethod_FORECAST_METHOD_UNSPECIFIED {
+		return params, nil
+	}
+	for method, v := range forecastMethods {
+		if v == p.Method {
+			params.Method = method
+			return params, nil
+		}
+	}
+	return params, fmt.Errorf("%w: unknown forecast method %s", domain.ErrInvalidArgument, p.Method)
+}
@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/usecase"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+type LotHandler struct {
+	pb.UnimplementedLotServiceServer
+	uc *usecase.LotUseCase
+}
+
+func NewLotHandler(uc *usecase.LotUseCase) *LotHandler {
+	return &LotHandler{uc: uc}
+}
+
+func (h *LotHandler) ReceiveLot(ctx context.Context, req *pb.ReceiveLotRequest) (*pb.Lot, error) {
+	l, err := h.uc.ReceiveLot(ctx, &domain.Lot{
+		ProductID:      req.ProductId,
+		LotNumber:      req.LotNumber,
+		ManufacturedAt: fromTimestamp(req.ManufacturedAt),
+		ExpiresAt:      fromTimestamp(req.ExpiresAt),
+		Quantity:       req.Quantity,
+		UnitCost:       req.UnitCost,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toLotResponse(l), nil
+}
+
+func (h *LotHandler) ListLots(ctx context.Context, req *pb.ProductID) (*pb.LotList, error) {
+	lots, err := h.uc.ListLots(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toLotList(lots), nil
+}
+
+func (h *LotHandler) ListExpiringLots(ctx context.Context, req *pb.ListExpiringLotsRequest) (*pb.LotList, error) {
+	lots, err := h.uc.ListExpiringLots(ctx, fromTimestamp(req.From), fromTimestamp(req.To))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toLotList(lots), nil
+}
+
+var lotStatuses = map[domain.LotStatus]pb.LotStatus{
+	domain.LotAvailable:   pb.LotStatus_LOT_STATUS_AVAILABLE,
+	domain.LotQuarantined: pb.LotStatus_LOT_STATUS_QUARANTINED,
+}
+
+func toLotResponse(l *domain.Lot) *pb.Lot {
+	return &pb.Lot{
+		Id:             l.ID,
+		ProductId:      l.ProductID,
+		LotNumber:      l.LotNumber,
+		ManufacturedAt: toTimestamp(l.ManufacturedAt),
+		ExpiresAt:      toTimestamp(l.ExpiresAt),
+		Quantity:       l.Quantity,
+		UnitCost:       l.UnitCost,
+		Status:         lotStatuses[l.Status],
+		ReceivedAt:     toTimestamp(l.ReceivedAt),
+		Booked:         l.Booked,
+	}
+}
+
+func toLotList(lots []*domain.Lot) *pb.LotList {
+	resp := &pb.LotList{Lots: make([]*pb.Lot, 0, len(lots))}
+	for _, l := range lots {
+		resp.Lots = append(resp.Lots, toLotResponse(l))
+	}
+	return resp
+}
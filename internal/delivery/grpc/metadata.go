@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/facelessEmptiness/inventory_service/internal/audit"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// actorKey is the metadata key callers use to say who they act for.
+	actorKey = "x-actor"
+	// requestIDKey carries the caller's request id; one is generated when it
+	// is missing and echoed back in the response header.
+	requestIDKey = "x-request-id"
+)
+
+// CallerInterceptor stores the actor, peer, method and request id of each
+// call in its context for the audit trail. A verified client certificate
+// names the actor; without one the actor claimed in x-actor is taken as is.
+func CallerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		c := audit.Caller{
+			Actor:     firstValue(ctx, actorKey),
+			RPC:       info.FullMethod,
+			RequestID: firstValue(ctx, requestIDKey),
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			if p.Addr != nil {
+				c.Peer = p.Addr.String()
+			}
+			if subject := certSubject(p.AuthInfo); subject != "" {
+				c.Actor, c.Verified = subject, true
+			}
+		}
+		if c.RequestID == "" {
+			c.RequestID = newRequestID()
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, c.RequestID))
+		return handler(audit.WithCaller(ctx, c), req)
+	}
+}
+
+// certSubject returns the subject of a client certificate the TLS handshake
+// verified, or "" when the client presented none.
+func certSubject(info credentials.AuthInfo) string {
+	tlsInfo, ok := info.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	subject := tlsInfo.State.VerifiedChains[0][0].Subject
+	if subject.CommonName != "" {
+		return subject.CommonName
+	}
+	return subject.String()
+}
+
+func firstValue(ctx context.Context, key string) string {
+	if v := metadata.ValueFromIncomingContext(ctx, key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,104 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/usecase"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+type PriceHandler struct {
+	pb.UnimplementedPriceServiceServer
+	uc *usecase.PriceUseCase
+}
+
+func NewPriceHandler(uc *usecase.PriceUseCase) *PriceHandler {
+	return &PriceHandler{uc: uc}
+}
+
+func (h *PriceHandler) SchedulePriceChange(ctx context.Context, req *pb.SchedulePriceChangeRequest) (*pb.ScheduledPriceChange, error) {
+	s, err := h.uc.SchedulePriceChange(ctx, &domain.ScheduledPrice{
+		ProductID:     req.ProductId,
+		Price:         req.Price,
+		EffectiveFrom: fromTimestamp(req.EffectiveFrom),
+		EffectiveTo:   fromTimestamp(req.EffectiveTo),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toScheduledPriceResponse(s), nil
+}
+
+func (h *PriceHandler) CancelScheduledPriceChange(ctx context.Context, req *pb.ScheduledPriceChangeID) (*pb.ScheduledPriceChange, error) {
+	s, err := h.uc.CancelScheduledPriceChange(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toScheduledPriceResponse(s), nil
+}
+
+func (h *PriceHandler) ListScheduledPriceChanges(ctx context.Context, req *pb.ProductID) (*pb.ScheduledPriceChangeList, error) {
+	changes, err := h.uc.ListScheduledPriceChanges(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &pb.ScheduledPriceChangeList{Changes: make([]*pb.ScheduledPriceChange, 0, len(changes))}
+	for _, s := range changes {
+		resp.Changes = append(resp.Changes, toScheduledPriceResponse(s))
+	}
+	return resp, nil
+}
+
+func (h *PriceHandler) GetPriceHistory(ctx context.Context, req *pb.ProductID) (*pb.PriceHistory, error) {
+	records, err := h.uc.GetPriceHistory(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &pb.PriceHistory{Records: make([]*pb.PriceRecord, 0, len(records))}
+	for _, r := range records {
+		resp.Records = append(resp.Records, toPriceRecordResponse(r))
+	}
+	return resp, nil
+}
+
+func (h *PriceHandler) GetPriceAt(ctx context.Context, req *pb.GetPriceAtRequest) (*pb.PriceRecord, error) {
+	r, err := h.uc.GetPriceAt(ctx, req.ProductId, fromTimestamp(req.At))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toPriceRecordResponse(r), nil
+}
+
+var scheduledPriceStatuses = map[domain.ScheduledPriceStatus]pb.ScheduledPriceStatus{
+	domain.ScheduledPricePending:   pb.ScheduledPriceStatus_SCHEDULED_PRICE_STATUS_PENDING,
+	domain.ScheduledPriceActive:    pb.ScheduledPriceStatus_SCHEDULED_PRICE_STATUS_ACTIVE,
+	domain.ScheduledPriceCompleted: pb.ScheduledPriceStatus_SCHEDULED_PRICE_STATUS_COMPLETED,
+	domain.ScheduledPriceCancelled: pb.ScheduledPriceStatus_SCHEDULED_PRICE_STATUS_CANCELLED,
+}
+
+func toScheduledPriceResponse(s *domain.ScheduledPrice) *pb.ScheduledPriceChange {
+	return &pb.ScheduledPriceChange{
+		Id:            s.ID,
+		ProductId:     s.ProductID,
+		Price:         s.Price,
+		EffectiveFrom: toTimestamp(s.EffectiveFrom),
+		EffectiveTo:   toTimestamp(s.EffectiveTo),
+		Status:        scheduledPriceStatuses[s.Status],
+		PreviousPrice: s.PreviousPrice,
+		CreatedAt:     toTimestamp(s.CreatedAt),
+		CreatedBy:     s.CreatedBy,
+	}
+}
+
+func toPriceRecordResponse(r *domain.PriceRecord) *pb.PriceRecord {
+	return &pb.PriceRecord{
+		ProductId:     r.ProductID,
+		Price:         r.Price,
+		EffectiveFrom: toTimestamp(r.EffectiveFrom),
+		EffectiveTo:   toTimestamp(r.EffectiveTo),
+		Source:        r.Source,
+		Actor:         r.Actor,
+	}
+}
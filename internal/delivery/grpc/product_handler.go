@@ -10,47 +10,206 @@ import (
 
 type ProductHandler struct {
 	pb.UnimplementedInventoryServiceServer
-	uc *usecase.ProductUseCase
+	uc    *usecase.ProductUseCase
+	stock *usecase.StockUseCase
 }
 
-func NewProductHandler(uc *usecase.ProductUseCase) *ProductHandler {
-	return &ProductHandler{uc: uc}
+func NewProductHandler(uc *usecase.ProductUseCase, stock *usecase.StockUseCase) *ProductHandler {
+	return &ProductHandler{uc: uc, stock: stock}
 }
 
 func (h *ProductHandler) AddProduct(ctx context.Context, req *pb.ProductRequest) (*pb.ProductResponse, error) {
 	p := &domain.Product{
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-		Stock:       req.Stock,
-		CategoryID:  req.CategoryId,
+		SKU:               req.Sku,
+		Name:              req.Name,
+		Description:       req.Description,
+		Price:             req.Price,
+		Stock:             req.Stock,
+		CategoryID:        req.CategoryId,
+		ReorderPoint:      req.ReorderPoint,
+		ReorderQuantity:   req.ReorderQuantity,
+		VariantAttributes: req.VariantAttributes,
+		LotTracked:        req.LotTracked,
+		Serialized:        req.Serialized,
 	}
-	id, err := h.uc.AddProduct(p)
+	id, err := h.uc.AddProduct(ctx, p)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 	p.ID = id
-	return &pb.ProductResponse{
-		Id:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-		Stock:       p.Stock,
-		CategoryId:  p.CategoryID,
-	}, nil
+	return toProductResponse(p), nil
+}
+
+func (h *ProductHandler) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.ProductResponse, error) {
+	p, err := h.uc.GetProduct(ctx, req.Id, req.IncludeDeleted)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toProductResponse(p), nil
 }
 
-func (h *ProductHandler) GetProduct(ctx context.Context, req *pb.ProductID) (*pb.ProductResponse, error) {
-	p, err := h.uc.GetProduct(req.Id)
+func (h *ProductHandler) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.ProductResponse, error) {
+	p, err := h.uc.UpdateProduct(ctx, req.Id, &domain.ProductUpdate{
+		Name:            req.Name,
+		Description:     req.Description,
+		Price:           req.Price,
+		CategoryID:      req.CategoryId,
+		ReorderPoint:    req.ReorderPoint,
+		ReorderQuantity: req.ReorderQuantity,
+	})
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
-	return &pb.ProductResponse{
-		Id:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-		Stock:       p.Stock,
-		CategoryId:  p.CategoryID,
+	return toProductResponse(p), nil
+}
+
+func (h *ProductHandler) GetProducts(ctx context.Context, req *pb.GetProductsRequest) (*pb.GetProductsResponse, error) {
+	products, missingIDs, missingSKUs, err := h.uc.GetProducts(ctx, req.Ids, req.Skus)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.GetProductsResponse{
+		Products:    toProductList(products).Products,
+		MissingIds:  missingIDs,
+		MissingSkus: missingSKUs,
 	}, nil
 }
+
+func (h *ProductHandler) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ProductList, error) {
+	products, err := h.uc.ListProducts(ctx, req.IncludeDeleted)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toProductList(products), nil
+}
+
+func (h *ProductHandler) SearchProducts(ctx context.Context, req *pb.SearchProductsRequest) (*pb.ProductList, error) {
+	products, err := h.uc.SearchProducts(ctx, req.Query, int(req.Limit))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toProductList(products), nil
+}
+
+func (h *ProductHandler) DeleteProduct(ctx context.Context, req *pb.ProductID) (*pb.ProductResponse, error) {
+	p, err := h.uc.DeleteProduct(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toProductResponse(p), nil
+}
+
+func (h *ProductHandler) RestoreProduct(ctx context.Context, req *pb.ProductID) (*pb.ProductResponse, error) {
+	p, err := h.uc.RestoreProduct(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toProductResponse(p), nil
+}
+
+func (h *ProductHandler) ListLowStockProducts(ctx context.Context, req *pb.ListLowStockProductsRequest) (*pb.ProductList, error) {
+	products, err := h.uc.ListLowStockProducts(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toProductList(products), nil
+}
+
+func (h *ProductHandler) AddVariant(ctx context.Context, req *pb.AddVariantRequest) (*pb.Variant, error) {
+	v, parent, err := h.uc.AddVariant(ctx, &domain.Variant{
+		ProductID:     req.ProductId,
+		SKU:           req.Sku,
+		Attributes:    req.Attributes,
+		PriceOverride: req.PriceOverride,
+		Stock:         req.Stock,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toVariantResponse(v, parent), nil
+}
+
+func (h *ProductHandler) ListVariants(ctx context.Context, req *pb.ProductID) (*pb.VariantList, error) {
+	parent, err := h.uc.ListVariants(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &pb.VariantList{Variants: make([]*pb.Variant, 0, len(parent.Variants))}
+	for _, v := range parent.Variants {
+		resp.Variants = append(resp.Variants, toVariantResponse(v, parent))
+	}
+	return resp, nil
+}
+
+func (h *ProductHandler) DecrementStock(ctx context.Context, req *pb.DecrementStockRequest) (*pb.DecrementStockResponse, error) {
+	p, allocations, err := h.stock.DecrementStock(ctx, req.ProductId, req.VariantId, req.Quantity, req.Reference)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &pb.DecrementStockResponse{
+		Product:     toProductResponse(p),
+		Allocations: make([]*pb.LotAllocation, 0, len(allocations)),
+	}
+	for _, a := range allocations {
+		resp.Allocations = append(resp.Allocations, &pb.LotAllocation{
+			LotId:     a.LotID,
+			LotNumber: a.LotNumber,
+			ExpiresAt: toTimestamp(a.ExpiresAt),
+			Quantity:  a.Quantity,
+		})
+	}
+	return resp, nil
+}
+
+func (h *ProductHandler) AdjustStock(ctx context.Context, req *pb.AdjustStockRequest) (*pb.ProductResponse, error) {
+	p, err := h.stock.AdjustStock(ctx, req.ProductId, req.VariantId, req.Delta, domain.MovementReason(req.Reason), req.Reference)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toProductResponse(p), nil
+}
+
+func toProductResponse(p *domain.Product) *pb.ProductResponse {
+	resp := &pb.ProductResponse{
+		Id:                p.ID,
+		Sku:               p.SKU,
+		Name:              p.Name,
+		Description:       p.Description,
+		Price:             p.Price,
+		Stock:             p.Stock,
+		DamagedStock:      p.DamagedStock,
+		CategoryId:        p.CategoryID,
+		ReorderPoint:      p.ReorderPoint,
+		ReorderQuantity:   p.ReorderQuantity,
+		VariantAttributes: p.VariantAttributes,
+		AvailableStock:    p.AvailableStock(),
+		LotTracked:        p.LotTracked,
+		Serialized:        p.Serialized,
+		DeletedAt:         toTimestamp(p.DeletedAt),
+		DeletedBy:         p.DeletedBy,
+	}
+	for _, v := range p.Variants {
+		resp.Variants = append(resp.Variants, toVariantResponse(v, p))
+	}
+	return resp
+}
+
+func toVariantResponse(v *domain.Variant, parent *domain.Product) *pb.Variant {
+	return &pb.Variant{
+		Id:            v.ID,
+		ProductId:     v.ProductID,
+		Sku:           v.SKU,
+		Attributes:    v.Attributes,
+		PriceOverride: v.PriceOverride,
+		Price:         v.Price(parent),
+		Stock:         v.Stock,
+	}
+}
+
+func toProductList(products []*domain.Product) *pb.ProductList {
+	resp := &pb.ProductList{Products: make([]*pb.ProductResponse, 0, len(products))}
+	for _, p := range products {
+		resp.Products = append(resp.Products, toProductResponse(p))
+	}
+	return resp
+}
@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/usecase"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+type PurchaseOrderHandler struct {
+	pb.UnimplementedPurchaseOrderServiceServer
+	uc *usecase.PurchaseOrderUseCase
+}
+
+func NewPurchaseOrderHandler(uc *usecase.PurchaseOrderUseCase) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{uc: uc}
+}
+
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(ctx context.Context, req *pb.CreatePurchaseOrderRequest) (*pb.PurchaseOrder, error) {
+	po := &domain.PurchaseOrder{
+		SupplierID: req.SupplierId,
+		Lines:      make([]domain.PurchaseOrderLine, 0, len(req.Lines)),
+		ExpectedAt: fromTimestamp(req.ExpectedAt),
+	}
+	for _, l := range req.Lines {
+		po.Lines = append(po.Lines, domain.PurchaseOrderLine{ProductID: l.ProductId, Quantity: l.Quantity})
+	}
+	po, err := h.uc.CreatePurchaseOrder(ctx, po)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toPurchaseOrderResponse(po), nil
+}
+
+func (h *PurchaseOrderHandler) GetPurchaseOrder(ctx context.Context, req *pb.PurchaseOrderID) (*pb.PurchaseOrder, error) {
+	po, err := h.uc.GetPurchaseOrder(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toPurchaseOrderResponse(po), nil
+}
+
+func (h *PurchaseOrderHandler) SendPurchaseOrder(ctx context.Context, req *pb.PurchaseOrderID) (*pb.PurchaseOrder, error) {
+	po, err := h.uc.SendPurchaseOrder(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toPurchaseOrderResponse(po), nil
+}
+
+func (h *PurchaseOrderHandler) CancelPurchaseOrder(ctx context.Context, req *pb.PurchaseOrderID) (*pb.PurchaseOrder, error) {
+	po, err := h.uc.CancelPurchaseOrder(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toPurchaseOrderResponse(po), nil
+}
+
+func (h *PurchaseOrderHandler) ReceiveGoods(ctx context.Context, req *pb.ReceiveGoodsRequest) (*pb.PurchaseOrder, error) {
+	lines := make([]domain.ReceiptLine, 0, len(req.Lines))
+	for _, l := range req.Lines {
+		lines = append(lines, domain.ReceiptLine{ProductID: l.ProductId, Quantity: l.Quantity, UnitCost: l.UnitCost})
+	}
+	po, err := h.uc.ReceiveGoods(ctx, req.PurchaseOrderId, lines, req.CloseShort)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toPurchaseOrderResponse(po), nil
+}
+
+var purchaseOrderStatuses = map[domain.PurchaseOrderStatus]pb.PurchaseOrderStatus{
+	domain.PurchaseOrderDraft:             pb.PurchaseOrderStatus_PURCHASE_ORDER_STATUS_DRAFT,
+	domain.PurchaseOrderSent:              pb.PurchaseOrderStatus_PURCHASE_ORDER_STATUS_SENT,
+	domain.PurchaseOrderPartiallyReceived: pb.PurchaseOrderStatus_PURCHASE_ORDER_STATUS_PARTIALLY_RECEIVED,
+	domain.PurchaseOrderReceived:          pb.PurchaseOrderStatus_PURCHASE_ORDER_STATUS_RECEIVED,
+	domain.PurchaseOrderCancelled:         pb.PurchaseOrderStatus_PURCHASE_ORDER_STATUS_CANCELLED,
+}
+
+func toPurchaseOrderResponse(po *domain.PurchaseOrder) *pb.PurchaseOrder {
+	resp := &pb.PurchaseOrder{
+		Id:         po.ID,
+		SupplierId: po.SupplierID,
+		Lines:      make([]*pb.PurchaseOrderLine, 0, len(po.Lines)),
+		ExpectedAt: toTimestamp(po.ExpectedAt),
+		Status:     purchaseOrderStatuses[po.Status],
+		CreatedAt:  toTimestamp(po.CreatedAt),
+		UpdatedAt:  toTimestamp(po.UpdatedAt),
+	}
+	for _, l := range po.Lines {
+		resp.Lines = append(resp.Lines, &pb.PurchaseOrderLine{
+			ProductId:        l.ProductID,
+			Quantity:         l.Quantity,
+			ReceivedQuantity: l.ReceivedQuantity,
+			UnbookedQuantity: po.Unbooked(l.ProductID),
+		})
+	}
+	return resp
+}
@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/usecase"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+type ReturnHandler struct {
+	pb.UnimplementedReturnServiceServer
+	uc *usecase.ReturnUseCase
+}
+
+func NewReturnHandler(uc *usecase.ReturnUseCase) *ReturnHandler {
+	return &ReturnHandler{uc: uc}
+}
+
+func (h *ReturnHandler) CreateReturn(ctx context.Context, req *pb.CreateReturnRequest) (*pb.Return, error) {
+	r := &domain.Return{
+		OrderID: req.OrderId,
+		Reason:  req.Reason,
+		Lines:   make([]domain.ReturnLine, 0, len(req.Items)),
+	}
+	for _, it := range req.Items {
+		r.Lines = append(r.Lines, domain.ReturnLine{ProductID: it.ProductId, Quantity: it.Quantity})
+	}
+	r, err := h.uc.CreateReturn(ctx, r)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toReturnResponse(r), nil
+}
+
+func (h *ReturnHandler) GetReturn(ctx context.Context, req *pb.ReturnID) (*pb.Return, error) {
+	r, err := h.uc.GetReturn(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toReturnResponse(r), nil
+}
+
+func (h *ReturnHandler) ListReturnsByOrder(ctx context.Context, req *pb.ListReturnsByOrderRequest) (*pb.ReturnList, error) {
+	returns, err := h.uc.ListReturnsByOrder(ctx, req.OrderId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &pb.ReturnList{Returns: make([]*pb.Return, 0, len(returns))}
+	for _, r := range returns {
+		resp.Returns = append(resp.Returns, toReturnResponse(r))
+	}
+	return resp, nil
+}
+
+func (h *ReturnHandler) InspectReturn(ctx context.Context, req *pb.InspectReturnRequest) (*pb.Return, error) {
+	inspections := make([]domain.Inspection, 0, len(req.Inspections))
+	for _, in := range req.Inspections {
+		d, err := fromDisposition(in.Disposition)
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		inspections = append(inspections, domain.Inspection{ProductID: in.ProductId, Quantity: in.Quantity, Disposition: d})
+	}
+	r, err := h.uc.InspectReturn(ctx, req.Id, inspections)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toReturnResponse(r), nil
+}
+
+var returnStatuses = map[domain.ReturnStatus]pb.ReturnStatus{
+	domain.ReturnOpen:      pb.ReturnStatus_RETURN_STATUS_OPEN,
+	domain.ReturnCompleted: pb.ReturnStatus_RETURN_STATUS_COMPLETED,
+}
+
+var dispositions = map[domain.Disposition]pb.Disposition{
+	domain.DispositionRestock:      pb.Disposition_DISPOSITION_RESTOCK,
+	domain.DispositionDamaged:      pb.Disposition_DISPOSITION_DAMAGED,
+	domain.DispositionVendorReturn: pb.Disposition_DISPOSITION_VENDOR_RETURN,
+}
+
+func fromDisposition(d pb.Disposition) (domain.Disposition, error) {
+	for disposition, v := range dispositions {
+		if v == d {
+			return disposition, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown disposition %s", domain.ErrInvalidArgument, d)
+}
+
+func toReturnResponse(r *domain.Return) *pb.Return {
+	resp := &pb.Return{
+		Id:        r.ID,
+		OrderId:   r.OrderID,
+		Reason:    r.Reason,
+		Lines:     make([]*pb.ReturnLine, 0, len(r.Lines)),
+		Status:    returnStatuses[r.Status],
+		CreatedAt: toTimestamp(r.CreatedAt),
+		CreatedBy: r.CreatedBy,
+	}
+	for _, l := range r.Lines {
+		resp.Lines = append(resp.Lines, &pb.ReturnLine{
+			ProductId:    l.ProductID,
+			Quantity:     l.Quantity,
+			Restocked:    l.Restocked,
+			Damaged:      l.Damaged,
+			VendorReturn: l.VendorReturn,
+			Unbooked:     r.Unbooked(l.ProductID),
+		})
+	}
+	return resp
+}
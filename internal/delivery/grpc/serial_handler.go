@@ -0,0 +1,125 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/usecase"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+type SerialHandler struct {
+	pb.UnimplementedSerialServiceServer
+	uc *usecase.SerialUseCase
+}
+
+func NewSerialHandler(uc *usecase.SerialUseCase) *SerialHandler {
+	return &SerialHandler{uc: uc}
+}
+
+func (h *SerialHandler) ReceiveSerials(ctx context.Context, req *pb.ReceiveSerialsRequest) (*pb.SerialUnitList, error) {
+	units, err := h.uc.ReceiveSerials(ctx, req.ProductId, req.Serials, req.Location, req.Reference, req.UnitCost)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toSerialUnitList(units), nil
+}
+
+func (h *SerialHandler) AllocateSerials(ctx context.Context, req *pb.AllocateSerialsRequest) (*pb.SerialUnitList, error) {
+	units, err := h.uc.AllocateSerials(ctx, req.ProductId, req.OrderId, req.Quantity, req.Serials)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toSerialUnitList(units), nil
+}
+
+func (h *SerialHandler) GetSerial(ctx context.Context, req *pb.GetSerialRequest) (*pb.SerialUnit, error) {
+	u, err := h.uc.GetSerial(ctx, req.Serial)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toSerialUnitResponse(u), nil
+}
+
+func (h *SerialHandler) ListSerials(ctx context.Context, req *pb.ListSerialsRequest) (*pb.SerialUnitList, error) {
+	var status domain.SerialStatus
+	if req.Status != pb.SerialStatus_SERIAL_STATUS_UNSPECIFIED {
+		var err error
+		if status, err = fromSerialStatus(req.Status); err != nil {
+			return nil, toStatusError(err)
+		}
+	}
+	units, err := h.uc.ListSerials(ctx, req.ProductId, status)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toSerialUnitList(units), nil
+}
+
+func (h *SerialHandler) UpdateSerialStatus(ctx context.Context, req *pb.UpdateSerialStatusRequest) (*pb.SerialUnit, error) {
+	status, err := fromSerialStatus(req.Status)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	u, err := h.uc.UpdateSerialStatus(ctx, req.Serial, status, req.Location)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toSerialUnitResponse(u), nil
+}
+
+func (h *SerialHandler) CountSerials(ctx context.Context, req *pb.ProductID) (*pb.SerialCounts, error) {
+	counts, err := h.uc.CountSerials(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.SerialCounts{
+		ProductId: req.Id,
+		Available: counts[domain.SerialAvailable],
+		Reserved:  counts[domain.SerialReserved],
+		Sold:      counts[domain.SerialSold],
+		Returned:  counts[domain.SerialReturned],
+		Defective: counts[domain.SerialDefective],
+	}, nil
+}
+
+var serialStatuses = map[domain.SerialStatus]pb.SerialStatus{
+	domain.SerialAvailable: pb.SerialStatus_SERIAL_STATUS_AVAILABLE,
+	domain.SerialReserved:  pb.SerialStatus_SERIAL_STATUS_RESERVED,
+	domain.SerialSold:      pb.SerialStatus_SERIAL_STATUS_SOLD,
+	domain.SerialReturned:  pb.SerialStatus_SERIAL_STATUS_RETURNED,
+	domain.SerialDefective: pb.SerialStatus_SERIAL_STATUS_DEFECTIVE,
+}
+
+func fromSerialStatus(s pb.SerialStatus) (domain.SerialStatus, error) {
+	for status, v := range serialStatuses {
+		if v == s {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown serial status %s", domain.ErrInvalidArgument, s)
+}
+
+func toSerialUnitResponse(u *domain.SerialUnit) *pb.SerialUnit {
+	return &pb.SerialUnit{
+		Id:         u.ID,
+		ProductId:  u.ProductID,
+		Serial:     u.Serial,
+		Status:     serialStatuses[u.Status],
+		Location:   u.Location,
+		OrderId:    u.OrderID,
+		Booked:     u.Booked,
+		ReceivedAt: toTimestamp(u.ReceivedAt),
+		UpdatedAt:  toTimestamp(u.UpdatedAt),
+	}
+}
+
+func toSerialUnitList(units []*domain.SerialUnit) *pb.SerialUnitList {
+	resp := &pb.SerialUnitList{Units: make([]*pb.SerialUnit, 0, len(units))}
+	for _, u := range units {
+		resp.Units = append(resp.Units, toSerialUnitResponse(u))
+	}
+	return resp
+}
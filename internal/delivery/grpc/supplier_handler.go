@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/usecase"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+type SupplierHandler struct {
+	pb.UnimplementedSupplierServiceServer
+	uc *usecase.SupplierUseCase
+}
+
+func NewSupplierHandler(uc *usecase.SupplierUseCase) *SupplierHandler {
+	return &SupplierHandler{uc: uc}
+}
+
+func (h *SupplierHandler) CreateSupplier(ctx context.Context, req *pb.CreateSupplierRequest) (*pb.Supplier, error) {
+	s, err := h.uc.CreateSupplier(ctx, &domain.Supplier{
+		Name:         req.Name,
+		ContactEmail: req.ContactEmail,
+		Phone:        req.Phone,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toSupplierResponse(s), nil
+}
+
+func (h *SupplierHandler) GetSupplier(ctx context.Context, req *pb.SupplierID) (*pb.Supplier, error) {
+	s, err := h.uc.GetSupplier(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toSupplierResponse(s), nil
+}
+
+func (h *SupplierHandler) ListSuppliers(ctx context.Context, req *pb.ListSuppliersRequest) (*pb.SupplierList, error) {
+	suppliers, err := h.uc.ListSuppliers(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &pb.SupplierList{Suppliers: make([]*pb.Supplier, 0, len(suppliers))}
+	for _, s := range suppliers {
+		resp.Suppliers = append(resp.Suppliers, toSupplierResponse(s))
+	}
+	return resp, nil
+}
+
+func (h *SupplierHandler) LinkProductSupplier(ctx context.Context, req *pb.ProductSupplier) (*pb.ProductSupplier, error) {
+	ps, err := h.uc.LinkProductSupplier(ctx, &domain.ProductSupplier{
+		ProductID:        req.ProductId,
+		SupplierID:       req.SupplierId,
+		SupplierSKU:      req.SupplierSku,
+		UnitCost:         req.UnitCost,
+		LeadTimeDays:     req.LeadTimeDays,
+		MinOrderQuantity: req.MinOrderQuantity,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toProductSupplierResponse(ps), nil
+}
+
+func (h *SupplierHandler) UnlinkProductSupplier(ctx context.Context, req *pb.UnlinkProductSupplierRequest) (*pb.UnlinkProductSupplierResponse, error) {
+	if err := h.uc.UnlinkProductSupplier(ctx, req.ProductId, req.SupplierId); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.UnlinkProductSupplierResponse{}, nil
+}
+
+func (h *SupplierHandler) ListSupplierProducts(ctx context.Context, req *pb.SupplierID) (*pb.SupplierProductList, error) {
+	products, err := h.uc.ListSupplierProducts(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &pb.SupplierProductList{Products: make([]*pb.SupplierProduct, 0, len(products))}
+	for _, sp := range products {
+		resp.Products = append(resp.Products, &pb.SupplierProduct{
+			Product: toProductResponse(sp.Product),
+			Terms:   toProductSupplierResponse(sp.Terms),
+		})
+	}
+	return resp, nil
+}
+
+func (h *SupplierHandler) ListProductSuppliers(ctx context.Context, req *pb.ProductID) (*pb.ProductSupplierList, error) {
+	links, err := h.uc.ListProductSuppliers(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &pb.ProductSupplierList{Links: make([]*pb.ProductSupplier, 0, len(links))}
+	for _, l := range links {
+		resp.Links = append(resp.Links, toProductSupplierResponse(l))
+	}
+	return resp, nil
+}
+
+func (h *SupplierHandler) GetCheapestSupplier(ctx context.Context, req *pb.GetCheapestSupplierRequest) (*pb.ProductSupplier, error) {
+	ps, err := h.uc.GetCheapestSupplier(ctx, req.ProductId, req.Quantity)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toProductSupplierResponse(ps), nil
+}
+
+func toSupplierResponse(s *domain.Supplier) *pb.Supplier {
+	return &pb.Supplier{
+		Id:           s.ID,
+		Name:         s.Name,
+		ContactEmail: s.ContactEmail,
+		Phone:        s.Phone,
+		CreatedAt:    toTimestamp(s.CreatedAt),
+	}
+}
+
+func toProductSupplierResponse(ps *domain.ProductSupplier) *pb.ProductSupplier {
+	return &pb.ProductSupplier{
+		ProductId:        ps.ProductID,
+		SupplierId:       ps.SupplierID,
+		SupplierSku:      ps.SupplierSKU,
+		UnitCost:         ps.UnitCost,
+		LeadTimeDays:     ps.LeadTimeDays,
+		MinOrderQuantity: ps.MinOrderQuantity,
+	}
+}
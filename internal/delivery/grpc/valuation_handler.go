@@ -0,0 +1,106 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/usecase"
+
+	pb "github.com/facelessEmptiness/inventory_service/proto"
+)
+
+type ValuationHandler struct {
+	pb.UnimplementedValuationServiceServer
+	uc *usecase.ValuationUseCase
+}
+
+func NewValuationHandler(uc *usecase.ValuationUseCase) *ValuationHandler {
+	return &ValuationHandler{uc: uc}
+}
+
+func (h *ValuationHandler) GetValuation(ctx context.Context, req *pb.ValuationRequest) (*pb.ValuationReport, error) {
+	method, err := fromValuationMethod(req.Method)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	r, err := h.uc.Valuate(ctx, method, fromTimestamp(req.AsOf), req.ProductIds, req.CategoryId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &pb.ValuationReport{
+		Method:     req.Method,
+		AsOf:       toTimestamp(r.AsOf),
+		Products:   make([]*pb.ProductValuation, 0, len(r.Products)),
+		Categories: make([]*pb.CategoryValuation, 0, len(r.Categories)),
+		Warehouses: make([]*pb.WarehouseValuation, 0, len(r.Warehouses)),
+		Quantity:   r.Quantity,
+		Value:      r.Value,
+	}
+	for _, v := range r.Products {
+		resp.Products = append(resp.Products, &pb.ProductValuation{
+			ProductId:        v.ProductID,
+			Sku:              v.SKU,
+			Name:             v.Name,
+			CategoryId:       v.CategoryID,
+			Quantity:         v.Quantity,
+			UncostedQuantity: v.UncostedQuantity,
+			Value:            v.Value,
+			UnitCost:         v.UnitCost(),
+		})
+	}
+	for _, c := range r.Categories {
+		resp.Categories = append(resp.Categories, &pb.CategoryValuation{
+			CategoryId: c.CategoryID,
+			Quantity:   c.Quantity,
+			Value:      c.Value,
+		})
+	}
+	for _, w := range r.Warehouses {
+		resp.Warehouses = append(resp.Warehouses, &pb.WarehouseValuation{
+			Location: w.Location,
+			Quantity: w.Quantity,
+			Value:    w.Value,
+		})
+	}
+	return resp, nil
+}
+
+func (h *ValuationHandler) GetCostLayers(ctx context.Context, req *pb.CostLayersRequest) (*pb.CostLayers, error) {
+	method, err := fromValuationMethod(req.Method)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	layers, uncosted, err := h.uc.CostLayers(ctx, req.ProductId, method, fromTimestamp(req.AsOf))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &pb.CostLayers{
+		ProductId:        req.ProductId,
+		Layers:           make([]*pb.CostLayer, 0, len(layers)),
+		UncostedQuantity: uncosted,
+	}
+	for _, l := range layers {
+		resp.Layers = append(resp.Layers, &pb.CostLayer{
+			ReceivedAt: toTimestamp(l.ReceivedAt),
+			Quantity:   l.Quantity,
+			UnitCost:   l.UnitCost,
+			Reference:  l.Reference,
+		})
+	}
+	return resp, nil
+}
+
+var valuationMethods = map[domain.ValuationMethod]pb.ValuationMethod{
+	domain.ValuationFIFO:            pb.ValuationMethod_VALUATION_METHOD_FIFO,
+	domain.ValuationWeightedAverage: pb.ValuationMethod_VALUATION_METHOD_WEIGHTED_AVERAGE,
+}
+
+func fromValuationMethod(m pb.ValuationMethod) (domain.ValuationMethod, error) {
+	for method, v := range valuationMethods {
+		if v == m {
+			return method, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown valuation method %s", domain.ErrInvalidArgument, m)
+}
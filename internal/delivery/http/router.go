@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/facelessEmptiness/inventory_service/internal/health"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func NewRouter(reg *prometheus.Registry, checker *health.Checker, serveMetrics bool) *gin.Engine {
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	if serveMetrics {
+		router.GET("/metrics", gin.WrapH(promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg})))
+	}
+
+	// Liveness only says the process is able to answer; readiness reflects
+	// whether MongoDB is reachable.
+	router.GET("/healthz", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	router.GET("/readyz", func(c *gin.Context) {
+		if err := checker.Err(); err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ready"})
+	})
+
+	return router
+}
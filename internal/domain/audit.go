@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditCreate      AuditAction = "create"
+	AuditUpdate      AuditAction = "update"
+	AuditDelete      AuditAction = "delete"
+	AuditRestore     AuditAction = "restore"
+	AuditPurge       AuditAction = "purge"
+	AuditStockChange AuditAction = "stock_change"
+)
+
+// AuditEvent records one mutation of a product: who made it, through which
+// RPC, and how each changed field looked before and after. Actor is only a
+// claim of the caller unless ActorVerified is set; Peer is the address the
+// call came from.
+type AuditEvent struct {
+	ID            string
+	ProductID     string
+	Action        AuditAction
+	Actor         string
+	ActorVerified bool
+	Peer          string
+	RPC           string
+	RequestID     string
+	OccurredAt    time.Time
+	Changes       []FieldChange
+}
+
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// AuditFilter selects audit events; zero fields match everything. Events in
+// [From, To) are returned, newest first.
+type AuditFilter struct {
+	ProductID string
+	Actor     string
+	From      time.Time
+	To        time.Time
+	Limit     int64
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Bundle is a kit sold as one item but made of several component products.
+// It has no stock of its own; availability is derived from the components.
+type Bundle struct {
+	ID         string
+	Name       string
+	SKU        string
+	Components []BundleComponent
+	CreatedAt  time.Time
+}
+
+type BundleComponent struct {
+	ProductID string
+	Quantity  int32
+}
+
+func (b *Bundle) Validate() error {
+	if b.Name == "" {
+		return fmt.Errorf("%w: bundle name is required", ErrInvalidArgument)
+	}
+	if len(b.Components) == 0 {
+		return fmt.Errorf("%w: bundle needs at least one component", ErrInvalidArgument)
+	}
+	seen := make(map[string]bool, len(b.Components))
+	for _, c := range b.Components {
+		if c.ProductID == "" || c.Quantity <= 0 {
+			return fmt.Errorf("%w: every component needs a product and a positive quantity", ErrInvalidArgument)
+		}
+		if seen[c.ProductID] {
+			return fmt.Errorf("%w: product %s appears in more than one component", ErrInvalidArgument, c.ProductID)
+		}
+		seen[c.ProductID] = true
+	}
+	return nil
+}
+
+// Availability is the number of complete bundles that can be assembled from
+// the given component stock levels, i.e. the minimum over all components.
+func (b *Bundle) Availability(stock map[string]int32) int32 {
+	var available int32 = -1
+	for _, c := range b.Components {
+		n := stock[c.ProductID] / c.Quantity
+		if n < 0 {
+			n = 0
+		}
+		if available < 0 || n < available {
+			available = n
+		}
+	}
+	if available < 0 {
+		return 0
+	}
+	return available
+}
+
+type ReservationStatus string
+
+const (
+	ReservationActive   ReservationStatus = "active"
+	ReservationReleased ReservationStatus = "released"
+)
+
+// Reservation records the component stock taken out for a bundle so that it
+// can be given back on release.
+type Reservation struct {
+	ID         string
+	BundleID   string
+	Quantity   int32
+	Lines      []ReservationLine
+	Reference  string
+	Status     ReservationStatus
+	CreatedAt  time.Time
+	ReleasedAt time.Time
+}
+
+// ReservationLine is the stock of one component a reservation holds.
+// Released is set once releasing has given the units back.
+type ReservationLine struct {
+	ProductID string
+	Quantity  int32
+	Released  bool
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type CycleCountStatus string
+
+const (
+	CycleCountOpen      CycleCountStatus = "open"
+	CycleCountApproved  CycleCountStatus = "approved"
+	CycleCountCancelled CycleCountStatus = "cancelled"
+)
+
+// CycleCount is a session in which the stock of a set of products is counted
+// physically and the differences to system stock are corrected on approval.
+type CycleCount struct {
+	ID         string
+	Status     CycleCountStatus
+	Lines      []CycleCountLine
+	CreatedAt  time.Time
+	CreatedBy  string
+	UpdatedAt  time.Time
+	ApprovedAt time.Time
+	ApprovedBy string
+	// Version is incremented on every update and used for optimistic locking.
+	Version int64
+}
+
+// CycleCountLine holds the count of one product. SystemStock is the stock the
+// system had when the count was submitted, so movements between the count and
+// its approval are not mistaken for variance.
+type CycleCountLine struct {
+	ProductID   string
+	Counted     int32
+	SystemStock int32
+	CountedAt   time.Time
+	// Reason is the movement reason the correction is booked under.
+	Reason  MovementReason
+	Applied bool
+}
+
+func (l *CycleCountLine) IsCounted() bool {
+	return !l.CountedAt.IsZero()
+}
+
+func (l *CycleCountLine) Variance() int32 {
+	return l.Counted - l.SystemStock
+}
+
+// CountEntry is one counted quantity submitted to a session.
+type CountEntry struct {
+	ProductID string
+	Counted   int32
+	Reason    MovementReason
+}
+
+func (c *CycleCount) Validate() error {
+	if len(c.Lines) == 0 {
+		return fmt.Errorf("%w: a cycle count needs at least one product", ErrInvalidArgument)
+	}
+	seen := make(map[string]bool, len(c.Lines))
+	for _, l := range c.Lines {
+		if l.ProductID == "" {
+			return fmt.Errorf("%w: product id is required", ErrInvalidArgument)
+		}
+		if seen[l.ProductID] {
+			return fmt.Errorf("%w: product %s is listed twice", ErrInvalidArgument, l.ProductID)
+		}
+		seen[l.ProductID] = true
+	}
+	return nil
+}
+
+// Record stores a counted quantity against the system stock at the time of
+// counting. Counting a product again replaces the earlier count.
+func (c *CycleCount) Record(e CountEntry, systemStock int32, at time.Time) error {
+	if c.Status != CycleCountOpen {
+		return fmt.Errorf("%w: cycle count is %s", ErrFailedPrecondition, c.Status)
+	}
+	if e.Counted < 0 {
+		return fmt.Errorf("%w: counted quantity of product %s must not be negative", ErrInvalidArgument, e.ProductID)
+	}
+	if e.Reason == "" {
+		e.Reason = MovementCycleCount
+	}
+	if !e.Reason.IsAdjustment() {
+		return fmt.Errorf("%w: %q is not an adjustment reason", ErrInvalidArgument, e.Reason)
+	}
+	l := c.line(e.ProductID)
+	if l == nil {
+		return fmt.Errorf("%w: product %s is not part of this cycle count", ErrInvalidArgument, e.ProductID)
+	}
+	if l.Applied {
+		return fmt.Errorf("%w: the count of product %s has already been applied", ErrFailedPrecondition, e.ProductID)
+	}
+	l.Counted, l.SystemStock, l.CountedAt, l.Reason = e.Counted, systemStock, at, e.Reason
+	return nil
+}
+
+// Approve marks every line as applied and the session as approved. The
+// caller then books the variances and calls Unapply for any it could not.
+func (c *CycleCount) Approve(by string, at time.Time) error {
+	if c.Status != CycleCountOpen {
+		return fmt.Errorf("%w: cycle count is %s", ErrFailedPrecondition, c.Status)
+	}
+	for _, l := range c.Lines {
+		if !l.IsCounted() {
+			return fmt.Errorf("%w: product %s has not been counted", ErrFailedPrecondition, l.ProductID)
+		}
+	}
+	for i := range c.Lines {
+		c.Lines[i].Applied = true
+	}
+	c.Status, c.ApprovedBy, c.ApprovedAt = CycleCountApproved, by, at
+	return nil
+}
+
+// Unapply reopens the session with the given lines still to be applied.
+func (c *CycleCount) Unapply(productIDs []string) {
+	for _, id := range productIDs {
+		if l := c.line(id); l != nil {
+			l.Applied = false
+		}
+	}
+	c.Status, c.ApprovedBy, c.ApprovedAt = CycleCountOpen, "", time.Time{}
+}
+
+func (c *CycleCount) Cancel() error {
+	if c.Status != CycleCountOpen {
+		return fmt.Errorf("%w: cycle count is %s", ErrFailedPrecondition, c.Status)
+	}
+	for _, l := range c.Lines {
+		if l.Applied {
+			return fmt.Errorf("%w: some counts have already been applied", ErrFailedPrecondition)
+		}
+	}
+	c.Status = CycleCountCancelled
+	return nil
+}
+
+func (c *CycleCount) line(productID string) *CycleCountLine {
+	for i := range c.Lines {
+		if c.Lines[i].ProductID == productID {
+			return &c.Lines[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "errors"
+
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	ErrConflict           = errors.New("concurrent modification")
+	ErrInsufficientStock  = errors.New("insufficient stock")
+	ErrPermissionDenied   = errors.New("permission denied")
+)
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type LotStatus string
+
+const (
+	LotAvailable   LotStatus = "available"
+	LotQuarantined LotStatus = "quarantined"
+)
+
+// Lot is a batch of a lot-tracked product sharing a lot number and expiry
+// date. The stock of a lot-tracked product is the sum of its available lots.
+type Lot struct {
+	ID             string
+	ProductID      string
+	LotNumber      string
+	ManufacturedAt time.Time
+	ExpiresAt      time.Time
+	Quantity       int32
+	Status         LotStatus
+	ReceivedAt     time.Time
+	// UnitCost is what each unit of the lot cost; zero when unknown.
+	UnitCost float64
+	// Booked reports whether the lot's quantity is counted in the product's
+	// stock. An available lot is unbooked while its receipt has yet to reach
+	// stock; a quarantined lot stays booked until its quantity has been
+	// taken out of stock again.
+	Booked bool
+}
+
+func (l *Lot) Validate(now time.Time) error {
+	if l.LotNumber == "" {
+		return fmt.Errorf("%w: lot number is required", ErrInvalidArgument)
+	}
+	if l.Quantity <= 0 {
+		return fmt.Errorf("%w: lot quantity must be positive", ErrInvalidArgument)
+	}
+	if l.UnitCost < 0 {
+		return fmt.Errorf("%w: lot unit cost must not be negative", ErrInvalidArgument)
+	}
+	if l.ExpiresAt.IsZero() {
+		return fmt.Errorf("%w: lot expiry date is required", ErrInvalidArgument)
+	}
+	if !l.ManufacturedAt.IsZero() && !l.ManufacturedAt.Before(l.ExpiresAt) {
+		return fmt.Errorf("%w: lot must be manufactured before it expires", ErrInvalidArgument)
+	}
+	if !l.ExpiresAt.After(now) {
+		return fmt.Errorf("%w: lot %s has already expired", ErrInvalidArgument, l.LotNumber)
+	}
+	return nil
+}
+
+// LotAllocation is the quantity taken from one lot to fulfil a decrement.
+type LotAllocation struct {
+	LotID     string
+	LotNumber string
+	ExpiresAt time.Time
+	Quantity  int32
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// PriceRecord is one interval during which a product had a price. The
+// current price has a zero EffectiveTo.
+type PriceRecord struct {
+	ID            string
+	ProductID     string
+	Price         float64
+	EffectiveFrom time.Time
+	EffectiveTo   time.Time
+	// Source says what set the price: "create", "update" or the scheduled
+	// change that applied it.
+	Source string
+	Actor  string
+}
+
+// Covers reports whether the record was in effect at t.
+func (r *PriceRecord) Covers(t time.Time) bool {
+	return !t.Before(r.EffectiveFrom) && (r.EffectiveTo.IsZero() || t.Before(r.EffectiveTo))
+}
+
+type ScheduledPriceStatus string
+
+const (
+	ScheduledPricePending   ScheduledPriceStatus = "pending"
+	ScheduledPriceActive    ScheduledPriceStatus = "active"
+	ScheduledPriceCompleted ScheduledPriceStatus = "completed"
+	ScheduledPriceCancelled ScheduledPriceStatus = "cancelled"
+)
+
+// ScheduledPrice is a future price change. Once EffectiveFrom has passed the
+// price is applied; if EffectiveTo is set, the price in effect before is put
+// back when it passes, as for a promotion.
+type ScheduledPrice struct {
+	ID            string
+	ProductID     string
+	Price         float64
+	EffectiveFrom time.Time
+	EffectiveTo   time.Time
+	Status        ScheduledPriceStatus
+	// PreviousPrice is the price replaced when the change was applied.
+	PreviousPrice float64
+	// HistoryPending is set while the price the change last set has yet to
+	// be written to the price history; the scheduler retries it.
+	HistoryPending bool
+	CreatedAt      time.Time
+	CreatedBy      string
+}
+
+func (s *ScheduledPrice) Validate(now time.Time) error {
+	switch {
+	case s.ProductID == "":
+		return fmt.Errorf("%w: product id is required", ErrInvalidArgument)
+	case s.Price < 0:
+		return fmt.Errorf("%w: price must not be negative", ErrInvalidArgument)
+	case s.EffectiveFrom.IsZero() || !s.EffectiveFrom.After(now):
+		return fmt.Errorf("%w: effective_from must be in the future", ErrInvalidArgument)
+	case !s.EffectiveTo.IsZero() && !s.EffectiveTo.After(s.EffectiveFrom):
+		return fmt.Errorf("%w: effective_to must be after effective_from", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// Overlaps reports whether the windows of two scheduled changes intersect.
+// An open-ended window runs forever.
+func (s *ScheduledPrice) Overlaps(o *ScheduledPrice) bool {
+	startsBeforeOtherEnds := o.EffectiveTo.IsZero() || s.EffectiveFrom.Before(o.EffectiveTo)
+	otherStartsBeforeEnd := s.EffectiveTo.IsZero() || o.EffectiveFrom.Before(s.EffectiveTo)
+	return startsBeforeOtherEnds && otherStartsBeforeEnd
+}
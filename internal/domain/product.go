@@ -1,10 +1,129 @@
 package domain
 
+import (
+	"fmt"
+	"time"
+)
+
 type Product struct {
 	ID          string
+	SKU         string
 	Name        string
 	Description string
 	Price       float64
 	Stock       int32
-	CategoryID  string
+	// DamagedStock counts returned units that cannot be sold; it is not part
+	// of Stock.
+	DamagedStock    int32
+	CategoryID      string
+	ReorderPoint    int32
+	ReorderQuantity int32
+	// VariantAttributes names the attributes, such as "size" and "colour",
+	// that distinguish the variants of a parent product.
+	VariantAttributes []string
+	Variants          []*Variant
+	// LotTracked products receive stock only through lots and are
+	// decremented first-expired-first-out.
+	LotTracked bool
+	// Serialized products are stocked as individual units with serial
+	// numbers; their stock is the number of available units.
+	Serialized bool
+	// DeletedAt is set while the product is archived; DeletedBy names who
+	// archived it.
+	DeletedAt time.Time
+	DeletedBy string
+}
+
+// Validate checks the fields a new product is created with.
+func (p *Product) Validate() error {
+	switch {
+	case p.Name == "":
+		return fmt.Errorf("%w: name must not be empty", ErrInvalidArgument)
+	case p.Price < 0:
+		return fmt.Errorf("%w: price must not be negative", ErrInvalidArgument)
+	case p.Stock < 0:
+		return fmt.Errorf("%w: stock must not be negative", ErrInvalidArgument)
+	case p.ReorderPoint < 0, p.ReorderQuantity < 0:
+		return fmt.Errorf("%w: reorder point and quantity must not be negative", ErrInvalidArgument)
+	}
+	return nil
+}
+
+func (p *Product) IsDeleted() bool {
+	return !p.DeletedAt.IsZero()
+}
+
+// IsLowStock reports whether stock has fallen to or below the reorder point.
+// Products without a reorder point are never considered low.
+func (p *Product) IsLowStock() bool {
+	return p.ReorderPoint > 0 && p.Stock <= p.ReorderPoint
+}
+
+func (p *Product) HasVariants() bool {
+	return len(p.VariantAttributes) > 0
+}
+
+// AvailableStock is the stock of the product itself, or for a parent product
+// whose variants have been loaded the total over its variants. A parent's
+// own Stock stays zero: stock moves per variant.
+func (p *Product) AvailableStock() int32 {
+	if !p.HasVariants() || p.Variants == nil {
+		return p.Stock
+	}
+	var total int32
+	for _, v := range p.Variants {
+		total += v.Stock
+	}
+	return total
+}
+
+// ProductUpdate holds the fields to change on a product; nil fields are left
+// as they are. Stock is changed through stock movements, not updates.
+type ProductUpdate struct {
+	Name            *string
+	Description     *string
+	Price           *float64
+	CategoryID      *string
+	ReorderPoint    *int32
+	ReorderQuantity *int32
+}
+
+func (u *ProductUpdate) Validate() error {
+	switch {
+	case u.Name != nil && *u.Name == "":
+		return fmt.Errorf("%w: name must not be empty", ErrInvalidArgument)
+	case u.Price != nil && *u.Price < 0:
+		return fmt.Errorf("%w: price must not be negative", ErrInvalidArgument)
+	case u.ReorderPoint != nil && *u.ReorderPoint < 0,
+		u.ReorderQuantity != nil && *u.ReorderQuantity < 0:
+		return fmt.Errorf("%w: reorder point and quantity must not be negative", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// Apply sets the fields of p that u changes.
+func (u *ProductUpdate) Apply(p *Product) {
+	if u.Name != nil {
+		p.Name = *u.Name
+	}
+	if u.Description != nil {
+		p.Description = *u.Description
+	}
+	if u.Price != nil {
+		p.Price = *u.Price
+	}
+	if u.CategoryID != nil {
+		p.CategoryID = *u.CategoryID
+	}
+	if u.ReorderPoint != nil {
+		p.ReorderPoint = *u.ReorderPoint
+	}
+	if u.ReorderQuantity != nil {
+		p.ReorderQuantity = *u.ReorderQuantity
+	}
+}
+
+func (u *ProductUpdate) IsEmpty() bool {
+	return u.Name == nil && u.Description == nil && u.Price == nil &&
+		u.CategoryID == nil && u.ReorderPoint == nil && u.ReorderQuantity == nil
 }
@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderDraft             PurchaseOrderStatus = "draft"
+	PurchaseOrderSent              PurchaseOrderStatus = "sent"
+	PurchaseOrderPartiallyReceived PurchaseOrderStatus = "partially_received"
+	PurchaseOrderReceived          PurchaseOrderStatus = "received"
+	PurchaseOrderCancelled         PurchaseOrderStatus = "cancelled"
+)
+
+type PurchaseOrder struct {
+	ID         string
+	SupplierID string
+	Lines      []PurchaseOrderLine
+	ExpectedAt time.Time
+	Status     PurchaseOrderStatus
+	// Receipts are the deliveries received against the order, oldest first.
+	Receipts  []Receipt
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Version is incremented on every update and used for optimistic locking.
+	Version int64
+}
+
+type PurchaseOrderLine struct {
+	ProductID        string
+	Quantity         int32
+	ReceivedQuantity int32
+}
+
+// Outstanding is the quantity ordered but not yet received.
+func (l PurchaseOrderLine) Outstanding() int32 {
+	return max(l.Quantity-l.ReceivedQuantity, 0)
+}
+
+// ReceiptLine is the quantity of one product physically received and, when
+// known, what each unit cost.
+type ReceiptLine struct {
+	ProductID string
+	Quantity  int32
+	UnitCost  float64
+	// Booked is set once the quantity has been added to stock.
+	Booked bool
+}
+
+// Receipt is one delivery received against a purchase order.
+type Receipt struct {
+	Lines      []ReceiptLine
+	ReceivedAt time.Time
+}
+
+// ReceiptLineRef points at a line of one of the receipts of an order.
+type ReceiptLineRef struct {
+	Receipt, Line int
+}
+
+// ReceiptLine returns the receipt line ref points at.
+func (po *PurchaseOrder) ReceiptLine(ref ReceiptLineRef) ReceiptLine {
+	return po.Receipts[ref.Receipt].Lines[ref.Line]
+}
+
+// Unbooked is the quantity of a product received but not yet added to stock.
+func (po *PurchaseOrder) Unbooked(productID string) int32 {
+	var n int32
+	for _, r := range po.Receipts {
+		for _, l := range r.Lines {
+			if l.ProductID == productID && !l.Booked {
+				n += l.Quantity
+			}
+		}
+	}
+	return n
+}
+
+func (po *PurchaseOrder) Validate() error {
+	if po.SupplierID == "" {
+		return fmt.Errorf("%w: supplier is required", ErrInvalidArgument)
+	}
+	if len(po.Lines) == 0 {
+		return fmt.Errorf("%w: purchase order needs at least one line", ErrInvalidArgument)
+	}
+	seen := make(map[string]bool, len(po.Lines))
+	for _, l := range po.Lines {
+		if l.ProductID == "" || l.Quantity <= 0 {
+			return fmt.Errorf("%w: every line needs a product and a positive quantity", ErrInvalidArgument)
+		}
+		if seen[l.ProductID] {
+			return fmt.Errorf("%w: product %s appears on more than one line", ErrInvalidArgument, l.ProductID)
+		}
+		seen[l.ProductID] = true
+	}
+	return nil
+}
+
+func (po *PurchaseOrder) Send() error {
+	if po.Status != PurchaseOrderDraft {
+		return fmt.Errorf("%w: only draft purchase orders can be sent, order is %s", ErrFailedPrecondition, po.Status)
+	}
+	po.Status = PurchaseOrderSent
+	return nil
+}
+
+func (po *PurchaseOrder) Cancel() error {
+	if po.Status != PurchaseOrderDraft && po.Status != PurchaseOrderSent {
+		return fmt.Errorf("%w: purchase order is %s and can no longer be cancelled", ErrFailedPrecondition, po.Status)
+	}
+	po.Status = PurchaseOrderCancelled
+	return nil
+}
+
+// Receive books received quantities against the order lines and records
+// them as a receipt. It marks the lines of the new receipt, and those of
+// earlier receipts that could not be added to stock, as booked and returns
+// them; the caller then adds them to stock and calls Unbook for any it could
+// not. Receiving no lines without closeShort only retries those earlier
+// lines.
+//
+// Over-delivery is accepted while the total received for a line stays within
+// overTolerance (a fraction of the ordered quantity, 0.1 meaning 10%); beyond
+// that the whole receipt is rejected. Under-delivery leaves the order
+// partially received unless closeShort is set, which closes the order with
+// whatever has arrived.
+func (po *PurchaseOrder) Receive(lines []ReceiptLine, overTolerance float64, closeShort bool, at time.Time) ([]ReceiptLineRef, error) {
+	var pending []ReceiptLineRef
+	for i, r := range po.Receipts {
+		for j, l := range r.Lines {
+			if !l.Booked {
+				pending = append(pending, ReceiptLineRef{Receipt: i, Line: j})
+			}
+		}
+	}
+	if len(lines) > 0 || closeShort {
+		if err := po.receive(lines, overTolerance, closeShort); err != nil {
+			return nil, err
+		}
+		if len(lines) > 0 {
+			r := Receipt{Lines: make([]ReceiptLine, 0, len(lines)), ReceivedAt: at}
+			for j, l := range lines {
+				r.Lines = append(r.Lines, ReceiptLine{ProductID: l.ProductID, Quantity: l.Quantity, UnitCost: l.UnitCost})
+				pending = append(pending, ReceiptLineRef{Receipt: len(po.Receipts), Line: j})
+			}
+			po.Receipts = append(po.Receipts, r)
+		}
+	} else if len(pending) == 0 {
+		return nil, fmt.Errorf("%w: nothing to receive", ErrInvalidArgument)
+	}
+	for _, ref := range pending {
+		po.Receipts[ref.Receipt].Lines[ref.Line].Booked = true
+	}
+	return pending, nil
+}
+
+// Unbook marks receipt lines as not yet added to stock, to be retried by
+// the next Receive.
+func (po *PurchaseOrder) Unbook(refs []ReceiptLineRef) {
+	for _, ref := range refs {
+		po.Receipts[ref.Receipt].Lines[ref.Line].Booked = false
+	}
+}
+
+func (po *PurchaseOrder) receive(lines []ReceiptLine, overTolerance float64, closeShort bool) error {
+	if po.Status != PurchaseOrderSent && po.Status != PurchaseOrderPartiallyReceived {
+		return fmt.Errorf("%w: cannot receive goods for a %s purchase order", ErrFailedPrecondition, po.Status)
+	}
+
+	index := make(map[string]int, len(po.Lines))
+	for i, l := range po.Lines {
+		index[l.ProductID] = i
+	}
+	received := make([]int32, len(po.Lines))
+	for _, r := range lines {
+		i, ok := index[r.ProductID]
+		if !ok {
+			return fmt.Errorf("%w: product %s is not on purchase order %s", ErrInvalidArgument, r.ProductID, po.ID)
+		}
+		if r.Quantity <= 0 {
+			return fmt.Errorf("%w: received quantity for product %s must be positive", ErrInvalidArgument, r.ProductID)
+		}
+		if r.UnitCost < 0 {
+			return fmt.Errorf("%w: unit cost of product %s must not be negative", ErrInvalidArgument, r.ProductID)
+		}
+		received[i] += r.Quantity
+	}
+
+	for i := range po.Lines {
+		l := po.Lines[i]
+		limit := int32(float64(l.Quantity) * (1 + overTolerance))
+		if l.ReceivedQuantity+received[i] > limit {
+			return fmt.Errorf("%w: over-delivery of product %s: %d ordered, %d would be received, at most %d accepted",
+				ErrInvalidArgument, l.ProductID, l.Quantity, l.ReceivedQuantity+received[i], limit)
+		}
+	}
+
+	complete := true
+	for i := range po.Lines {
+		po.Lines[i].ReceivedQuantity += received[i]
+		if po.Lines[i].ReceivedQuantity < po.Lines[i].Quantity {
+			complete = false
+		}
+	}
+	if complete || closeShort {
+		po.Status = PurchaseOrderReceived
+	} else {
+		po.Status = PurchaseOrderPartiallyReceived
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func sentOrder() *PurchaseOrder {
+	return &PurchaseOrder{
+		ID:         "po1",
+		SupplierID: "s1",
+		Status:     PurchaseOrderSent,
+		Lines: []PurchaseOrderLine{
+			{ProductID: "a", Quantity: 10},
+			{ProductID: "b", Quantity: 5},
+		},
+	}
+}
+
+func TestPurchaseOrderReceive(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     PurchaseOrderStatus
+		received   [2]int32
+		lines      []ReceiptLine
+		tolerance  float64
+		closeShort bool
+
+		err          error
+		wantStatus   PurchaseOrderStatus
+		wantReceived [2]int32
+		refs         []ReceiptLineRef
+		receipts     int
+	}{
+		{
+			name:         "everything ordered",
+			lines:        []ReceiptLine{{ProductID: "a", Quantity: 10, UnitCost: 2}, {ProductID: "b", Quantity: 5}},
+			wantStatus:   PurchaseOrderReceived,
+			wantReceived: [2]int32{10, 5},
+			refs:         []ReceiptLineRef{{0, 0}, {0, 1}},
+			receipts:     1,
+		},
+		{
+			name:         "part of the order",
+			lines:        []ReceiptLine{{ProductID: "a", Quantity: 4}},
+			wantStatus:   PurchaseOrderPartiallyReceived,
+			wantReceived: [2]int32{4, 0},
+			refs:         []ReceiptLineRef{{0, 0}},
+			receipts:     1,
+		},
+		{
+			name:         "rest of a partial receipt",
+			status:       PurchaseOrderPartiallyReceived,
+			received:     [2]int32{4, 5},
+			lines:        []ReceiptLine{{ProductID: "a", Quantity: 6}},
+			wantStatus:   PurchaseOrderReceived,
+			wantReceived: [2]int32{10, 5},
+			refs:         []ReceiptLineRef{{0, 0}},
+			receipts:     1,
+		},
+		{
+			name:         "lines of one product add up",
+			lines:        []ReceiptLine{{ProductID: "b", Quantity: 2}, {ProductID: "b", Quantity: 3}},
+			wantStatus:   PurchaseOrderPartiallyReceived,
+			wantReceived: [2]int32{0, 5},
+			refs:         []ReceiptLineRef{{0, 0}, {0, 1}},
+			receipts:     1,
+		},
+		{
+			name:         "short delivery closed",
+			lines:        []ReceiptLine{{ProductID: "a", Quantity: 4}},
+			closeShort:   true,
+			wantStatus:   PurchaseOrderReceived,
+			wantReceived: [2]int32{4, 0},
+			refs:         []ReceiptLineRef{{0, 0}},
+			receipts:     1,
+		},
+		{
+			name:         "closing short without a receipt",
+			status:       PurchaseOrderPartiallyReceived,
+			received:     [2]int32{4, 0},
+			closeShort:   true,
+			wantStatus:   PurchaseOrderReceived,
+			wantReceived: [2]int32{4, 0},
+		},
+		{
+			name:         "over-delivery within tolerance",
+			lines:        []ReceiptLine{{ProductID: "a", Quantity: 11}},
+			tolerance:    0.1,
+			wantStatus:   PurchaseOrderPartiallyReceived,
+			wantReceived: [2]int32{11, 0},
+			refs:         []ReceiptLineRef{{0, 0}},
+			receipts:     1,
+		},
+		{
+			name:      "over-delivery beyond tolerance",
+			lines:     []ReceiptLine{{ProductID: "a", Quantity: 12}, {ProductID: "b", Quantity: 5}},
+			tolerance: 0.1,
+			err:       ErrInvalidArgument,
+		},
+		{
+			name:      "tolerance counts what was received before",
+			status:    PurchaseOrderPartiallyReceived,
+			received:  [2]int32{8, 0},
+			lines:     []ReceiptLine{{ProductID: "a", Quantity: 4}},
+			tolerance: 0.1,
+			err:       ErrInvalidArgument,
+		},
+		{
+			name:  "no tolerance",
+			lines: []ReceiptLine{{ProductID: "b", Quantity: 6}},
+			err:   ErrInvalidArgument,
+		},
+		{
+			name:  "product not on the order",
+			lines: []ReceiptLine{{ProductID: "c", Quantity: 1}},
+			err:   ErrInvalidArgument,
+		},
+		{
+			name:  "quantity not positive",
+			lines: []ReceiptLine{{ProductID: "a", Quantity: 0}},
+			err:   ErrInvalidArgument,
+		},
+		{
+			name:  "negative unit cost",
+			lines: []ReceiptLine{{ProductID: "a", Quantity: 1, UnitCost: -1}},
+			err:   ErrInvalidArgument,
+		},
+		{
+			name: "nothing to receive",
+			err:  ErrInvalidArgument,
+		},
+		{
+			name:   "draft order",
+			status: PurchaseOrderDraft,
+			lines:  []ReceiptLine{{ProductID: "a", Quantity: 1}},
+			err:    ErrFailedPrecondition,
+		},
+		{
+			name:      "received order",
+			status:    PurchaseOrderReceived,
+			received:  [2]int32{10, 5},
+			lines:     []ReceiptLine{{ProductID: "a", Quantity: 1}},
+			tolerance: 0.5,
+			err:       ErrFailedPrecondition,
+		},
+		{
+			name:   "cancelled order",
+			status: PurchaseOrderCancelled,
+			lines:  []ReceiptLine{{ProductID: "a", Quantity: 1}},
+			err:    ErrFailedPrecondition,
+		},
+	}
+	at := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			po := sentOrder()
+			if tt.status != "" {
+				po.Status = tt.status
+			}
+			for i, n := range tt.received {
+				po.Lines[i].ReceivedQuantity = n
+			}
+			status := po.Status
+
+			refs, err := po.Receive(tt.lines, tt.tolerance, tt.closeShort, at)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("Receive error = %v, want %v", err, tt.err)
+				}
+				if po.Status != status || len(po.Receipts) != 0 || po.Lines[0].ReceivedQuantity != tt.received[0] || po.Lines[1].ReceivedQuantity != tt.received[1] {
+					t.Errorf("rejected receipt changed the order: %+v", po)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Receive: %v", err)
+			}
+			if po.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", po.Status, tt.wantStatus)
+			}
+			if got := [2]int32{po.Lines[0].ReceivedQuantity, po.Lines[1].ReceivedQuantity}; got != tt.wantReceived {
+				t.Errorf("received quantities = %v, want %v", got, tt.wantReceived)
+			}
+			if !reflect.DeepEqual(refs, tt.refs) {
+				t.Errorf("refs = %v, want %v", refs, tt.refs)
+			}
+			if len(po.Receipts) != tt.receipts {
+				t.Fatalf("%d receipts, want %d", len(po.Receipts), tt.receipts)
+			}
+			for _, ref := range refs {
+				want := tt.lines[ref.Line]
+				want.Booked = true
+				if l := po.ReceiptLine(ref); l != want {
+					t.Errorf("receipt line %v = %+v, want %+v", ref, l, want)
+				}
+			}
+			if tt.receipts > 0 && !po.Receipts[0].ReceivedAt.Equal(at) {
+				t.Errorf("receipt received at %v, want %v", po.Receipts[0].ReceivedAt, at)
+			}
+		})
+	}
+}
+
+// A receipt line that could not be added to stock is booked again by the
+// next Receive, whether or not that brings a new receipt.
+func TestPurchaseOrderReceiveRetriesUnbooked(t *testing.T) {
+	at := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	po := sentOrder()
+	refs, err := po.Receive([]ReceiptLine{{ProductID: "a", Quantity: 4}, {ProductID: "b", Quantity: 5}}, 0, false, at)
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+	po.Unbook(refs[1:])
+	if got := po.Unbooked("b"); got != 5 {
+		t.Fatalf("Unbooked(b) = %d, want 5", got)
+	}
+
+	refs, err = po.Receive([]ReceiptLine{{ProductID: "a", Quantity: 6}}, 0, false, at.Add(time.Hour))
+	want := []ReceiptLineRef{{Receipt: 0, Line: 1}, {Receipt: 1, Line: 0}}
+	if err != nil || !reflect.DeepEqual(refs, want) {
+		t.Fatalf("Receive with a pending line = %v, %v; want %v", refs, err, want)
+	}
+	if po.Status != PurchaseOrderReceived || po.Unbooked("b") != 0 {
+		t.Fatalf("after the second receipt: status %s, unbooked %d; want received, 0", po.Status, po.Unbooked("b"))
+	}
+
+	po.Unbook(refs)
+	refs, err = po.Receive(nil, 0, false, at.Add(2*time.Hour))
+	if err != nil || !reflect.DeepEqual(refs, want) {
+		t.Fatalf("retry of a received order = %v, %v; want %v", refs, err, want)
+	}
+	if po.Status != PurchaseOrderReceived || len(po.Receipts) != 2 || po.Unbooked("a") != 0 || po.Unbooked("b") != 0 {
+		t.Errorf("after the retry: %+v", po)
+	}
+	if po.Lines[0].ReceivedQuantity != 10 || po.Lines[1].ReceivedQuantity != 5 {
+		t.Errorf("retry received again: %+v", po.Lines)
+	}
+
+	if _, err := po.Receive(nil, 0, false, at); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Receive with nothing pending error = %v, want ErrInvalidArgument", err)
+	}
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type ReturnStatus string
+
+const (
+	// ReturnOpen returns still have units awaiting inspection.
+	ReturnOpen      ReturnStatus = "open"
+	ReturnCompleted ReturnStatus = "completed"
+)
+
+type Disposition string
+
+const (
+	// DispositionRestock units go back into available stock.
+	DispositionRestock Disposition = "restock"
+	// DispositionDamaged units go into the product's damaged stock.
+	DispositionDamaged Disposition = "damaged"
+	// DispositionVendorReturn units are held, with the damaged stock, until
+	// they are sent back to the supplier.
+	DispositionVendorReturn Disposition = "vendor_return"
+)
+
+// Return (an RMA) is a customer's return of units of an earlier order.
+type Return struct {
+	ID      string
+	OrderID string
+	Reason  string
+	Lines   []ReturnLine
+	// Inspections are the outcomes booked so far, oldest first.
+	Inspections []Inspection
+	Status      ReturnStatus
+	CreatedAt   time.Time
+	CreatedBy   string
+	UpdatedAt   time.Time
+	// Version is incremented on every update and used for optimistic locking.
+	Version int64
+}
+
+type ReturnLine struct {
+	ProductID    string
+	Quantity     int32
+	Restocked    int32
+	Damaged      int32
+	VendorReturn int32
+}
+
+// Inspected is the number of units of the line with an outcome.
+func (l *ReturnLine) Inspected() int32 {
+	return l.Restocked + l.Damaged + l.VendorReturn
+}
+
+// Inspection is the outcome for some units of one returned product.
+type Inspection struct {
+	ProductID   string
+	Quantity    int32
+	Disposition Disposition
+	// Booked is set once the units have been moved into stock.
+	Booked bool
+}
+
+// Unbooked is the number of inspected units of a product not yet moved into
+// stock.
+func (r *Return) Unbooked(productID string) int32 {
+	var n int32
+	for _, in := range r.Inspections {
+		if in.ProductID == productID && !in.Booked {
+			n += in.Quantity
+		}
+	}
+	return n
+}
+
+func (r *Return) Validate() error {
+	if r.OrderID == "" {
+		return fmt.Errorf("%w: the original order id is required", ErrInvalidArgument)
+	}
+	if len(r.Lines) == 0 {
+		return fmt.Errorf("%w: a return needs at least one line", ErrInvalidArgument)
+	}
+	seen := make(map[string]bool, len(r.Lines))
+	for _, l := range r.Lines {
+		if l.ProductID == "" || l.Quantity <= 0 {
+			return fmt.Errorf("%w: every line needs a product and a positive quantity", ErrInvalidArgument)
+		}
+		if seen[l.ProductID] {
+			return fmt.Errorf("%w: product %s appears on more than one line", ErrInvalidArgument, l.ProductID)
+		}
+		seen[l.ProductID] = true
+	}
+	return nil
+}
+
+// Inspect books inspection outcomes against the return lines, records them
+// and completes the return once every unit has one. It marks the new
+// inspections, and earlier ones whose units could not be moved into stock,
+// as booked and returns their indexes in Inspections; the caller then moves
+// the units and calls Unbook for any it could not. No inspections only
+// retries the earlier ones.
+func (r *Return) Inspect(inspections []Inspection) ([]int, error) {
+	var pending []int
+	for i, in := range r.Inspections {
+		if !in.Booked {
+			pending = append(pending, i)
+		}
+	}
+	if len(inspections) == 0 {
+		if len(pending) == 0 {
+			return nil, fmt.Errorf("%w: at least one inspection is required", ErrInvalidArgument)
+		}
+	} else {
+		if err := r.inspect(inspections); err != nil {
+			return nil, err
+		}
+		for _, in := range inspections {
+			pending = append(pending, len(r.Inspections))
+			r.Inspections = append(r.Inspections, Inspection{ProductID: in.ProductID, Quantity: in.Quantity, Disposition: in.Disposition})
+		}
+	}
+	for _, i := range pending {
+		r.Inspections[i].Booked = true
+	}
+	return pending, nil
+}
+
+// Unbook marks inspections as not yet moved into stock, to be retried by the
+// next Inspect.
+func (r *Return) Unbook(indexes []int) {
+	for _, i := range indexes {
+		r.Inspections[i].Booked = false
+	}
+}
+
+func (r *Return) inspect(inspections []Inspection) error {
+	if r.Status != ReturnOpen {
+		return fmt.Errorf("%w: return is %s", ErrFailedPrecondition, r.Status)
+	}
+	for _, in := range inspections {
+		if in.Quantity <= 0 {
+			return fmt.Errorf("%w: inspected quantity of product %s must be positive", ErrInvalidArgument, in.ProductID)
+		}
+		l := r.line(in.ProductID)
+		if l == nil {
+			return fmt.Errorf("%w: product %s is not part of this return", ErrInvalidArgument, in.ProductID)
+		}
+		if l.Inspected()+in.Quantity > l.Quantity {
+			return fmt.Errorf("%w: only %d units of product %s are awaiting inspection", ErrInvalidArgument, l.Quantity-l.Inspected(), in.ProductID)
+		}
+		switch in.Disposition {
+		case DispositionRestock:
+			l.Restocked += in.Quantity
+		case DispositionDamaged:
+			l.Damaged += in.Quantity
+		case DispositionVendorReturn:
+			l.VendorReturn += in.Quantity
+		default:
+			return fmt.Errorf("%w: unknown disposition %q", ErrInvalidArgument, in.Disposition)
+		}
+	}
+	for _, l := range r.Lines {
+		if l.Inspected() < l.Quantity {
+			return nil
+		}
+	}
+	r.Status = ReturnCompleted
+	return nil
+}
+
+func (r *Return) line(productID string) *ReturnLine {
+	for i := range r.Lines {
+		if r.Lines[i].ProductID == productID {
+			return &r.Lines[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type SerialStatus string
+
+const (
+	SerialAvailable SerialStatus = "available"
+	SerialReserved  SerialStatus = "reserved"
+	SerialSold      SerialStatus = "sold"
+	SerialReturned  SerialStatus = "returned"
+	SerialDefective SerialStatus = "defective"
+)
+
+// serialTransitions lists the statuses each status may move to.
+var serialTransitions = map[SerialStatus][]SerialStatus{
+	SerialAvailable: {SerialReserved, SerialDefective},
+	SerialReserved:  {SerialSold, SerialAvailable, SerialDefective},
+	SerialSold:      {SerialReturned},
+	SerialReturned:  {SerialAvailable, SerialDefective},
+}
+
+func (s SerialStatus) Valid() bool {
+	switch s {
+	case SerialAvailable, SerialReserved, SerialSold, SerialReturned, SerialDefective:
+		return true
+	}
+	return false
+}
+
+func (s SerialStatus) CanTransitionTo(to SerialStatus) bool {
+	for _, allowed := range serialTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// StockDelta is the change to the product's stock when a unit moves from s
+// to to: only available units count as stock.
+func (s SerialStatus) StockDelta(to SerialStatus) int32 {
+	switch {
+	case s != SerialAvailable && to == SerialAvailable:
+		return 1
+	case s == SerialAvailable && to != SerialAvailable:
+		return -1
+	}
+	return 0
+}
+
+// SerialUnit is one physical item of a serialized product. A unit that is
+// not Booked was received but has yet to reach stock; it cannot change status
+// until receiving it again books it.
+type SerialUnit struct {
+	ID         string
+	ProductID  string
+	Serial     string
+	Status     SerialStatus
+	Location   string
+	OrderID    string
+	Booked     bool
+	ReceivedAt time.Time
+	UpdatedAt  time.Time
+}
+
+func ValidateSerials(serials []string) error {
+	if len(serials) == 0 {
+		return fmt.Errorf("%w: at least one serial number is required", ErrInvalidArgument)
+	}
+	seen := make(map[string]bool, len(serials))
+	for _, s := range serials {
+		if s == "" {
+			return fmt.Errorf("%w: serial numbers must not be empty", ErrInvalidArgument)
+		}
+		if seen[s] {
+			return fmt.Errorf("%w: serial %s is listed twice", ErrInvalidArgument, s)
+		}
+		seen[s] = true
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+type MovementReason string
+
+const (
+	MovementPurchaseReceipt    MovementReason = "purchase_receipt"
+	MovementReservation        MovementReason = "reservation"
+	MovementReservationRelease MovementReason = "reservation_release"
+	MovementSale               MovementReason = "sale"
+	MovementSaleReversal       MovementReason = "sale_reversal"
+	MovementLotReceipt         MovementReason = "lot_receipt"
+	MovementLotQuarantine      MovementReason = "lot_quarantine"
+	MovementSerialReceipt      MovementReason = "serial_receipt"
+	MovementSerialStatus       MovementReason = "serial_status"
+
+	// Reason codes for manual adjustments and cycle-count corrections.
+	MovementCycleCount MovementReason = "cycle_count"
+	MovementDamage     MovementReason = "damage"
+	MovementLoss       MovementReason = "loss"
+	MovementFound      MovementReason = "found"
+	MovementCorrection MovementReason = "correction"
+
+	MovementReturnRestock  MovementReason = "return_restock"
+	MovementReturnDamaged  MovementReason = "return_damaged"
+	MovementReturnToVendor MovementReason = "return_to_vendor"
+)
+
+// StockBucket names the stock a movement changes.
+type StockBucket string
+
+const (
+	BucketAvailable StockBucket = "available"
+	BucketDamaged   StockBucket = "damaged"
+)
+
+// IsAdjustment reports whether r is a reason code accepted for manual stock
+// adjustments.
+func (r MovementReason) IsAdjustment() bool {
+	switch r {
+	case MovementCycleCount, MovementDamage, MovementLoss, MovementFound, MovementCorrection:
+		return true
+	}
+	return false
+}
+
+// StockMovement is a ledger entry recording a signed change of a product's
+// stock and why it happened.
+type StockMovement struct {
+	ID        string
+	ProductID string
+	// VariantID is set when the movement changed the stock of one variant
+	// of the product rather than the product's own.
+	VariantID string
+	Quantity  int32
+	Reason    MovementReason
+	Bucket    StockBucket
+	// UnitCost is what each unit cost on receipts where the cost is known
+	// and zero on every other movement.
+	UnitCost  float64
+	Reference string
+	CreatedAt time.Time
+}
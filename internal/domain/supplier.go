@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type Supplier struct {
+	ID           string
+	Name         string
+	ContactEmail string
+	Phone        string
+	CreatedAt    time.Time
+}
+
+func (s *Supplier) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("%w: supplier name is required", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// ProductSupplier describes the terms under which a supplier delivers a
+// product. A product can have many suppliers and a supplier many products.
+type ProductSupplier struct {
+	ProductID        string
+	SupplierID       string
+	SupplierSKU      string
+	UnitCost         float64
+	LeadTimeDays     int32
+	MinOrderQuantity int32
+}
+
+func (ps *ProductSupplier) Validate() error {
+	if ps.ProductID == "" || ps.SupplierID == "" {
+		return fmt.Errorf("%w: product and supplier are required", ErrInvalidArgument)
+	}
+	if ps.UnitCost < 0 || ps.LeadTimeDays < 0 || ps.MinOrderQuantity < 0 {
+		return fmt.Errorf("%w: unit cost, lead time and minimum order quantity must not be negative", ErrInvalidArgument)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+type ValuationMethod string
+
+const (
+	ValuationFIFO            ValuationMethod = "fifo"
+	ValuationWeightedAverage ValuationMethod = "weighted_average"
+)
+
+func (m ValuationMethod) Validate() error {
+	if m != ValuationFIFO && m != ValuationWeightedAverage {
+		return fmt.Errorf("%w: unknown valuation method %q", ErrInvalidArgument, m)
+	}
+	return nil
+}
+
+// CostLayer is a quantity of stock on hand carried at one unit cost. Under
+// FIFO every receipt opens a layer; under weighted average there is a single
+// layer at the average cost.
+type CostLayer struct {
+	ReceivedAt time.Time
+	Quantity   int32
+	UnitCost   float64
+	Reference  string
+}
+
+// CostBook replays the stock movements of a product into the cost layers of
+// its available stock. Damaged stock is not valued.
+//
+// Receipts with a unit cost open a layer at that cost. Other inbound
+// movements, such as released reservations or restocked returns, are carried
+// at the current average cost. Units for which no cost is known at all, like
+// stock entered when the product was created, are uncosted: they are valued
+// at zero and taken out first.
+type CostBook struct {
+	method   ValuationMethod
+	layers   []CostLayer
+	uncosted int32
+	lastCost float64
+}
+
+// NewCostBook starts a book holding opening units of unknown cost.
+func NewCostBook(method ValuationMethod, opening int32) *CostBook {
+	return &CostBook{method: method, uncosted: max(opening, 0)}
+}
+
+func (b *CostBook) Apply(m *StockMovement) {
+	if m.Bucket == BucketDamaged || m.Quantity == 0 {
+		return
+	}
+	if m.Quantity < 0 {
+		b.remove(-m.Quantity)
+		return
+	}
+	cost := m.UnitCost
+	if cost <= 0 {
+		cost = b.averageCost()
+	}
+	if cost <= 0 {
+		b.uncosted += m.Quantity
+		return
+	}
+	b.lastCost = cost
+	if b.method == ValuationWeightedAverage && len(b.layers) > 0 {
+		l := &b.layers[0]
+		total := l.Quantity + m.Quantity
+		l.UnitCost = (float64(l.Quantity)*l.UnitCost + float64(m.Quantity)*cost) / float64(total)
+		l.Quantity = total
+		return
+	}
+	b.layers = append(b.layers, CostLayer{
+		ReceivedAt: m.CreatedAt,
+		Quantity:   m.Quantity,
+		UnitCost:   cost,
+		Reference:  m.Reference,
+	})
+}
+
+func (b *CostBook) remove(quantity int32) {
+	taken := min(quantity, b.uncosted)
+	b.uncosted -= taken
+	quantity -= taken
+	for quantity > 0 && len(b.layers) > 0 {
+		l := &b.layers[0]
+		taken = min(quantity, l.Quantity)
+		l.Quantity -= taken
+		quantity -= taken
+		if l.Quantity == 0 {
+			b.layers = b.layers[1:]
+		}
+	}
+}
+
+// averageCost is the average unit cost of the costed stock on hand or, with
+// none on hand, the last cost seen.
+func (b *CostBook) averageCost() float64 {
+	var quantity int32
+	var value float64
+	for _, l := range b.layers {
+		quantity += l.Quantity
+		value += float64(l.Quantity) * l.UnitCost
+	}
+	if quantity == 0 {
+		return b.lastCost
+	}
+	return value / float64(quantity)
+}
+
+// Layers returns the costed layers, oldest first.
+func (b *CostBook) Layers() []CostLayer {
+	return append([]CostLayer(nil), b.layers...)
+}
+
+func (b *CostBook) Uncosted() int32 {
+	return b.uncosted
+}
+
+func (b *CostBook) Quantity() int32 {
+	quantity := b.uncosted
+	for _, l := range b.layers {
+		quantity += l.Quantity
+	}
+	return quantity
+}
+
+func (b *CostBook) Value() float64 {
+	var value float64
+	for _, l := range b.layers {
+		value += float64(l.Quantity) * l.UnitCost
+	}
+	return value
+}
+
+// ReplayCostBook builds the cost book of a product as of a moment from its
+// movements, oldest first. The stock held at that moment is worked back from
+// the product's current stock, so stock that never went through the ledger
+// shows up as uncosted opening units.
+func ReplayCostBook(p *Product, movements []*StockMovement, method ValuationMethod, asOf time.Time) *CostBook {
+	var before, after int32
+	for _, m := range movements {
+		if m.Bucket == BucketDamaged {
+			continue
+		}
+		if m.CreatedAt.After(asOf) {
+			after += m.Quantity
+		} else {
+			before += m.Quantity
+		}
+	}
+	b := NewCostBook(method, p.Stock-after-before)
+	for _, m := range movements {
+		if !m.CreatedAt.After(asOf) {
+			b.Apply(m)
+		}
+	}
+	return b
+}
+
+type ProductValuation struct {
+	ProductID  string
+	SKU        string
+	Name       string
+	CategoryID string
+	Quantity   int32
+	// UncostedQuantity is the part of Quantity valued at zero for want of
+	// a known cost.
+	UncostedQuantity int32
+	Value            float64
+}
+
+// UnitCost is the average cost of the costed units.
+func (v *ProductValuation) UnitCost() float64 {
+	costed := v.Quantity - v.UncostedQuantity
+	if costed <= 0 {
+		return 0
+	}
+	return v.Value / float64(costed)
+}
+
+// SplitByLocation spreads the valuation over the locations holding the
+// product's units, each unit carried at the product's average book value.
+// Units not accounted for by a location, which is all of them for products
+// that are not serialized, fall under the empty location.
+func (v *ProductValuation) SplitByLocation(units map[string]int32) []WarehouseValuation {
+	locations := make([]string, 0, len(units))
+	for l, n := range units {
+		if l != "" && n > 0 {
+			locations = append(locations, l)
+		}
+	}
+	sort.Strings(locations)
+
+	split := make([]WarehouseValuation, 0, len(locations)+1)
+	quantity, value := v.Quantity, v.Value
+	for _, l := range locations {
+		if quantity <= 0 {
+			break
+		}
+		n := min(units[l], quantity)
+		w := WarehouseValuation{Location: l, Quantity: n, Value: v.Value * float64(n) / float64(v.Quantity)}
+		split = append(split, w)
+		quantity -= n
+		value -= w.Value
+	}
+	if quantity > 0 {
+		split = append(split, WarehouseValuation{Quantity: quantity, Value: value})
+	}
+	return split
+}
+
+type CategoryValuation struct {
+	CategoryID string
+	Quantity   int32
+	Value      float64
+}
+
+// WarehouseValuation is the stock held at one location. Only serial units
+// record where they are kept, so the empty location holds everything else.
+type WarehouseValuation struct {
+	Location string
+	Quantity int32
+	Value    float64
+}
+
+type ValuationReport struct {
+	Method     ValuationMethod
+	AsOf       time.Time
+	Products   []ProductValuation
+	Categories []CategoryValuation
+	// Warehouses is only filled in for a report on the present, as units
+	// keep no history of where they were held.
+	Warehouses []WarehouseValuation
+	Quantity   int32
+	Value      float64
+}
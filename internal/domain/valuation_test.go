@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var day = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func movement(quantity int32, unitCost float64, days int) *StockMovement {
+	return &StockMovement{
+		ProductID: "p1",
+		Quantity:  quantity,
+		Bucket:    BucketAvailable,
+		UnitCost:  unitCost,
+		CreatedAt: day.AddDate(0, 0, days),
+	}
+}
+
+func damaged(quantity int32, days int) *StockMovement {
+	m := movement(quantity, 0, days)
+	m.Bucket = BucketDamaged
+	return m
+}
+
+func TestCostBook(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    ValuationMethod
+		opening   int32
+		movements []*StockMovement
+		layers    []CostLayer
+		uncosted  int32
+		value     float64
+	}{
+		{
+			name:      "fifo takes the oldest layer first",
+			method:    ValuationFIFO,
+			movements: []*StockMovement{movement(5, 2, 0), movement(5, 4, 1), movement(-7, 0, 2)},
+			layers:    []CostLayer{{ReceivedAt: day.AddDate(0, 0, 1), Quantity: 3, UnitCost: 4}},
+			value:     12,
+		},
+		{
+			name:      "weighted average keeps one layer",
+			method:    ValuationWeightedAverage,
+			movements: []*StockMovement{movement(5, 2, 0), movement(5, 4, 1), movement(-7, 0, 2)},
+			layers:    []CostLayer{{ReceivedAt: day, Quantity: 3, UnitCost: 3}},
+			value:     9,
+		},
+		{
+			name:      "uncosted units go out first",
+			method:    ValuationFIFO,
+			opening:   4,
+			movements: []*StockMovement{movement(2, 10, 0), movement(-5, 0, 1)},
+			layers:    []CostLayer{{ReceivedAt: day, Quantity: 1, UnitCost: 10}},
+			value:     10,
+		},
+		{
+			name:      "inbound without a cost is carried at the average",
+			method:    ValuationFIFO,
+			movements: []*StockMovement{movement(4, 2, 0), movement(4, 4, 1), movement(2, 0, 2)},
+			layers: []CostLayer{
+				{ReceivedAt: day, Quantity: 4, UnitCost: 2},
+				{ReceivedAt: day.AddDate(0, 0, 1), Quantity: 4, UnitCost: 4},
+				{ReceivedAt: day.AddDate(0, 0, 2), Quantity: 2, UnitCost: 3},
+			},
+			value: 30,
+		},
+		{
+			name:      "inbound after selling out is carried at the last cost",
+			method:    ValuationWeightedAverage,
+			movements: []*StockMovement{movement(2, 5, 0), movement(-2, 0, 1), movement(1, 0, 2)},
+			layers:    []CostLayer{{ReceivedAt: day.AddDate(0, 0, 2), Quantity: 1, UnitCost: 5}},
+			value:     5,
+		},
+		{
+			name:      "inbound with no cost ever seen is uncosted",
+			method:    ValuationFIFO,
+			movements: []*StockMovement{movement(3, 0, 0)},
+			uncosted:  3,
+		},
+		{
+			name:      "damaged stock is not valued",
+			method:    ValuationFIFO,
+			movements: []*StockMovement{movement(5, 2, 0), damaged(3, 1), damaged(-1, 2)},
+			layers:    []CostLayer{{ReceivedAt: day, Quantity: 5, UnitCost: 2}},
+			value:     10,
+		},
+		{
+			name:    "negative opening stock counts as none",
+			method:  ValuationFIFO,
+			opening: -2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewCostBook(tt.method, tt.opening)
+			for _, m := range tt.movements {
+				b.Apply(m)
+			}
+			if got := b.Layers(); !reflect.DeepEqual(got, tt.layers) {
+				t.Errorf("Layers() = %+v, want %+v", got, tt.layers)
+			}
+			if got := b.Uncosted(); got != tt.uncosted {
+				t.Errorf("Uncosted() = %d, want %d", got, tt.uncosted)
+			}
+			var quantity int32
+			for _, l := range tt.layers {
+				quantity += l.Quantity
+			}
+			if got := b.Quantity(); got != quantity+tt.uncosted {
+				t.Errorf("Quantity() = %d, want %d", got, quantity+tt.uncosted)
+			}
+			if got := b.Value(); got != tt.value {
+				t.Errorf("Value() = %v, want %v", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestReplayCostBook(t *testing.T) {
+	tests := []struct {
+		name      string
+		stock     int32
+		movements []*StockMovement
+		asOf      time.Time
+		quantity  int32
+		uncosted  int32
+		value     float64
+	}{
+		{
+			name:      "stock that never went through the ledger is uncosted opening stock",
+			stock:     8,
+			movements: []*StockMovement{movement(5, 2, 0)},
+			asOf:      day.AddDate(0, 0, 1),
+			quantity:  8,
+			uncosted:  3,
+			value:     10,
+		},
+		{
+			name:      "movements after the moment are worked back out",
+			stock:     6,
+			movements: []*StockMovement{movement(5, 2, 0), movement(3, 4, 2), movement(-2, 0, 2)},
+			asOf:      day.AddDate(0, 0, 1),
+			quantity:  5,
+			value:     10,
+		},
+		{
+			name:      "a movement at the moment itself counts",
+			stock:     8,
+			movements: []*StockMovement{movement(5, 2, 0), movement(3, 4, 1)},
+			asOf:      day.AddDate(0, 0, 1),
+			quantity:  8,
+			value:     22,
+		},
+		{
+			name:      "opening stock before the first movement",
+			stock:     7,
+			movements: []*StockMovement{movement(5, 2, 1)},
+			asOf:      day,
+			quantity:  2,
+			uncosted:  2,
+		},
+		{
+			name:      "damaged movements do not touch available stock",
+			stock:     5,
+			movements: []*StockMovement{movement(5, 2, 0), damaged(2, 1)},
+			asOf:      day.AddDate(0, 0, 2),
+			quantity:  5,
+			value:     10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Product{ID: "p1", Stock: tt.stock}
+			for _, method := range []ValuationMethod{ValuationFIFO, ValuationWeightedAverage} {
+				b := ReplayCostBook(p, tt.movements, method, tt.asOf)
+				if b.Quantity() != tt.quantity || b.Uncosted() != tt.uncosted || b.Value() != tt.value {
+					t.Errorf("%s: quantity %d, uncosted %d, value %v; want %d, %d, %v",
+						method, b.Quantity(), b.Uncosted(), b.Value(), tt.quantity, tt.uncosted, tt.value)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitByLocation(t *testing.T) {
+	tests := []struct {
+		name  string
+		v     ProductValuation
+		units map[string]int32
+		want  []WarehouseValuation
+	}{
+		{
+			name:  "remainder under the empty location",
+			v:     ProductValuation{Quantity: 10, Value: 30},
+			units: map[string]int32{"B": 3, "A": 4},
+			want:  []WarehouseValuation{{"A", 4, 12}, {"B", 3, 9}, {"", 3, 9}},
+		},
+		{
+			name:  "more units located than valued",
+			v:     ProductValuation{Quantity: 5, Value: 10},
+			units: map[string]int32{"A": 4, "B": 3},
+			want:  []WarehouseValuation{{"A", 4, 8}, {"B", 1, 2}},
+		},
+		{
+			name: "not serialized",
+			v:    ProductValuation{Quantity: 5, Value: 10},
+			want: []WarehouseValuation{{"", 5, 10}},
+		},
+		{
+			name:  "units without a location",
+			v:     ProductValuation{Quantity: 5, Value: 10},
+			units: map[string]int32{"": 2, "A": 3},
+			want:  []WarehouseValuation{{"A", 3, 6}, {"", 2, 4}},
+		},
+		{
+			name:  "no stock",
+			v:     ProductValuation{},
+			units: map[string]int32{"A": 3},
+			want:  []WarehouseValuation{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.SplitByLocation(tt.units); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitByLocation(%v) = %+v, want %+v", tt.units, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Variant is a sellable version of a parent product, such as one size and
+// colour of a shirt. It carries its own SKU and stock and may override the
+// parent's price.
+type Variant struct {
+	ID            string
+	ProductID     string
+	SKU           string
+	Attributes    map[string]string
+	PriceOverride *float64
+	Stock         int32
+	CreatedAt     time.Time
+}
+
+// Price returns the override if set and the parent's price otherwise.
+func (v *Variant) Price(parent *Product) float64 {
+	if v.PriceOverride != nil {
+		return *v.PriceOverride
+	}
+	return parent.Price
+}
+
+// ValidateFor checks the variant against the attribute definitions of its
+// parent: every defined attribute needs a value and no others are allowed.
+func (v *Variant) ValidateFor(parent *Product) error {
+	if !parent.HasVariants() {
+		return fmt.Errorf("%w: product %s defines no variant attributes", ErrFailedPrecondition, parent.ID)
+	}
+	if v.SKU == "" {
+		return fmt.Errorf("%w: variant SKU is required", ErrInvalidArgument)
+	}
+	if v.Stock < 0 {
+		return fmt.Errorf("%w: variant stock must not be negative", ErrInvalidArgument)
+	}
+	if v.PriceOverride != nil && *v.PriceOverride < 0 {
+		return fmt.Errorf("%w: variant price must not be negative", ErrInvalidArgument)
+	}
+	for _, name := range parent.VariantAttributes {
+		if v.Attributes[name] == "" {
+			return fmt.Errorf("%w: variant attribute %q is required", ErrInvalidArgument, name)
+		}
+	}
+	if len(v.Attributes) != len(parent.VariantAttributes) {
+		return fmt.Errorf("%w: variant attributes must be exactly %v", ErrInvalidArgument, parent.VariantAttributes)
+	}
+	return nil
+}
+
+// SameAttributes reports whether both variants describe the same combination.
+func (v *Variant) SameAttributes(other *Variant) bool {
+	if len(v.Attributes) != len(other.Attributes) {
+		return false
+	}
+	for k, val := range v.Attributes {
+		if other.Attributes[k] != val {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,32 @@
+package event
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+const TypeLowStock = "inventory.low_stock"
+
+type Event struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+func New(eventType string, data interface{}) Event {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return Event{
+		ID:         hex.EncodeToString(b),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+}
@@ -0,0 +1,57 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// WebhookPublisher POSTs every event as JSON to a single URL. The event id is
+// sent in the X-Event-ID header so receivers can drop redeliveries.
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookPublisher(url string, timeout time.Duration) *WebhookPublisher {
+	return &WebhookPublisher{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", e.ID)
+	req.Header.Set("X-Event-Type", e.Type)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded with %s", p.url, resp.Status)
+	}
+	return nil
+}
+
+// LogPublisher only logs events; it is used when no webhook is configured.
+type LogPublisher struct{}
+
+func (LogPublisher) Publish(ctx context.Context, e Event) error {
+	data, _ := json.Marshal(e.Data)
+	slog.InfoContext(ctx, "event", "type", e.Type, "id", e.ID, "data", string(data))
+	return nil
+}
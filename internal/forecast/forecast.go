@@ -0,0 +1,203 @@
+// Package forecast estimates product demand from the stock ledger.
+package forecast
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+type Method string
+
+const (
+	MovingAverage        Method = "moving_average"
+	ExponentialSmoothing Method = "exponential_smoothing"
+)
+
+// Demand is the expected demand per day and its standard deviation.
+type Demand struct {
+	Daily  float64
+	StdDev float64
+}
+
+// IsDemand reports whether a movement moves units out of stock for a
+// customer or back from one: sales and their reversals, reservations and
+// their releases, restocked returns, and serial units allocated to orders.
+// Quantities are signed, so releases and restocked returns net off demand.
+func IsDemand(m *domain.StockMovement) bool {
+	switch m.Reason {
+	case domain.MovementSale, domain.MovementSaleReversal,
+		domain.MovementReservation, domain.MovementReservationRelease, domain.MovementReturnRestock:
+		return true
+	case domain.MovementSerialStatus:
+		return strings.HasPrefix(m.Reference, "order:")
+	}
+	return false
+}
+
+// DailySeries buckets the demand movements of one product into the days
+// starting at from, oldest first. Days on which reversals, releases and
+// returns outweigh sales and reservations count as no demand.
+func DailySeries(movements []*domain.StockMovement, from time.Time, days int) []float64 {
+	series := make([]float64, days)
+	for _, m := range movements {
+		if !IsDemand(m) || m.CreatedAt.Before(from) {
+			continue
+		}
+		day := int(m.CreatedAt.Sub(from) / (24 * time.Hour))
+		if day >= days {
+			continue
+		}
+		series[day] -= float64(m.Quantity)
+	}
+	for i, v := range series {
+		series[i] = math.Max(v, 0)
+	}
+	return series
+}
+
+// Estimate forecasts daily demand from a series with the given method. The
+// moving average uses the last window days; exponential smoothing weighs
+// every day with smoothing factor alpha and measures variability by its
+// one-day-ahead forecast errors.
+func Estimate(series []float64, method Method, window int, alpha float64) (Demand, error) {
+	switch method {
+	case MovingAverage:
+		if window <= 0 {
+			return Demand{}, fmt.Errorf("%w: moving average window must be positive", domain.ErrInvalidArgument)
+		}
+		return movingAverage(series, window), nil
+	case ExponentialSmoothing:
+		if alpha <= 0 || alpha > 1 {
+			return Demand{}, fmt.Errorf("%w: smoothing factor must be in (0, 1]", domain.ErrInvalidArgument)
+		}
+		return exponentialSmoothing(series, alpha), nil
+	}
+	return Demand{}, fmt.Errorf("%w: unknown forecast method %q", domain.ErrInvalidArgument, method)
+}
+
+func movingAverage(series []float64, window int) Demand {
+	if len(series) > window {
+		series = series[len(series)-window:]
+	}
+	if len(series) == 0 {
+		return Demand{}
+	}
+	var sum float64
+	for _, v := range series {
+		sum += v
+	}
+	mean := sum / float64(len(series))
+	if len(series) < 2 {
+		return Demand{Daily: mean}
+	}
+	var squares float64
+	for _, v := range series {
+		squares += (v - mean) * (v - mean)
+	}
+	return Demand{Daily: mean, StdDev: math.Sqrt(squares / float64(len(series)-1))}
+}
+
+func exponentialSmoothing(series []float64, alpha float64) Demand {
+	if len(series) == 0 {
+		return Demand{}
+	}
+	level := series[0]
+	var squares float64
+	for _, v := range series[1:] {
+		squares += (v - level) * (v - level)
+		level = alpha*v + (1-alpha)*level
+	}
+	if len(series) < 2 {
+		return Demand{Daily: level}
+	}
+	return Demand{Daily: level, StdDev: math.Sqrt(squares / float64(len(series)-1))}
+}
+
+// ServiceFactor is the number of standard deviations of safety stock needed
+// to avoid a stock-out in a replenishment cycle with the given probability.
+func ServiceFactor(serviceLevel float64) (float64, error) {
+	if serviceLevel <= 0 || serviceLevel >= 1 {
+		return 0, fmt.Errorf("%w: service level must be between 0 and 1", domain.ErrInvalidArgument)
+	}
+	return math.Sqrt2 * math.Erfinv(2*serviceLevel-1), nil
+}
+
+// Plan is the replenishment plan for one product.
+type Plan struct {
+	SafetyStock  int32
+	ReorderPoint int32
+	// Quantity is what to order now to bring the stock position, stock on
+	// hand plus stock on order, up to the reorder point plus the demand of
+	// the review period; zero when no order is needed.
+	Quantity int32
+}
+
+// Replenish plans for demand d over a lead time and review period given in
+// days, with service factor z, for the given stock position. A positive
+// quantity is raised to minOrder.
+func Replenish(d Demand, leadTimeDays, reviewDays, z float64, position, minOrder int32) Plan {
+	safety := math.Ceil(z * d.StdDev * math.Sqrt(leadTimeDays))
+	reorderPoint := math.Ceil(d.Daily*leadTimeDays) + max(safety, 0)
+	p := Plan{SafetyStock: int32(max(safety, 0)), ReorderPoint: int32(reorderPoint)}
+	if float64(position) > reorderPoint {
+		return p
+	}
+	p.Quantity = int32(math.Ceil(reorderPoint + d.Daily*reviewDays - float64(position)))
+	if p.Quantity > 0 && p.Quantity < minOrder {
+		p.Quantity = minOrder
+	}
+	return p
+}
+
+// Params are the inputs of a forecast and reorder plan. Zero fields take the
+// value of the defaults they are merged with.
+type Params struct {
+	Method       Method
+	HistoryDays  int
+	WindowDays   int
+	Alpha        float64
+	ServiceLevel float64
+	LeadTimeDays float64
+	ReviewDays   float64
+}
+
+// Or returns p with its zero fields taken from defaults.
+func (p Params) Or(defaults Params) Params {
+	if p.Method == "" {
+		p.Method = defaults.Method
+	}
+	if p.HistoryDays == 0 {
+		p.HistoryDays = defaults.HistoryDays
+	}
+	if p.WindowDays == 0 {
+		p.WindowDays = defaults.WindowDays
+	}
+	if p.Alpha == 0 {
+		p.Alpha = defaults.Alpha
+	}
+	if p.ServiceLevel == 0 {
+		p.ServiceLevel = defaults.ServiceLevel
+	}
+	if p.LeadTimeDays == 0 {
+		p.LeadTimeDays = defaults.LeadTimeDays
+	}
+	if p.ReviewDays == 0 {
+		p.ReviewDays = defaults.ReviewDays
+	}
+	return p
+}
+
+func (p Params) Validate() error {
+	if p.HistoryDays <= 0 || p.LeadTimeDays < 0 || p.ReviewDays < 0 {
+		return fmt.Errorf("%w: history must be positive and lead time and review period must not be negative", domain.ErrInvalidArgument)
+	}
+	if _, err := ServiceFactor(p.ServiceLevel); err != nil {
+		return err
+	}
+	_, err := Estimate(nil, p.Method, p.WindowDays, p.Alpha)
+	return err
+}
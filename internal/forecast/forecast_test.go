@@ -0,0 +1,265 @@
+package forecast
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+var day = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func movement(reason domain.MovementReason, quantity int32, days int, reference string) *domain.StockMovement {
+	return &domain.StockMovement{
+		ProductID: "p1",
+		Quantity:  quantity,
+		Reason:    reason,
+		Reference: reference,
+		CreatedAt: day.AddDate(0, 0, days).Add(time.Hour),
+	}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestIsDemand(t *testing.T) {
+	tests := []struct {
+		reason    domain.MovementReason
+		reference string
+		want      bool
+	}{
+		{domain.MovementSale, "", true},
+		{domain.MovementSaleReversal, "", true},
+		{domain.MovementReservation, "reservation:r1", true},
+		{domain.MovementReservationRelease, "reservation:r1", true},
+		{domain.MovementReturnRestock, "return:r1", true},
+		{domain.MovementReturnDamaged, "return:r1", false},
+		{domain.MovementPurchaseReceipt, "po:p1", false},
+		{domain.MovementCycleCount, "cycle_count:c1", false},
+		{domain.MovementSerialStatus, "order:o1", true},
+		{domain.MovementSerialStatus, "serial:s1", false},
+	}
+	for _, tt := range tests {
+		if got := IsDemand(movement(tt.reason, -1, 0, tt.reference)); got != tt.want {
+			t.Errorf("IsDemand(%s, %q) = %v, want %v", tt.reason, tt.reference, got, tt.want)
+		}
+	}
+}
+
+func TestDailySeries(t *testing.T) {
+	tests := []struct {
+		name      string
+		movements []*domain.StockMovement
+		days      int
+		want      []float64
+	}{
+		{
+			name: "returns net off sales",
+			movements: []*domain.StockMovement{
+				movement(domain.MovementSale, -5, 0, ""),
+				movement(domain.MovementReturnRestock, 2, 0, "return:r1"),
+			},
+			days: 2,
+			want: []float64{3, 0},
+		},
+		{
+			name: "a day of more releases than reservations is no demand",
+			movements: []*domain.StockMovement{
+				movement(domain.MovementReservation, -1, 1, "reservation:r2"),
+				movement(domain.MovementReservationRelease, 4, 1, "reservation:r1"),
+				movement(domain.MovementSale, -2, 2, ""),
+			},
+			days: 3,
+			want: []float64{0, 0, 2},
+		},
+		{
+			name: "receipts and serial moves outside orders are left out",
+			movements: []*domain.StockMovement{
+				movement(domain.MovementPurchaseReceipt, 10, 0, "po:p1"),
+				movement(domain.MovementSerialStatus, -1, 0, "order:o1"),
+				movement(domain.MovementSerialStatus, -1, 0, "serial:s1"),
+			},
+			days: 1,
+			want: []float64{1},
+		},
+		{
+			name: "movements outside the days are left out",
+			movements: []*domain.StockMovement{
+				movement(domain.MovementSale, -9, -1, ""),
+				movement(domain.MovementSale, -3, 1, ""),
+				movement(domain.MovementSale, -9, 2, ""),
+			},
+			days: 2,
+			want: []float64{0, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DailySeries(tt.movements, day, tt.days); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DailySeries = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		name    string
+		series  []float64
+		method  Method
+		window  int
+		alpha   float64
+		want    Demand
+		invalid bool
+	}{
+		{
+			name:   "moving average over the last window days",
+			series: []float64{1, 2, 3, 4, 5},
+			method: MovingAverage,
+			window: 3,
+			want:   Demand{Daily: 4, StdDev: 1},
+		},
+		{
+			name:   "moving average window longer than the series",
+			series: []float64{2, 4},
+			method: MovingAverage,
+			window: 7,
+			want:   Demand{Daily: 3, StdDev: math.Sqrt2},
+		},
+		{
+			name:   "moving average of one day has no deviation",
+			series: []float64{5},
+			method: MovingAverage,
+			window: 7,
+			want:   Demand{Daily: 5},
+		},
+		{
+			name:   "moving average of no days",
+			method: MovingAverage,
+			window: 7,
+		},
+		{name: "zero window", series: []float64{1}, method: MovingAverage, invalid: true},
+		{name: "negative window", series: []float64{1}, method: MovingAverage, window: -1, invalid: true},
+		{
+			name:   "smoothing with alpha 1 follows the last day",
+			series: []float64{1, 2, 3},
+			method: ExponentialSmoothing,
+			alpha:  1,
+			want:   Demand{Daily: 3, StdDev: 1},
+		},
+		{
+			name:   "smoothing measures one-day-ahead errors",
+			series: []float64{4, 0, 4},
+			method: ExponentialSmoothing,
+			alpha:  0.5,
+			want:   Demand{Daily: 3, StdDev: math.Sqrt(10)},
+		},
+		{
+			name:   "smoothing one day",
+			series: []float64{6},
+			method: ExponentialSmoothing,
+			alpha:  0.3,
+			want:   Demand{Daily: 6},
+		},
+		{name: "zero alpha", series: []float64{1}, method: ExponentialSmoothing, invalid: true},
+		{name: "alpha above 1", series: []float64{1}, method: ExponentialSmoothing, alpha: 1.5, invalid: true},
+		{name: "unknown method", series: []float64{1}, method: "median", window: 7, alpha: 0.3, invalid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Estimate(tt.series, tt.method, tt.window, tt.alpha)
+			if tt.invalid {
+				if !errors.Is(err, domain.ErrInvalidArgument) {
+					t.Errorf("Estimate error = %v, want ErrInvalidArgument", err)
+				}
+				return
+			}
+			if err != nil || !near(got.Daily, tt.want.Daily) || !near(got.StdDev, tt.want.StdDev) {
+				t.Errorf("Estimate = %+v, %v; want %+v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceFactor(t *testing.T) {
+	tests := []struct {
+		level   float64
+		want    float64
+		invalid bool
+	}{
+		{level: 0.5, want: 0},
+		{level: 0.95, want: 1.644854},
+		{level: 0.975, want: 1.959964},
+		{level: 0.1, want: -1.281552},
+		{level: 0, invalid: true},
+		{level: 1, invalid: true},
+		{level: 1.2, invalid: true},
+	}
+	for _, tt := range tests {
+		got, err := ServiceFactor(tt.level)
+		if tt.invalid {
+			if !errors.Is(err, domain.ErrInvalidArgument) {
+				t.Errorf("ServiceFactor(%v) error = %v, want ErrInvalidArgument", tt.level, err)
+			}
+			continue
+		}
+		if err != nil || !near(got, tt.want) {
+			t.Errorf("ServiceFactor(%v) = %v, %v; want %v", tt.level, got, err, tt.want)
+		}
+	}
+}
+
+func TestReplenish(t *testing.T) {
+	d := Demand{Daily: 2, StdDev: 1}
+	tests := []struct {
+		name     string
+		d        Demand
+		z        float64
+		position int32
+		minOrder int32
+		want     Plan
+	}{
+		{name: "order up to the reorder point and review demand", d: d, z: 2, want: Plan{SafetyStock: 4, ReorderPoint: 12, Quantity: 26}},
+		{name: "above the reorder point", d: d, z: 2, position: 13, want: Plan{SafetyStock: 4, ReorderPoint: 12}},
+		{name: "at the reorder point", d: d, z: 2, position: 12, want: Plan{SafetyStock: 4, ReorderPoint: 12, Quantity: 14}},
+		{name: "raised to the minimum order", d: d, z: 2, minOrder: 50, want: Plan{SafetyStock: 4, ReorderPoint: 12, Quantity: 50}},
+		{name: "above the minimum order", d: d, z: 2, minOrder: 10, want: Plan{SafetyStock: 4, ReorderPoint: 12, Quantity: 26}},
+		{name: "negative service factor holds no safety stock", d: d, z: -1, want: Plan{ReorderPoint: 8, Quantity: 22}},
+		{name: "no demand orders nothing despite a minimum", z: 2, minOrder: 10, want: Plan{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Replenish(tt.d, 4, 7, tt.z, tt.position, tt.minOrder); got != tt.want {
+				t.Errorf("Replenish = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParams(t *testing.T) {
+	defaults := Params{Method: MovingAverage, HistoryDays: 90, WindowDays: 28, Alpha: 0.3, ServiceLevel: 0.95, LeadTimeDays: 7, ReviewDays: 7}
+	got := Params{Method: ExponentialSmoothing, Alpha: 0.5}.Or(defaults)
+	want := defaults
+	want.Method, want.Alpha = ExponentialSmoothing, 0.5
+	if got != want {
+		t.Errorf("Or = %+v, want %+v", got, want)
+	}
+	if err := got.Validate(); err != nil {
+		t.Errorf("Validate(%+v) = %v", got, err)
+	}
+	for _, p := range []Params{
+		{HistoryDays: -1},
+		{LeadTimeDays: -1},
+		{ServiceLevel: 1},
+		{Method: MovingAverage, WindowDays: -1},
+	} {
+		p = p.Or(defaults)
+		if err := p.Validate(); !errors.Is(err, domain.ErrInvalidArgument) {
+			t.Errorf("Validate(%+v) = %v, want ErrInvalidArgument", p, err)
+		}
+	}
+}
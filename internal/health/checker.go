@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var errNotChecked = errors.New("health has not been checked yet")
+
+// Checker periodically pings the backing store and mirrors the result into
+// the gRPC health server, for the overall server ("") and every registered
+// service.
+type Checker struct {
+	server   *health.Server
+	ping     func(ctx context.Context) error
+	interval time.Duration
+	services []string
+
+	mu      sync.RWMutex
+	lastErr error
+}
+
+func NewChecker(server *health.Server, ping func(ctx context.Context) error, interval time.Duration, services ...string) *Checker {
+	return &Checker{
+		server:   server,
+		ping:     ping,
+		interval: interval,
+		services: append([]string{""}, services...),
+		lastErr:  errNotChecked,
+	}
+}
+
+// Run checks immediately and then on every interval until ctx is done, at
+// which point every service is switched to NOT_SERVING so that in-flight
+// probes see the shutdown.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.check(ctx)
+		select {
+		case <-ctx.Done():
+			c.server.Shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Err returns the result of the latest check, nil meaning ready.
+func (c *Checker) Err() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastErr
+}
+
+func (c *Checker) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.interval)
+	defer cancel()
+
+	err := c.ping(ctx)
+
+	c.mu.Lock()
+	changed := (err == nil) != (c.lastErr == nil)
+	c.lastErr = err
+	c.mu.Unlock()
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if err != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	if changed {
+		slog.Info("health: status changed", "status", status.String(), "err", err)
+	}
+	for _, svc := range c.services {
+		c.server.SetServingStatus(svc, status)
+	}
+}
@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "inventory"
+
+type Metrics struct {
+	rpcRequests   *prometheus.CounterVec
+	rpcDuration   *prometheus.HistogramVec
+	mongoDuration *prometheus.HistogramVec
+	mongoErrors   *prometheus.CounterVec
+	cacheLookups  *prometheus.CounterVec
+	throttled     *prometheus.CounterVec
+}
+
+func New(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		rpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "Total number of gRPC requests handled, by method and status code.",
+		}, []string{"method", "code"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of gRPC requests, by method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		mongoDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "mongo",
+			Name:      "operation_duration_seconds",
+			Help:      "Duration of MongoDB commands, by command and collection.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"command", "collection"}),
+		mongoErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "mongo",
+			Name:      "operation_errors_total",
+			Help:      "Total number of failed MongoDB commands, by command and collection.",
+		}, []string{"command", "collection"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "cache",
+			Name:      "lookups_total",
+			Help:      "Total number of product cache lookups, by backend and result (hit or miss).",
+		}, []string{"backend", "result"}),
+		throttled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "throttled_total",
+			Help:      "Total number of gRPC requests rejected by the rate limiter, by method.",
+		}, []string{"method"}),
+	}
+	reg.MustRegister(m.rpcRequests, m.rpcDuration, m.mongoDuration, m.mongoErrors, m.cacheLookups, m.throttled)
+	return m
+}
+
+func (m *Metrics) CacheResult(backend string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheLookups.WithLabelValues(backend, result).Inc()
+}
+
+func (m *Metrics) Throttled(method string) {
+	m.throttled.WithLabelValues(method).Inc()
+}
+
+// NewRegistry returns a registry preloaded with the Go runtime and process collectors.
+func NewRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return reg
+}
@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// MongoMonitor returns a command monitor that records the duration and
+// failures of every command sent through the client it is attached to.
+func (m *Metrics) MongoMonitor() *event.CommandMonitor {
+	var collections sync.Map // request id -> collection name
+
+	finished := func(evt event.CommandFinishedEvent, failed bool) {
+		collection := ""
+		if v, ok := collections.LoadAndDelete(evt.RequestID); ok {
+			collection = v.(string)
+		}
+		m.mongoDuration.WithLabelValues(evt.CommandName, collection).Observe(evt.Duration.Seconds())
+		if failed {
+			m.mongoErrors.WithLabelValues(evt.CommandName, collection).Inc()
+		}
+	}
+
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+			collection, _ := evt.Command.Lookup(evt.CommandName).StringValueOK()
+			collections.Store(evt.RequestID, collection)
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			finished(evt.CommandFinishedEvent, false)
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			finished(evt.CommandFinishedEvent, true)
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type StockCounter interface {
+	Count(ctx context.Context) (int64, error)
+	CountOutOfStock(ctx context.Context) (int64, error)
+}
+
+// stockCollector queries the repository on every scrape, so the gauges
+// always reflect the current catalog instead of a cached snapshot.
+type stockCollector struct {
+	repo       StockCounter
+	products   *prometheus.Desc
+	outOfStock *prometheus.Desc
+}
+
+func NewStockCollector(repo StockCounter) prometheus.Collector {
+	return &stockCollector{
+		repo: repo,
+		products: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "products"),
+			"Number of products in the catalog.", nil, nil),
+		outOfStock: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "products_out_of_stock"),
+			"Number of products with no stock left.", nil, nil),
+	}
+}
+
+func (c *stockCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.products
+	ch <- c.outOfStock
+}
+
+func (c *stockCollector) Collect(ch chan<- prometheus.Metric) {
+	if n, err := c.repo.Count(context.Background()); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.products, prometheus.GaugeValue, float64(n))
+	}
+	if n, err := c.repo.CountOutOfStock(context.Background()); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.outOfStock, prometheus.GaugeValue, float64(n))
+	}
+}
@@ -0,0 +1,160 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Indexes declares the indexes of every collection, keyed by collection
+// name. Creating an index that already exists with the same name and keys
+// does nothing, so a changed index needs a new name, and a migration that
+// drops the old one. There are no tenant indexes: nothing in the service is
+// scoped to a tenant yet.
+func Indexes(productsCollection string) map[string][]mongo.IndexModel {
+	return map[string][]mongo.IndexModel{
+		productsCollection: {
+			// Products without a SKU have no sku field, so only strings are
+			// indexed and any number of them may lack one.
+			index("sku_unique", bson.D{{Key: "sku", Value: 1}},
+				options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"sku": bson.M{"$type": "string"}})),
+			index("name_description_text", bson.D{{Key: "name", Value: "text"}, {Key: "description", Value: "text"}},
+				options.Index().SetWeights(bson.M{"name": 10, "description": 1})),
+			index("category_id", bson.D{{Key: "category_id", Value: 1}}, nil),
+			index("deleted_at", bson.D{{Key: "deleted_at", Value: 1}}, nil),
+		},
+		"product_variants": {
+			index("sku_unique", bson.D{{Key: "sku", Value: 1}}, options.Index().SetUnique(true)),
+			index("product_id", bson.D{{Key: "product_id", Value: 1}}, nil),
+		},
+		"stock_movements": {
+			index("product_id_created_at", bson.D{{Key: "product_id", Value: 1}, {Key: "created_at", Value: 1}}, nil),
+			index("reason_created_at", bson.D{{Key: "reason", Value: 1}, {Key: "created_at", Value: 1}}, nil),
+		},
+		"audit_events": {
+			index("product_id_occurred_at", bson.D{{Key: "product_id", Value: 1}, {Key: "occurred_at", Value: -1}}, nil),
+			index("actor_occurred_at", bson.D{{Key: "actor", Value: 1}, {Key: "occurred_at", Value: -1}}, nil),
+		},
+		"bundles": {
+			index("components_product_id", bson.D{{Key: "components.product_id", Value: 1}}, nil),
+		},
+		"lots": {
+			index("product_id_lot_number_unique", bson.D{{Key: "product_id", Value: 1}, {Key: "lot_number", Value: 1}},
+				options.Index().SetUnique(true)),
+			index("status_expires_at", bson.D{{Key: "status", Value: 1}, {Key: "expires_at", Value: 1}}, nil),
+			index("status_booked", bson.D{{Key: "status", Value: 1}, {Key: "booked", Value: 1}}, nil),
+		},
+		"serial_units": {
+			index("serial_unique", bson.D{{Key: "serial", Value: 1}}, options.Index().SetUnique(true)),
+			index("product_id_status_received_at",
+				bson.D{{Key: "product_id", Value: 1}, {Key: "status", Value: 1}, {Key: "received_at", Value: 1}}, nil),
+		},
+		"product_suppliers": {
+			index("product_id_supplier_id_unique", bson.D{{Key: "product_id", Value: 1}, {Key: "supplier_id", Value: 1}},
+				options.Index().SetUnique(true)),
+			index("supplier_id", bson.D{{Key: "supplier_id", Value: 1}}, nil),
+		},
+		"purchase_orders": {
+			index("status", bson.D{{Key: "status", Value: 1}}, nil),
+		},
+		"reservations": {
+			index("status_created_at", bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}, nil),
+			index("lines_product_id", bson.D{{Key: "lines.product_id", Value: 1}}, nil),
+		},
+		"price_history": {
+			index("product_id_effective_from", bson.D{{Key: "product_id", Value: 1}, {Key: "effective_from", Value: -1}}, nil),
+		},
+		"scheduled_prices": {
+			index("product_id_status", bson.D{{Key: "product_id", Value: 1}, {Key: "status", Value: 1}}, nil),
+			index("status_effective_from", bson.D{{Key: "status", Value: 1}, {Key: "effective_from", Value: 1}}, nil),
+			index("history_pending", bson.D{{Key: "history_pending", Value: 1}},
+				options.Index().SetPartialFilterExpression(bson.M{"history_pending": true})),
+		},
+		"returns": {
+			index("order_id_created_at", bson.D{{Key: "order_id", Value: 1}, {Key: "created_at", Value: 1}}, nil),
+		},
+	}
+}
+
+// EnsureIndexes creates the declared indexes that do not exist yet.
+func EnsureIndexes(ctx context.Context, db *mongo.Database, productsCollection string) error {
+	for coll, models := range Indexes(productsCollection) {
+		if _, err := db.Collection(coll).Indexes().CreateMany(ctx, models); err != nil {
+			return fmt.Errorf("create indexes on %s: %w", coll, err)
+		}
+	}
+	return nil
+}
+
+// uniqueSKUs names the collections whose sku_unique index rejects duplicate
+// SKUs.
+func uniqueSKUs(productsCollection string) []string {
+	return []string{productsCollection, "product_variants"}
+}
+
+// CheckDuplicates reports SKUs stored more than once in a collection whose
+// sku_unique index has not been created yet, which would make creating it
+// fail. The duplicates have to be resolved by hand: which of the products
+// keeps the SKU is not for a migration to decide.
+func CheckDuplicates(ctx context.Context, db *mongo.Database, productsCollection string) error {
+	for _, name := range uniqueSKUs(productsCollection) {
+		coll := db.Collection(name)
+		exists, err := hasIndex(ctx, coll, "sku_unique")
+		if err != nil {
+			return fmt.Errorf("list indexes of %s: %w", name, err)
+		}
+		if exists {
+			continue
+		}
+		cur, err := coll.Aggregate(ctx, mongo.Pipeline{
+			{{Key: "$match", Value: bson.M{"sku": bson.M{"$type": "string"}}}},
+			{{Key: "$group", Value: bson.M{"_id": "$sku", "ids": bson.M{"$push": "$_id"}, "count": bson.M{"$sum": 1}}}},
+			{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+			{{Key: "$limit", Value: 10}},
+		})
+		if err != nil {
+			return fmt.Errorf("find duplicate SKUs in %s: %w", name, err)
+		}
+		var dups []struct {
+			SKU string        `bson:"_id"`
+			IDs []interface{} `bson:"ids"`
+		}
+		if err := cur.All(ctx, &dups); err != nil {
+			return fmt.Errorf("find duplicate SKUs in %s: %w", name, err)
+		}
+		if len(dups) == 0 {
+			continue
+		}
+		lines := make([]string, 0, len(dups))
+		for _, d := range dups {
+			lines = append(lines, fmt.Sprintf("  %s: %v", d.SKU, d.IDs))
+		}
+		return fmt.Errorf("%s holds SKUs used more than once (at most 10 shown); give each document its own SKU before migrating:\n%s",
+			name, strings.Join(lines, "\n"))
+	}
+	return nil
+}
+
+func hasIndex(ctx context.Context, coll *mongo.Collection, name string) (bool, error) {
+	names, err := coll.Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, spec := range names {
+		if spec.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func index(name string, keys bson.D, opts *options.IndexOptions) mongo.IndexModel {
+	if opts == nil {
+		opts = options.Index()
+	}
+	return mongo.IndexModel{Keys: keys, Options: opts.SetName(name)}
+}
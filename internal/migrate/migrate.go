@@ -0,0 +1,122 @@
+// Package migrate upgrades the MongoDB schema: it creates the indexes the
+// repositories rely on and reshapes documents written by older versions. It
+// also holds the schema of the PostgreSQL store.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Migration is one versioned schema change. Up must be idempotent: it may
+// run again if the service stops before the migration is recorded, or when
+// two instances start at once.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// Record is a migration recorded as applied.
+type Record struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// Migrator applies migrations in version order and records each applied one
+// in the schema_migrations collection. Indexes are not versioned: every Up
+// creates those declared by Indexes that are missing.
+type Migrator struct {
+	db                 *mongo.Database
+	records            *mongo.Collection
+	productsCollection string
+	migrations         []Migration
+}
+
+func New(db *mongo.Database, productsCollection string, migrations []Migration) (*Migrator, error) {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	for i, m := range sorted {
+		if m.Version <= 0 || m.Up == nil {
+			return nil, fmt.Errorf("migration %d: version must be positive and Up set", m.Version)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("migration %d is declared twice", m.Version)
+		}
+	}
+	return &Migrator{db: db, records: db.Collection("schema_migrations"), productsCollection: productsCollection, migrations: sorted}, nil
+}
+
+// Applied returns the recorded migrations, oldest version first.
+func (m *Migrator) Applied(ctx context.Context) ([]Record, error) {
+	cur, err := m.records.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var records []Record
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Pending returns the migrations not yet recorded, in the order Up applies
+// them.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	records, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[int]bool, len(records))
+	for _, r := range records {
+		applied[r.Version] = true
+	}
+	var pending []Migration
+	for _, mig := range m.migrations {
+		if !applied[mig.Version] {
+			pending = append(pending, mig)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies the pending migrations, then creates missing indexes, and
+// returns the migrations it applied. It stops at the first migration that
+// fails, leaving it and the later ones pending. Before anything it checks
+// that no unique index is about to fail on duplicates already stored.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	if err := CheckDuplicates(ctx, m.db, m.productsCollection); err != nil {
+		return nil, err
+	}
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var done []Migration
+	for _, mig := range pending {
+		if err := mig.Up(ctx, m.db); err != nil {
+			return done, fmt.Errorf("migration %d (%s): %w", mig.Version, mig.Description, err)
+		}
+		_, err := m.records.InsertOne(ctx, Record{
+			Version:     mig.Version,
+			Description: mig.Description,
+			AppliedAt:   time.Now().UTC(),
+		})
+		// Another instance may have applied and recorded it meanwhile.
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return done, fmt.Errorf("record migration %d: %w", mig.Version, err)
+		}
+		done = append(done, mig)
+	}
+	if err := EnsureIndexes(ctx, m.db, m.productsCollection); err != nil {
+		return done, err
+	}
+	return done, nil
+}
@@ -0,0 +1,58 @@
+package migrate
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Migrations lists the schema changes of the service. New ones are appended
+// with the next version; applied ones must not be changed.
+func Migrations(productsCollection string) []Migration {
+	return []Migration{
+		{
+			Version:     1,
+			Description: "create collection indexes",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				return EnsureIndexes(ctx, db, productsCollection)
+			},
+		},
+		{
+			Version:     2,
+			Description: "set the bucket of stock movements recorded before damaged stock existed",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection("stock_movements").UpdateMany(ctx,
+					bson.M{"bucket": bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{"bucket": "available"}})
+				return err
+			},
+		},
+		{
+			Version:     3,
+			Description: "mark lots recorded before the booked flag existed",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				lots := db.Collection("lots")
+				if _, err := lots.UpdateMany(ctx,
+					bson.M{"booked": bson.M{"$exists": false}, "status": "available"},
+					bson.M{"$set": bson.M{"booked": true}}); err != nil {
+					return err
+				}
+				_, err := lots.UpdateMany(ctx,
+					bson.M{"booked": bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{"booked": false}})
+				return err
+			},
+		},
+		{
+			Version:     4,
+			Description: "mark serial units recorded before the booked flag existed",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection("serial_units").UpdateMany(ctx,
+					bson.M{"booked": bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{"booked": true}})
+				return err
+			},
+		},
+	}
+}
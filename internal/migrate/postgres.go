@@ -0,0 +1,314 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SQLMigration is one versioned schema change of the PostgreSQL store,
+// applied in a transaction together with its record.
+type SQLMigration struct {
+	Version     int
+	Description string
+	SQL         string
+}
+
+// PostgresMigrations returns the schema of the PostgreSQL store. Version 2
+// adds a table for each of the other MongoDB collections, with the indexes
+// Indexes declares for them; their ids are ObjectIDs the repositories generate.
+func PostgresMigrations() []SQLMigration {
+	return []SQLMigration{
+		{
+			Version:     1,
+			Description: "create products table",
+			SQL: `
+CREATE TABLE IF NOT EXISTS products (
+	id                 text PRIMARY KEY DEFAULT gen_random_uuid()::text,
+	sku                text,
+	name               text NOT NULL,
+	description        text NOT NULL DEFAULT '',
+	price              double precision NOT NULL DEFAULT 0,
+	stock              integer NOT NULL DEFAULT 0 CHECK (stock >= 0),
+	damaged_stock      integer NOT NULL DEFAULT 0 CHECK (damaged_stock >= 0),
+	category_id        text NOT NULL DEFAULT '',
+	reorder_point      integer NOT NULL DEFAULT 0,
+	reorder_quantity   integer NOT NULL DEFAULT 0,
+	low_stock_alerted  boolean NOT NULL DEFAULT false,
+	variant_attributes text[] NOT NULL DEFAULT '{}',
+	lot_tracked        boolean NOT NULL DEFAULT false,
+	serialized         boolean NOT NULL DEFAULT false,
+	deleted_at         timestamptz,
+	deleted_by         text NOT NULL DEFAULT '',
+	created_at         timestamptz NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS products_sku_unique ON products (sku) WHERE sku IS NOT NULL;
+CREATE INDEX IF NOT EXISTS products_category_id ON products (category_id);
+CREATE INDEX IF NOT EXISTS products_deleted_at ON products (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS products_low_stock ON products (id) WHERE reorder_point > 0 AND stock <= reorder_point;
+`,
+		},
+		{
+			Version:     2,
+			Description: "create tables of the other collections",
+			SQL: `
+CREATE TABLE IF NOT EXISTS audit_events (
+	id             text PRIMARY KEY,
+	product_id     text NOT NULL,
+	action         text NOT NULL,
+	actor          text NOT NULL DEFAULT '',
+	actor_verified boolean NOT NULL DEFAULT false,
+	peer           text NOT NULL DEFAULT '',
+	rpc            text NOT NULL DEFAULT '',
+	request_id     text NOT NULL DEFAULT '',
+	occurred_at    timestamptz NOT NULL,
+	changes        jsonb
+);
+CREATE INDEX IF NOT EXISTS audit_events_product_id ON audit_events (product_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS audit_events_actor ON audit_events (actor, occurred_at DESC);
+
+CREATE TABLE IF NOT EXISTS stock_movements (
+	id         text PRIMARY KEY,
+	product_id text NOT NULL,
+	variant_id text NOT NULL DEFAULT '',
+	quantity   integer NOT NULL,
+	reason     text NOT NULL,
+	bucket     text NOT NULL DEFAULT '',
+	unit_cost  double precision NOT NULL DEFAULT 0,
+	reference  text NOT NULL DEFAULT '',
+	created_at timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS stock_movements_product_id ON stock_movements (product_id, created_at);
+CREATE INDEX IF NOT EXISTS stock_movements_reason ON stock_movements (reason, created_at);
+
+CREATE TABLE IF NOT EXISTS suppliers (
+	id            text PRIMARY KEY,
+	name          text NOT NULL,
+	contact_email text NOT NULL DEFAULT '',
+	phone         text NOT NULL DEFAULT '',
+	created_at    timestamptz NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS product_suppliers (
+	product_id         text NOT NULL,
+	supplier_id        text NOT NULL,
+	supplier_sku       text NOT NULL DEFAULT '',
+	unit_cost          double precision NOT NULL DEFAULT 0,
+	lead_time_days     integer NOT NULL DEFAULT 0,
+	min_order_quantity integer NOT NULL DEFAULT 0,
+	PRIMARY KEY (product_id, supplier_id)
+);
+CREATE INDEX IF NOT EXISTS product_suppliers_supplier_id ON product_suppliers (supplier_id);
+
+CREATE TABLE IF NOT EXISTS product_variants (
+	id             text PRIMARY KEY,
+	product_id     text NOT NULL,
+	sku            text NOT NULL UNIQUE,
+	attributes     jsonb,
+	price_override double precision,
+	stock          integer NOT NULL DEFAULT 0 CHECK (stock >= 0),
+	created_at     timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS product_variants_product_id ON product_variants (product_id);
+
+CREATE TABLE IF NOT EXISTS bundles (
+	id         text PRIMARY KEY,
+	name       text NOT NULL,
+	sku        text NOT NULL DEFAULT '',
+	components jsonb,
+	created_at timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS bundles_components ON bundles USING gin (components jsonb_path_ops);
+
+CREATE TABLE IF NOT EXISTS reservations (
+	id          text PRIMARY KEY,
+	bundle_id   text NOT NULL DEFAULT '',
+	quantity    integer NOT NULL DEFAULT 0,
+	lines       jsonb,
+	reference   text NOT NULL DEFAULT '',
+	status      text NOT NULL,
+	created_at  timestamptz NOT NULL,
+	released_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS reservations_status ON reservations (status, created_at DESC);
+CREATE INDEX IF NOT EXISTS reservations_lines ON reservations USING gin (lines jsonb_path_ops);
+
+CREATE TABLE IF NOT EXISTS lots (
+	id              text PRIMARY KEY,
+	product_id      text NOT NULL,
+	lot_number      text NOT NULL,
+	manufactured_at timestamptz,
+	expires_at      timestamptz NOT NULL,
+	quantity        integer NOT NULL DEFAULT 0,
+	unit_cost       double precision NOT NULL DEFAULT 0,
+	status          text NOT NULL,
+	received_at     timestamptz NOT NULL,
+	booked          boolean NOT NULL DEFAULT false,
+	UNIQUE (product_id, lot_number)
+);
+CREATE INDEX IF NOT EXISTS lots_status_expires_at ON lots (status, expires_at);
+CREATE INDEX IF NOT EXISTS lots_status_booked ON lots (status, booked);
+
+CREATE TABLE IF NOT EXISTS serial_units (
+	id          text PRIMARY KEY,
+	product_id  text NOT NULL,
+	serial      text NOT NULL UNIQUE,
+	status      text NOT NULL,
+	location    text NOT NULL DEFAULT '',
+	order_id    text NOT NULL DEFAULT '',
+	booked      boolean NOT NULL DEFAULT false,
+	received_at timestamptz NOT NULL,
+	updated_at  timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS serial_units_product_id ON serial_units (product_id, status, received_at);
+
+CREATE TABLE IF NOT EXISTS price_history (
+	id             text PRIMARY KEY,
+	product_id     text NOT NULL,
+	price          double precision NOT NULL,
+	effective_from timestamptz NOT NULL,
+	effective_to   timestamptz,
+	source         text NOT NULL DEFAULT '',
+	actor          text NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS price_history_product_id ON price_history (product_id, effective_from DESC);
+
+CREATE TABLE IF NOT EXISTS scheduled_prices (
+	id              text PRIMARY KEY,
+	product_id      text NOT NULL,
+	price           double precision NOT NULL,
+	effective_from  timestamptz NOT NULL,
+	effective_to    timestamptz,
+	status          text NOT NULL,
+	previous_price  double precision NOT NULL DEFAULT 0,
+	history_pending boolean NOT NULL DEFAULT false,
+	created_at      timestamptz NOT NULL,
+	created_by      text NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS scheduled_prices_product_id ON scheduled_prices (product_id, status);
+CREATE INDEX IF NOT EXISTS scheduled_prices_status ON scheduled_prices (status, effective_from);
+CREATE INDEX IF NOT EXISTS scheduled_prices_history_pending ON scheduled_prices (id) WHERE history_pending;
+
+CREATE TABLE IF NOT EXISTS cycle_counts (
+	id          text PRIMARY KEY,
+	status      text NOT NULL,
+	lines       jsonb,
+	created_at  timestamptz NOT NULL,
+	created_by  text NOT NULL DEFAULT '',
+	updated_at  timestamptz NOT NULL,
+	approved_at timestamptz,
+	approved_by text NOT NULL DEFAULT '',
+	version     bigint NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+	id          text PRIMARY KEY,
+	supplier_id text NOT NULL,
+	lines       jsonb,
+	expected_at timestamptz,
+	status      text NOT NULL,
+	receipts    jsonb,
+	created_at  timestamptz NOT NULL,
+	updated_at  timestamptz NOT NULL,
+	version     bigint NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS purchase_orders_status ON purchase_orders (status);
+
+CREATE TABLE IF NOT EXISTS returns (
+	id          text PRIMARY KEY,
+	order_id    text NOT NULL,
+	reason      text NOT NULL DEFAULT '',
+	lines       jsonb,
+	inspections jsonb,
+	status      text NOT NULL,
+	created_at  timestamptz NOT NULL,
+	created_by  text NOT NULL DEFAULT '',
+	updated_at  timestamptz NOT NULL,
+	version     bigint NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS returns_order_id ON returns (order_id, created_at);
+`,
+		},
+	}
+}
+
+// postgresLock serialises instances migrating the same database.
+const postgresLock = 4471_0001
+
+// UpPostgres applies the migrations not yet recorded in the
+// schema_migrations table and returns those it applied. Each runs in its own
+// transaction under an advisory lock, so a failing one leaves no trace and
+// concurrent instances apply every migration once.
+func UpPostgres(ctx context.Context, pool *pgxpool.Pool, migrations []SQLMigration) ([]SQLMigration, error) {
+	if _, err := pool.Exec(ctx, `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+	version     integer PRIMARY KEY,
+	description text NOT NULL,
+	applied_at  timestamptz NOT NULL DEFAULT now()
+)`); err != nil {
+		return nil, fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	var done []SQLMigration
+	for _, mig := range migrations {
+		applied, err := applyPostgres(ctx, pool, mig)
+		if err != nil {
+			return done, fmt.Errorf("migration %d (%s): %w", mig.Version, mig.Description, err)
+		}
+		if applied {
+			done = append(done, mig)
+		}
+	}
+	return done, nil
+}
+
+func applyPostgres(ctx context.Context, pool *pgxpool.Pool, mig SQLMigration) (bool, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, postgresLock); err != nil {
+		return false, err
+	}
+	var exists bool
+	if err := tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, mig.Version).Scan(&exists); err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+	// The simple protocol lets one migration hold several statements.
+	if _, err := tx.Exec(ctx, mig.SQL, pgx.QueryExecModeSimpleProtocol); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO schema_migrations (version, description) VALUES ($1, $2)`,
+		mig.Version, mig.Description); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+// AppliedPostgres returns the migrations recorded in the schema_migrations
+// table in version order, none when the table does not exist yet.
+func AppliedPostgres(ctx context.Context, pool *pgxpool.Pool) ([]Record, error) {
+	var exists bool
+	if err := pool.QueryRow(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil || !exists {
+		return nil, err
+	}
+	rows, err := pool.Query(ctx, `SELECT version, description, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (Record, error) {
+		var r Record
+		err := row.Scan(&r.Version, &r.Description, &r.AppliedAt)
+		r.AppliedAt = r.AppliedAt.UTC()
+		return r, err
+	})
+}
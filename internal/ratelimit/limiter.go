@@ -0,0 +1,137 @@
+// Package ratelimit throttles gRPC calls with a token bucket per client and
+// RPC, so that one noisy client cannot starve the others.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/audit"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RetryAfterKey is the response header telling a throttled client how many
+// seconds to wait before calling again.
+const RetryAfterKey = "retry-after"
+
+// maxBuckets caps the buckets kept in memory. Beyond it the least recently
+// used bucket is dropped, which at worst hands an idle client a full bucket
+// again.
+const maxBuckets = 100_000
+
+// Limit is the sustained rate, in calls per second, and the burst a client
+// may make of one RPC. A zero rate leaves the RPC unlimited.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Recorder counts throttled calls.
+type Recorder interface {
+	Throttled(method string)
+}
+
+type bucketKey struct {
+	client, method string
+}
+
+type Limiter struct {
+	def      Limit
+	methods  map[string]Limit
+	recorder Recorder
+
+	buckets *lru.Cache[bucketKey, *rate.Limiter]
+}
+
+// New returns a limiter applying def to every RPC without an entry in
+// methods. Methods are keyed by full method name, such as
+// "/inventory.InventoryService/GetProduct", or by the bare RPC name.
+func New(def Limit, methods map[string]Limit, recorder Recorder) *Limiter {
+	buckets, _ := lru.New[bucketKey, *rate.Limiter](maxBuckets)
+	return &Limiter{def: def, methods: methods, recorder: recorder, buckets: buckets}
+}
+
+func (l *Limiter) limit(method string) Limit {
+	if lim, ok := l.methods[method]; ok {
+		return lim
+	}
+	if lim, ok := l.methods[method[strings.LastIndex(method, "/")+1:]]; ok {
+		return lim
+	}
+	return l.def
+}
+
+// Reserve takes a token for one call of method by client. It returns zero
+// when the call may go ahead and otherwise how long the client should wait.
+func (l *Limiter) Reserve(client, method string, now time.Time) time.Duration {
+	lim := l.limit(method)
+	if lim.Rate <= 0 {
+		return 0
+	}
+
+	key := bucketKey{client: client, method: method}
+	b, ok := l.buckets.Get(key)
+	if !ok {
+		// Concurrent first calls must share one bucket.
+		b = rate.NewLimiter(rate.Limit(lim.Rate), lim.Burst)
+		if prev, found, _ := l.buckets.PeekOrAdd(key, b); found {
+			b = prev
+		}
+	}
+	r := b.ReserveN(now, 1)
+	if !r.OK() {
+		return time.Duration(math.MaxInt64)
+	}
+	delay := r.DelayFrom(now)
+	if delay > 0 {
+		// Rejected calls must not use up the tokens of later ones.
+		r.CancelAt(now)
+	}
+	return delay
+}
+
+// UnaryServerInterceptor rejects calls over their client's limit with
+// ResourceExhausted and a retry-after header. It must run after
+// CallerInterceptor, which identifies the client.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		delay := l.Reserve(clientOf(ctx), info.FullMethod, time.Now())
+		if delay == 0 {
+			return handler(ctx, req)
+		}
+		if l.recorder != nil {
+			l.recorder.Throttled(info.FullMethod)
+		}
+		seconds := int64(math.Ceil(delay.Seconds()))
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterKey, strconv.FormatInt(seconds, 10)))
+		return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s, retry in %ds", info.FullMethod, seconds)
+	}
+}
+
+// clientOf identifies the caller by its verified client certificate or,
+// failing that, by its network address. The x-actor metadata is never used:
+// any caller can set it to dodge its own limit or to spend someone else's.
+func clientOf(ctx context.Context) string {
+	if c := audit.CallerFrom(ctx); c.Verified {
+		return "subject:" + c.Actor
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr := p.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+		return "peer:" + addr
+	}
+	return "unknown"
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+type AuditRepository interface {
+	Record(ctx context.Context, e *domain.AuditEvent) error
+	List(ctx context.Context, f domain.AuditFilter) ([]*domain.AuditEvent, error)
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func boltStartSpan(ctx context.Context, collection, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		semconv.DBSystemKey.String("bbolt"),
+		semconv.DBCollectionName(collection),
+		semconv.DBOperationName(op),
+	)
+	return tracer.Start(ctx, "bolt."+collection+"."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+}
+
+// boltCollection keeps the documents of one MongoDB collection in a bbolt
+// bucket of the same name. They are the documents the MongoDB repositories
+// store, BSON-encoded under the bytes of their ObjectID, so ids look alike
+// whichever store is used and the conversions to and from the domain are
+// shared. ObjectIDs begin with their creation time, so a cursor walks a
+// bucket in creation order. bbolt runs one write transaction at a time,
+// which makes every read-modify-write of the repositories atomic.
+//
+// Unique keys are kept in index buckets named after the collection and the
+// key, mapping each key to the document it belongs to.
+type boltCollection[D any] struct {
+	db   *bolt.DB
+	name string
+}
+
+// newBoltCollection creates the bucket of a collection and of its indexes
+// unless the file already has them.
+func newBoltCollection[D any](db *bolt.DB, name string, indexes ...string) (boltCollection[D], error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		buckets := []string{name}
+		for _, index := range indexes {
+			buckets = append(buckets, name+"."+index)
+		}
+		for _, b := range buckets {
+			if _, err := tx.CreateBucketIfNotExists([]byte(b)); err != nil {
+				return fmt.Errorf("create bucket %s: %w", b, err)
+			}
+		}
+		return nil
+	})
+	return boltCollection[D]{db: db, name: name}, err
+}
+
+func (c boltCollection[D]) startSpan(ctx context.Context, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return boltStartSpan(ctx, c.name, op, attrs...)
+}
+
+func (c boltCollection[D]) bucket(tx *bolt.Tx) *bolt.Bucket {
+	return tx.Bucket([]byte(c.name))
+}
+
+func (c boltCollection[D]) index(tx *bolt.Tx, name string) *bolt.Bucket {
+	return tx.Bucket([]byte(c.name + "." + name))
+}
+
+// get reads the document stored under id; ids that are not ObjectIDs match
+// no document.
+func (c boltCollection[D]) get(tx *bolt.Tx, id string) (*D, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	return c.getKey(tx, oid[:])
+}
+
+func (c boltCollection[D]) getKey(tx *bolt.Tx, key []byte) (*D, error) {
+	raw := c.bucket(tx).Get(key)
+	if raw == nil {
+		return nil, domain.ErrNotFound
+	}
+	var doc D
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (c boltCollection[D]) put(tx *bolt.Tx, key []byte, doc *D) error {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return c.bucket(tx).Put(key, raw)
+}
+
+// scan returns the documents matching keep in key order.
+func (c boltCollection[D]) scan(tx *bolt.Tx, keep func(*D) bool) ([]*D, error) {
+	var docs []*D
+	err := c.bucket(tx).ForEach(func(_, raw []byte) error {
+		var doc D
+		if err := bson.Unmarshal(raw, &doc); err != nil {
+			return err
+		}
+		if keep(&doc) {
+			docs = append(docs, &doc)
+		}
+		return nil
+	})
+	return docs, err
+}
+
+// find scans the collection in a read transaction.
+func (c boltCollection[D]) find(keep func(*D) bool) ([]*D, error) {
+	var docs []*D
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var err error
+		docs, err = c.scan(tx, keep)
+		return err
+	})
+	return docs, err
+}
+
+// insert stores a new document under its id.
+func (c boltCollection[D]) insert(id primitive.ObjectID, doc *D) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return c.put(tx, id[:], doc)
+	})
+}
+
+// load reads the document stored under id in a read transaction.
+func (c boltCollection[D]) load(id string) (*D, error) {
+	var doc *D
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var err error
+		doc, err = c.get(tx, id)
+		return err
+	})
+	return doc, err
+}
+
+// update reads the document stored under id, lets change modify it and
+// stores it again, all in one write transaction. change reports whether it
+// modified the document; an error from it leaves the document as it was.
+func (c boltCollection[D]) update(id string, change func(*D) (bool, error)) (bool, error) {
+	changed := false
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		doc, err := c.get(tx, id)
+		if err != nil {
+			return err
+		}
+		if changed, err = change(doc); err != nil || !changed {
+			return err
+		}
+		oid, _ := primitive.ObjectIDFromHex(id)
+		return c.put(tx, oid[:], doc)
+	})
+	return changed, err
+}
+
+// claim records key in a unique index for the document id and reports false
+// when another document already holds it.
+func (c boltCollection[D]) claim(tx *bolt.Tx, index, key string, id primitive.ObjectID) (bool, error) {
+	b := c.index(tx, index)
+	if held := b.Get([]byte(key)); held != nil && string(held) != string(id[:]) {
+		return false, nil
+	}
+	return true, b.Put([]byte(key), id[:])
+}
+
+// lookup reads the document a unique index maps key to.
+func (c boltCollection[D]) lookup(tx *bolt.Tx, index, key string) (*D, error) {
+	id := c.index(tx, index).Get([]byte(key))
+	if id == nil {
+		return nil, domain.ErrNotFound
+	}
+	return c.getKey(tx, id)
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type boltAuditRepo struct {
+	events boltCollection[auditEventDocument]
+}
+
+func NewBoltAuditRepository(db *bolt.DB) (AuditRepository, error) {
+	events, err := newBoltCollection[auditEventDocument](db, "audit_events")
+	if err != nil {
+		return nil, err
+	}
+	return &boltAuditRepo{events: events}, nil
+}
+
+func (r *boltAuditRepo) Record(ctx context.Context, e *domain.AuditEvent) error {
+	_, span := r.events.startSpan(ctx, "insertOne",
+		attribute.String("product.id", e.ProductID), attribute.String("audit.action", string(e.Action)))
+	defer span.End()
+
+	doc := newAuditEventDocument(e)
+	doc.ID = primitive.NewObjectID()
+	if err := r.events.insert(doc.ID, &doc); err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	e.ID = doc.ID.Hex()
+	return nil
+}
+
+func (r *boltAuditRepo) List(ctx context.Context, f domain.AuditFilter) ([]*domain.AuditEvent, error) {
+	_, span := r.events.startSpan(ctx, "find")
+	defer span.End()
+
+	docs, err := r.events.find(func(d *auditEventDocument) bool {
+		return (f.ProductID == "" || d.ProductID == f.ProductID) &&
+			(f.Actor == "" || d.Actor == f.Actor) &&
+			(f.From.IsZero() || !d.OccurredAt.Before(f.From)) &&
+			(f.To.IsZero() || d.OccurredAt.Before(f.To))
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	// Newest first, ties broken by the later id as in MongoDB.
+	slices.Reverse(docs)
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].OccurredAt.After(docs[j].OccurredAt) })
+	if f.Limit > 0 && int64(len(docs)) > f.Limit {
+		docs = docs[:f.Limit]
+	}
+	events := make([]*domain.AuditEvent, 0, len(docs))
+	for _, d := range docs {
+		events = append(events, d.toDomain())
+	}
+	return events, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type boltBundleRepo struct {
+	bundles boltCollection[bundleDocument]
+}
+
+func NewBoltBundleRepository(db *bolt.DB) (BundleRepository, error) {
+	bundles, err := newBoltCollection[bundleDocument](db, "bundles")
+	if err != nil {
+		return nil, err
+	}
+	return &boltBundleRepo{bundles: bundles}, nil
+}
+
+func (r *boltBundleRepo) Create(ctx context.Context, b *domain.Bundle) (string, error) {
+	_, span := r.bundles.startSpan(ctx, "insertOne")
+	defer span.End()
+
+	doc := newBundleDocument(b)
+	doc.ID = primitive.NewObjectID()
+	if err := r.bundles.insert(doc.ID, &doc); err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("bundle.id", doc.ID.Hex()))
+	return doc.ID.Hex(), nil
+}
+
+func (r *boltBundleRepo) GetByID(ctx context.Context, id string) (*domain.Bundle, error) {
+	_, span := r.bundles.startSpan(ctx, "findOne", attribute.String("bundle.id", id))
+	defer span.End()
+
+	doc, err := r.bundles.load(id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *boltBundleRepo) ListByComponent(ctx context.Context, productID string) ([]*domain.Bundle, error) {
+	_, span := r.bundles.startSpan(ctx, "find", attribute.String("product.id", productID))
+	defer span.End()
+
+	docs, err := r.bundles.find(func(d *bundleDocument) bool {
+		for _, c := range d.Components {
+			if c.ProductID == productID {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	bundles := make([]*domain.Bundle, 0, len(docs))
+	for _, d := range docs {
+		bundles = append(bundles, d.toDomain())
+	}
+	return bundles, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type boltCycleCountRepo struct {
+	counts boltCollection[cycleCountDocument]
+}
+
+func NewBoltCycleCountRepository(db *bolt.DB) (CycleCountRepository, error) {
+	counts, err := newBoltCollection[cycleCountDocument](db, "cycle_counts")
+	if err != nil {
+		return nil, err
+	}
+	return &boltCycleCountRepo{counts: counts}, nil
+}
+
+func (r *boltCycleCountRepo) Create(ctx context.Context, c *domain.CycleCount) (string, error) {
+	_, span := r.counts.startSpan(ctx, "insertOne")
+	defer span.End()
+
+	doc := newCycleCountDocument(c)
+	doc.ID = primitive.NewObjectID()
+	if err := r.counts.insert(doc.ID, &doc); err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("cycle_count.id", doc.ID.Hex()))
+	return doc.ID.Hex(), nil
+}
+
+func (r *boltCycleCountRepo) GetByID(ctx context.Context, id string) (*domain.CycleCount, error) {
+	_, span := r.counts.startSpan(ctx, "findOne", attribute.String("cycle_count.id", id))
+	defer span.End()
+
+	doc, err := r.counts.load(id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *boltCycleCountRepo) Update(ctx context.Context, c *domain.CycleCount) error {
+	_, span := r.counts.startSpan(ctx, "replaceOne", attribute.String("cycle_count.id", c.ID))
+	defer span.End()
+
+	doc := newCycleCountDocument(c)
+	doc.Version = c.Version + 1
+	_, err := r.counts.update(c.ID, func(d *cycleCountDocument) (bool, error) {
+		if d.Version != c.Version {
+			return false, domain.ErrConflict
+		}
+		doc.ID = d.ID
+		*d = doc
+		return true, nil
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		err = domain.ErrConflict
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	c.Version = doc.Version
+	return nil
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type boltLotRepo struct {
+	lots boltCollection[lotDocument]
+}
+
+// NewBoltLotRepository keeps lot numbers unique per product in an index
+// keyed by product and lot number.
+func NewBoltLotRepository(db *bolt.DB) (LotRepository, error) {
+	lots, err := newBoltCollection[lotDocument](db, "lots", "product_id_lot_number")
+	if err != nil {
+		return nil, err
+	}
+	return &boltLotRepo{lots: lots}, nil
+}
+
+func lotKey(productID, lotNumber string) string {
+	return productID + "\x00" + lotNumber
+}
+
+func (r *boltLotRepo) Create(ctx context.Context, l *domain.Lot) (string, error) {
+	_, span := r.lots.startSpan(ctx, "insertOne", attribute.String("product.id", l.ProductID))
+	defer span.End()
+
+	doc := newLotDocument(l)
+	doc.ID = primitive.NewObjectID()
+	err := r.lots.db.Update(func(tx *bolt.Tx) error {
+		ok, err := r.lots.claim(tx, "product_id_lot_number", lotKey(l.ProductID, l.LotNumber), doc.ID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: lot %s of product %s already exists", domain.ErrInvalidArgument, l.LotNumber, l.ProductID)
+		}
+		return r.lots.put(tx, doc.ID[:], &doc)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("lot.id", doc.ID.Hex()))
+	return doc.ID.Hex(), nil
+}
+
+func (r *boltLotRepo) GetByNumber(ctx context.Context, productID, lotNumber string) (*domain.Lot, error) {
+	_, span := r.lots.startSpan(ctx, "findOne",
+		attribute.String("product.id", productID), attribute.String("lot.number", lotNumber))
+	defer span.End()
+
+	var doc *lotDocument
+	err := r.lots.db.View(func(tx *bolt.Tx) error {
+		var err error
+		doc, err = r.lots.lookup(tx, "product_id_lot_number", lotKey(productID, lotNumber))
+		return err
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *boltLotRepo) ListByProduct(ctx context.Context, productID string) ([]*domain.Lot, error) {
+	_, span := r.lots.startSpan(ctx, "find", attribute.String("product.id", productID))
+	defer span.End()
+
+	lots, err := r.find(func(d *lotDocument) bool { return d.ProductID == productID })
+	tracing.RecordError(span, err)
+	return lots, err
+}
+
+func (r *boltLotRepo) ListAllocatable(ctx context.Context, productID string, now time.Time) ([]*domain.Lot, error) {
+	_, span := r.lots.startSpan(ctx, "find", attribute.String("product.id", productID))
+	defer span.End()
+
+	lots, err := r.find(func(d *lotDocument) bool {
+		return d.ProductID == productID && d.Status == string(domain.LotAvailable) && d.Booked &&
+			d.Quantity > 0 && d.ExpiresAt.After(now)
+	})
+	tracing.RecordError(span, err)
+	return lots, err
+}
+
+func (r *boltLotRepo) ListExpiring(ctx context.Context, from, to time.Time) ([]*domain.Lot, error) {
+	_, span := r.lots.startSpan(ctx, "find")
+	defer span.End()
+
+	lots, err := r.find(func(d *lotDocument) bool {
+		return d.Status == string(domain.LotAvailable) && d.Quantity > 0 &&
+			!d.ExpiresAt.Before(from) && d.ExpiresAt.Before(to)
+	})
+	tracing.RecordError(span, err)
+	return lots, err
+}
+
+func (r *boltLotRepo) Take(ctx context.Context, id string, quantity int32) (bool, error) {
+	_, span := r.lots.startSpan(ctx, "updateOne", attribute.String("lot.id", id))
+	defer span.End()
+
+	taken, err := r.lots.update(id, func(d *lotDocument) (bool, error) {
+		if d.Status != string(domain.LotAvailable) || d.Quantity < quantity {
+			return false, nil
+		}
+		d.Quantity -= quantity
+		return true, nil
+	})
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return taken, nil
+}
+
+func (r *boltLotRepo) Return(ctx context.Context, id string, quantity int32) error {
+	_, span := r.lots.startSpan(ctx, "updateOne", attribute.String("lot.id", id))
+	defer span.End()
+
+	_, err := r.lots.update(id, func(d *lotDocument) (bool, error) {
+		d.Quantity += quantity
+		return true, nil
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *boltLotRepo) Quarantine(ctx context.Context, id string) (*domain.Lot, bool, error) {
+	_, span := r.lots.startSpan(ctx, "findOneAndUpdate", attribute.String("lot.id", id))
+	defer span.End()
+
+	var lot *domain.Lot
+	ok, err := r.lots.update(id, func(d *lotDocument) (bool, error) {
+		if d.Status != string(domain.LotAvailable) {
+			return false, nil
+		}
+		d.Status = string(domain.LotQuarantined)
+		lot = d.toDomain()
+		return true, nil
+	})
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		tracing.RecordError(span, err)
+		return nil, false, err
+	}
+	return lot, ok, nil
+}
+
+func (r *boltLotRepo) SetBooked(ctx context.Context, id string, booked bool) (bool, error) {
+	_, span := r.lots.startSpan(ctx, "updateOne", attribute.String("lot.id", id), attribute.Bool("lot.booked", booked))
+	defer span.End()
+
+	changed, err := r.lots.update(id, func(d *lotDocument) (bool, error) {
+		if d.Booked == booked {
+			return false, nil
+		}
+		d.Booked = booked
+		return true, nil
+	})
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return changed, nil
+}
+
+func (r *boltLotRepo) ListQuarantinedBooked(ctx context.Context) ([]*domain.Lot, error) {
+	_, span := r.lots.startSpan(ctx, "find")
+	defer span.End()
+
+	lots, err := r.find(func(d *lotDocument) bool {
+		return d.Status == string(domain.LotQuarantined) && d.Booked
+	})
+	tracing.RecordError(span, err)
+	return lots, err
+}
+
+// find returns the matching lots earliest expiry first.
+func (r *boltLotRepo) find(keep func(*lotDocument) bool) ([]*domain.Lot, error) {
+	docs, err := r.lots.find(keep)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].ExpiresAt.Before(docs[j].ExpiresAt) })
+	lots := make([]*domain.Lot, 0, len(docs))
+	for _, d := range docs {
+		lots = append(lots, d.toDomain())
+	}
+	return lots, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type boltPriceHistoryRepo struct {
+	records boltCollection[priceRecordDocument]
+}
+
+func NewBoltPriceHistoryRepository(db *bolt.DB) (PriceHistoryRepository, error) {
+	records, err := newBoltCollection[priceRecordDocument](db, "price_history")
+	if err != nil {
+		return nil, err
+	}
+	return &boltPriceHistoryRepo{records: records}, nil
+}
+
+// Record closes the current record and adds the new one in one transaction.
+func (r *boltPriceHistoryRepo) Record(ctx context.Context, rec *domain.PriceRecord) error {
+	_, span := r.records.startSpan(ctx, "insertOne", attribute.String("product.id", rec.ProductID))
+	defer span.End()
+
+	doc := newPriceRecordDocument(rec)
+	doc.ID = primitive.NewObjectID()
+	err := r.records.db.Update(func(tx *bolt.Tx) error {
+		open, err := r.records.scan(tx, func(d *priceRecordDocument) bool {
+			return d.ProductID == rec.ProductID && d.EffectiveTo == nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, d := range open {
+			d.EffectiveTo = &rec.EffectiveFrom
+			if err := r.records.put(tx, d.ID[:], d); err != nil {
+				return err
+			}
+		}
+		return r.records.put(tx, doc.ID[:], &doc)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	rec.ID = doc.ID.Hex()
+	return nil
+}
+
+func (r *boltPriceHistoryRepo) At(ctx context.Context, productID string, t time.Time) (*domain.PriceRecord, error) {
+	_, span := r.records.startSpan(ctx, "findOne", attribute.String("product.id", productID))
+	defer span.End()
+
+	records, err := r.find(func(d *priceRecordDocument) bool {
+		return d.ProductID == productID && !d.EffectiveFrom.After(t) &&
+			(d.EffectiveTo == nil || d.EffectiveTo.After(t))
+	})
+	if err == nil && len(records) == 0 {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return records[0], nil
+}
+
+func (r *boltPriceHistoryRepo) ListByProduct(ctx context.Context, productID string) ([]*domain.PriceRecord, error) {
+	_, span := r.records.startSpan(ctx, "find", attribute.String("product.id", productID))
+	defer span.End()
+
+	records, err := r.find(func(d *priceRecordDocument) bool { return d.ProductID == productID })
+	tracing.RecordError(span, err)
+	return records, err
+}
+
+// find returns the matching records newest first.
+func (r *boltPriceHistoryRepo) find(keep func(*priceRecordDocument) bool) ([]*domain.PriceRecord, error) {
+	docs, err := r.records.find(keep)
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(docs)
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].EffectiveFrom.After(docs[j].EffectiveFrom) })
+	records := make([]*domain.PriceRecord, 0, len(docs))
+	for _, d := range docs {
+		records = append(records, d.toDomain())
+	}
+	return records, nil
+}
@@ -0,0 +1,522 @@
+package repository
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	bolt "go.etcd.io/bbolt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	boltProducts = []byte("products")
+	// boltProductSKUs maps SKUs to product keys and keeps them unique.
+	boltProductSKUs = []byte("product_skus")
+)
+
+// boltProductRepo keeps products in an embedded bbolt file, keyed by a
+// sequence number in creation order. bbolt runs one write transaction at a
+// time, so every read-modify-write below is atomic.
+type boltProductRepo struct {
+	db *bolt.DB
+}
+
+type boltProductDocument struct {
+	SKU               string     `json:"sku,omitempty"`
+	Name              string     `json:"name"`
+	Description       string     `json:"description"`
+	Price             float64    `json:"price"`
+	Stock             int32      `json:"stock"`
+	DamagedStock      int32      `json:"damaged_stock,omitempty"`
+	CategoryID        string     `json:"category_id"`
+	ReorderPoint      int32      `json:"reorder_point"`
+	ReorderQuantity   int32      `json:"reorder_quantity"`
+	LowStockAlerted   bool       `json:"low_stock_alerted"`
+	VariantAttributes []string   `json:"variant_attributes,omitempty"`
+	LotTracked        bool       `json:"lot_tracked,omitempty"`
+	Serialized        bool       `json:"serialized,omitempty"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy         string     `json:"deleted_by,omitempty"`
+}
+
+func (d *boltProductDocument) toDomain(id string) *domain.Product {
+	p := &domain.Product{
+		ID:                id,
+		SKU:               d.SKU,
+		Name:              d.Name,
+		Description:       d.Description,
+		Price:             d.Price,
+		Stock:             d.Stock,
+		DamagedStock:      d.DamagedStock,
+		CategoryID:        d.CategoryID,
+		ReorderPoint:      d.ReorderPoint,
+		ReorderQuantity:   d.ReorderQuantity,
+		VariantAttributes: d.VariantAttributes,
+		LotTracked:        d.LotTracked,
+		Serialized:        d.Serialized,
+	}
+	if d.DeletedAt != nil {
+		p.DeletedAt = *d.DeletedAt
+		p.DeletedBy = d.DeletedBy
+	}
+	return p
+}
+
+// NewBoltProductRepository creates the buckets the products live in unless
+// the file already has them.
+func NewBoltProductRepository(db *bolt.DB) (ProductRepository, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{boltProducts, boltProductSKUs} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return fmt.Errorf("create bucket %s: %w", name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &boltProductRepo{db: db}, nil
+}
+
+func (r *boltProductRepo) startSpan(ctx context.Context, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return boltStartSpan(ctx, "products", op, attrs...)
+}
+
+func boltKey(seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, seq)
+}
+
+// boltKeyOf converts a product id back to its key; ids that are not
+// sequence numbers match no product.
+func boltKeyOf(id string) ([]byte, bool) {
+	seq, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || seq == 0 {
+		return nil, false
+	}
+	return boltKey(seq), true
+}
+
+func boltID(key []byte) string {
+	return strconv.FormatUint(binary.BigEndian.Uint64(key), 10)
+}
+
+// getDocument reads the product stored under id; archived products count as
+// missing unless includeDeleted is set.
+func getDocument(tx *bolt.Tx, id string, includeDeleted bool) ([]byte, *boltProductDocument, error) {
+	key, ok := boltKeyOf(id)
+	if !ok {
+		return nil, nil, domain.ErrNotFound
+	}
+	raw := tx.Bucket(boltProducts).Get(key)
+	if raw == nil {
+		return nil, nil, domain.ErrNotFound
+	}
+	var doc boltProductDocument
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, nil, err
+	}
+	if doc.DeletedAt != nil && !includeDeleted {
+		return nil, nil, domain.ErrNotFound
+	}
+	return key, &doc, nil
+}
+
+func putDocument(tx *bolt.Tx, key []byte, doc *boltProductDocument) error {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(boltProducts).Put(key, raw)
+}
+
+func (r *boltProductRepo) Create(ctx context.Context, p *domain.Product) (string, error) {
+	_, span := r.startSpan(ctx, "put")
+	defer span.End()
+
+	doc := boltProductDocument{
+		SKU:               p.SKU,
+		Name:              p.Name,
+		Description:       p.Description,
+		Price:             p.Price,
+		Stock:             p.Stock,
+		CategoryID:        p.CategoryID,
+		ReorderPoint:      p.ReorderPoint,
+		ReorderQuantity:   p.ReorderQuantity,
+		VariantAttributes: p.VariantAttributes,
+		LotTracked:        p.LotTracked,
+		Serialized:        p.Serialized,
+	}
+	var id string
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		skus := tx.Bucket(boltProductSKUs)
+		if p.SKU != "" && skus.Get([]byte(p.SKU)) != nil {
+			return fmt.Errorf("%w: SKU %s is already in use", domain.ErrInvalidArgument, p.SKU)
+		}
+		seq, err := tx.Bucket(boltProducts).NextSequence()
+		if err != nil {
+			return err
+		}
+		key := boltKey(seq)
+		if err := putDocument(tx, key, &doc); err != nil {
+			return err
+		}
+		if p.SKU != "" {
+			if err := skus.Put([]byte(p.SKU), key); err != nil {
+				return err
+			}
+		}
+		id = boltID(key)
+		return nil
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("product.id", id))
+	return id, nil
+}
+
+func (r *boltProductRepo) GetByID(ctx context.Context, id string) (*domain.Product, error) {
+	return r.get(ctx, id, false)
+}
+
+func (r *boltProductRepo) GetByIDIncludingDeleted(ctx context.Context, id string) (*domain.Product, error) {
+	return r.get(ctx, id, true)
+}
+
+func (r *boltProductRepo) get(ctx context.Context, id string, includeDeleted bool) (*domain.Product, error) {
+	_, span := r.startSpan(ctx, "get", attribute.String("product.id", id))
+	defer span.End()
+
+	var p *domain.Product
+	err := r.db.View(func(tx *bolt.Tx) error {
+		_, doc, err := getDocument(tx, id, includeDeleted)
+		if err != nil {
+			return err
+		}
+		p = doc.toDomain(id)
+		return nil
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *boltProductRepo) GetMany(ctx context.Context, ids, skus []string) ([]*domain.Product, error) {
+	_, span := r.startSpan(ctx, "get",
+		attribute.Int("product.ids", len(ids)), attribute.Int("product.skus", len(skus)))
+	defer span.End()
+
+	var products []*domain.Product
+	err := r.db.View(func(tx *bolt.Tx) error {
+		seen := make(map[string]bool, len(ids)+len(skus))
+		add := func(id string) error {
+			if seen[id] {
+				return nil
+			}
+			seen[id] = true
+			_, doc, err := getDocument(tx, id, false)
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			products = append(products, doc.toDomain(id))
+			return nil
+		}
+		for _, id := range ids {
+			if err := add(id); err != nil {
+				return err
+			}
+		}
+		bySKU := tx.Bucket(boltProductSKUs)
+		for _, sku := range skus {
+			if key := bySKU.Get([]byte(sku)); key != nil {
+				if err := add(boltID(key)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+func (r *boltProductRepo) List(ctx context.Context, includeDeleted bool) ([]*domain.Product, error) {
+	_, span := r.startSpan(ctx, "scan", attribute.Bool("include_deleted", includeDeleted))
+	defer span.End()
+
+	products, err := r.scan(func(d *boltProductDocument) bool {
+		return includeDeleted || d.DeletedAt == nil
+	}, 0)
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+func (r *boltProductRepo) Search(ctx context.Context, query string, limit int) ([]*domain.Product, error) {
+	_, span := r.startSpan(ctx, "scan", attribute.String("search.query", query))
+	defer span.End()
+
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return nil, nil
+	}
+	products, err := r.scan(func(d *boltProductDocument) bool {
+		if d.DeletedAt != nil {
+			return false
+		}
+		name, desc, sku := strings.ToLower(d.Name), strings.ToLower(d.Description), strings.ToLower(d.SKU)
+		for _, w := range words {
+			if !strings.Contains(name, w) && !strings.Contains(desc, w) && !strings.Contains(sku, w) {
+				return false
+			}
+		}
+		return true
+	}, limit)
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+func (r *boltProductRepo) Update(ctx context.Context, id string, u *domain.ProductUpdate) (*domain.Product, *domain.Product, error) {
+	var before *domain.Product
+	after, err := r.modify(ctx, id, func(d *boltProductDocument) error {
+		before = d.toDomain(id)
+		p := d.toDomain(id)
+		u.Apply(p)
+		d.Name, d.Description, d.Price = p.Name, p.Description, p.Price
+		d.CategoryID, d.ReorderPoint, d.ReorderQuantity = p.CategoryID, p.ReorderPoint, p.ReorderQuantity
+		return nil
+	}, attribute.String("product.id", id))
+	if err != nil {
+		return nil, nil, err
+	}
+	return before, after, nil
+}
+
+// AdjustStock atomically adds delta to the stock of a product. A negative
+// delta only applies when enough stock is left, so stock never goes below
+// zero; ErrInsufficientStock is returned otherwise.
+func (r *boltProductRepo) AdjustStock(ctx context.Context, id string, delta int32) (*domain.Product, error) {
+	return r.modify(ctx, id, func(d *boltProductDocument) error {
+		if d.Stock+delta < 0 {
+			return domain.ErrInsufficientStock
+		}
+		d.Stock += delta
+		return nil
+	}, attribute.String("product.id", id), attribute.String("stock.bucket", "stock"), attribute.Int("stock.delta", int(delta)))
+}
+
+func (r *boltProductRepo) AdjustDamagedStock(ctx context.Context, id string, delta int32) (*domain.Product, error) {
+	return r.modify(ctx, id, func(d *boltProductDocument) error {
+		if d.DamagedStock+delta < 0 {
+			return domain.ErrInsufficientStock
+		}
+		d.DamagedStock += delta
+		return nil
+	}, attribute.String("product.id", id), attribute.String("stock.bucket", "damaged_stock"), attribute.Int("stock.delta", int(delta)))
+}
+
+// modify applies change to a live product in one write transaction and
+// returns the product as stored.
+func (r *boltProductRepo) modify(ctx context.Context, id string, change func(*boltProductDocument) error, attrs ...attribute.KeyValue) (*domain.Product, error) {
+	_, span := r.startSpan(ctx, "put", attrs...)
+	defer span.End()
+
+	var p *domain.Product
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		key, doc, err := getDocument(tx, id, false)
+		if err != nil {
+			return err
+		}
+		if err := change(doc); err != nil {
+			return err
+		}
+		if err := putDocument(tx, key, doc); err != nil {
+			return err
+		}
+		p = doc.toDomain(id)
+		return nil
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *boltProductRepo) Count(ctx context.Context) (int64, error) {
+	_, span := r.startSpan(ctx, "scan")
+	defer span.End()
+
+	products, err := r.scan(func(d *boltProductDocument) bool { return d.DeletedAt == nil }, 0)
+	tracing.RecordError(span, err)
+	return int64(len(products)), err
+}
+
+func (r *boltProductRepo) CountOutOfStock(ctx context.Context) (int64, error) {
+	_, span := r.startSpan(ctx, "scan")
+	defer span.End()
+
+	products, err := r.scan(func(d *boltProductDocument) bool { return d.DeletedAt == nil && d.Stock <= 0 }, 0)
+	tracing.RecordError(span, err)
+	return int64(len(products)), err
+}
+
+func (r *boltProductRepo) ListLowStock(ctx context.Context) ([]*domain.Product, error) {
+	_, span := r.startSpan(ctx, "scan")
+	defer span.End()
+
+	products, err := r.scan(func(d *boltProductDocument) bool {
+		return d.DeletedAt == nil && d.ReorderPoint > 0 && d.Stock <= d.ReorderPoint
+	}, 0)
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+func (r *boltProductRepo) SetLowStockAlerted(ctx context.Context, id string, alerted bool) (bool, error) {
+	_, span := r.startSpan(ctx, "put", attribute.String("product.id", id))
+	defer span.End()
+
+	var changed bool
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		key, doc, err := getDocument(tx, id, true)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		if err != nil || doc.LowStockAlerted == alerted {
+			return err
+		}
+		doc.LowStockAlerted = alerted
+		changed = true
+		return putDocument(tx, key, doc)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return changed, nil
+}
+
+func (r *boltProductRepo) SoftDelete(ctx context.Context, id, deletedBy string, at time.Time) (*domain.Product, error) {
+	return r.modify(ctx, id, func(d *boltProductDocument) error {
+		at := at.UTC()
+		d.DeletedAt = &at
+		d.DeletedBy = deletedBy
+		return nil
+	}, attribute.String("product.id", id))
+}
+
+func (r *boltProductRepo) Restore(ctx context.Context, id string) (*domain.Product, error) {
+	_, span := r.startSpan(ctx, "put", attribute.String("product.id", id))
+	defer span.End()
+
+	var p *domain.Product
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		key, doc, err := getDocument(tx, id, true)
+		if err != nil {
+			return err
+		}
+		if doc.DeletedAt == nil {
+			return fmt.Errorf("%w: product %s is not archived", domain.ErrFailedPrecondition, id)
+		}
+		doc.DeletedAt, doc.DeletedBy = nil, ""
+		if err := putDocument(tx, key, doc); err != nil {
+			return err
+		}
+		p = doc.toDomain(id)
+		return nil
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *boltProductRepo) ListDeleted(ctx context.Context, before time.Time) ([]*domain.Product, error) {
+	_, span := r.startSpan(ctx, "scan")
+	defer span.End()
+
+	products, err := r.scan(func(d *boltProductDocument) bool {
+		return d.DeletedAt != nil && d.DeletedAt.Before(before)
+	}, 0)
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+func (r *boltProductRepo) PurgeDeleted(ctx context.Context, ids []string, before time.Time) ([]string, error) {
+	_, span := r.startSpan(ctx, "delete", attribute.Int("product.ids", len(ids)))
+	defer span.End()
+
+	var purged []string
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		purged = make([]string, 0, len(ids))
+		products, skus := tx.Bucket(boltProducts), tx.Bucket(boltProductSKUs)
+		for _, id := range ids {
+			key, doc, err := getDocument(tx, id, true)
+			if errors.Is(err, domain.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if doc.DeletedAt == nil || !doc.DeletedAt.Before(before) {
+				continue
+			}
+			if err := products.Delete(key); err != nil {
+				return err
+			}
+			if doc.SKU != "" {
+				if err := skus.Delete([]byte(doc.SKU)); err != nil {
+					return err
+				}
+			}
+			purged = append(purged, id)
+		}
+		return nil
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return purged, nil
+}
+
+// scan returns the products matching keep in creation order, at most limit
+// of them when limit is positive.
+func (r *boltProductRepo) scan(keep func(*boltProductDocument) bool, limit int) ([]*domain.Product, error) {
+	products := make([]*domain.Product, 0)
+	err := r.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(boltProducts).Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var doc boltProductDocument
+			if err := json.Unmarshal(v, &doc); err != nil {
+				return err
+			}
+			if !keep(&doc) {
+				continue
+			}
+			products = append(products, doc.toDomain(boltID(k)))
+			if limit > 0 && len(products) == limit {
+				break
+			}
+		}
+		return nil
+	})
+	return products, err
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	bolt "go.etcd.io/bbolt"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// boltProductSupplierRepo keys each link by its product and supplier, which
+// keeps the pair unique and lists a supplier's links by product.
+type boltProductSupplierRepo struct {
+	links boltCollection[productSupplierDocument]
+}
+
+func NewBoltProductSupplierRepository(db *bolt.DB) (ProductSupplierRepository, error) {
+	links, err := newBoltCollection[productSupplierDocument](db, "product_suppliers")
+	if err != nil {
+		return nil, err
+	}
+	return &boltProductSupplierRepo{links: links}, nil
+}
+
+func productSupplierKey(productID, supplierID string) []byte {
+	return []byte(productID + "\x00" + supplierID)
+}
+
+func (r *boltProductSupplierRepo) Upsert(ctx context.Context, ps *domain.ProductSupplier) error {
+	_, span := r.links.startSpan(ctx, "replaceOne",
+		attribute.String("product.id", ps.ProductID), attribute.String("supplier.id", ps.SupplierID))
+	defer span.End()
+
+	doc := newProductSupplierDocument(ps)
+	err := r.links.db.Update(func(tx *bolt.Tx) error {
+		return r.links.put(tx, productSupplierKey(ps.ProductID, ps.SupplierID), &doc)
+	})
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *boltProductSupplierRepo) Delete(ctx context.Context, productID, supplierID string) error {
+	_, span := r.links.startSpan(ctx, "deleteOne",
+		attribute.String("product.id", productID), attribute.String("supplier.id", supplierID))
+	defer span.End()
+
+	key := productSupplierKey(productID, supplierID)
+	err := r.links.db.Update(func(tx *bolt.Tx) error {
+		b := r.links.bucket(tx)
+		if b.Get(key) == nil {
+			return domain.ErrNotFound
+		}
+		return b.Delete(key)
+	})
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *boltProductSupplierRepo) ListBySupplier(ctx context.Context, supplierID string) ([]*domain.ProductSupplier, error) {
+	_, span := r.links.startSpan(ctx, "find", attribute.String("supplier.id", supplierID))
+	defer span.End()
+
+	links, err := r.find(func(d *productSupplierDocument) bool { return d.SupplierID == supplierID })
+	tracing.RecordError(span, err)
+	return links, err
+}
+
+func (r *boltProductSupplierRepo) ListByProduct(ctx context.Context, productID string) ([]*domain.ProductSupplier, error) {
+	_, span := r.links.startSpan(ctx, "find", attribute.String("product.id", productID))
+	defer span.End()
+
+	links, err := r.find(func(d *productSupplierDocument) bool { return d.ProductID == productID })
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	sort.SliceStable(links, func(i, j int) bool { return links[i].UnitCost < links[j].UnitCost })
+	return links, nil
+}
+
+func (r *boltProductSupplierRepo) Cheapest(ctx context.Context, productID string, quantity int32) (*domain.ProductSupplier, error) {
+	_, span := r.links.startSpan(ctx, "findOne", attribute.String("product.id", productID))
+	defer span.End()
+
+	links, err := r.find(func(d *productSupplierDocument) bool {
+		return d.ProductID == productID && (quantity <= 0 || d.MinOrderQuantity <= quantity)
+	})
+	if err == nil && len(links) == 0 {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	sort.SliceStable(links, func(i, j int) bool {
+		if links[i].UnitCost != links[j].UnitCost {
+			return links[i].UnitCost < links[j].UnitCost
+		}
+		return links[i].LeadTimeDays < links[j].LeadTimeDays
+	})
+	return links[0], nil
+}
+
+// find returns the matching links in key order.
+func (r *boltProductSupplierRepo) find(keep func(*productSupplierDocument) bool) ([]*domain.ProductSupplier, error) {
+	docs, err := r.links.find(keep)
+	if err != nil {
+		return nil, err
+	}
+	links := make([]*domain.ProductSupplier, 0, len(docs))
+	for _, d := range docs {
+		links = append(links, d.toDomain())
+	}
+	return links, nil
+}
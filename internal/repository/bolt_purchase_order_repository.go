@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type boltPurchaseOrderRepo struct {
+	orders boltCollection[purchaseOrderDocument]
+}
+
+func NewBoltPurchaseOrderRepository(db *bolt.DB) (PurchaseOrderRepository, error) {
+	orders, err := newBoltCollection[purchaseOrderDocument](db, "purchase_orders")
+	if err != nil {
+		return nil, err
+	}
+	return &boltPurchaseOrderRepo{orders: orders}, nil
+}
+
+func (r *boltPurchaseOrderRepo) Create(ctx context.Context, po *domain.PurchaseOrder) (string, error) {
+	_, span := r.orders.startSpan(ctx, "insertOne")
+	defer span.End()
+
+	doc := newPurchaseOrderDocument(po)
+	doc.ID = primitive.NewObjectID()
+	if err := r.orders.insert(doc.ID, &doc); err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("purchase_order.id", doc.ID.Hex()))
+	return doc.ID.Hex(), nil
+}
+
+func (r *boltPurchaseOrderRepo) GetByID(ctx context.Context, id string) (*domain.PurchaseOrder, error) {
+	_, span := r.orders.startSpan(ctx, "findOne", attribute.String("purchase_order.id", id))
+	defer span.End()
+
+	doc, err := r.orders.load(id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *boltPurchaseOrderRepo) ListByStatus(ctx context.Context, statuses ...domain.PurchaseOrderStatus) ([]*domain.PurchaseOrder, error) {
+	_, span := r.orders.startSpan(ctx, "find")
+	defer span.End()
+
+	docs, err := r.orders.find(func(d *purchaseOrderDocument) bool {
+		return slices.Contains(statuses, domain.PurchaseOrderStatus(d.Status))
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	orders := make([]*domain.PurchaseOrder, 0, len(docs))
+	for _, d := range docs {
+		orders = append(orders, d.toDomain())
+	}
+	return orders, nil
+}
+
+func (r *boltPurchaseOrderRepo) Update(ctx context.Context, po *domain.PurchaseOrder) error {
+	_, span := r.orders.startSpan(ctx, "replaceOne", attribute.String("purchase_order.id", po.ID))
+	defer span.End()
+
+	doc := newPurchaseOrderDocument(po)
+	doc.Version = po.Version + 1
+	_, err := r.orders.update(po.ID, func(d *purchaseOrderDocument) (bool, error) {
+		if d.Version != po.Version {
+			return false, domain.ErrConflict
+		}
+		doc.ID = d.ID
+		*d = doc
+		return true, nil
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		err = domain.ErrConflict
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	po.Version = doc.Version
+	return nil
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type boltReservationRepo struct {
+	reservations boltCollection[reservationDocument]
+}
+
+func NewBoltReservationRepository(db *bolt.DB) (ReservationRepository, error) {
+	reservations, err := newBoltCollection[reservationDocument](db, "reservations")
+	if err != nil {
+		return nil, err
+	}
+	return &boltReservationRepo{reservations: reservations}, nil
+}
+
+func (r *boltReservationRepo) Create(ctx context.Context, res *domain.Reservation) (string, error) {
+	_, span := r.reservations.startSpan(ctx, "insertOne", attribute.String("bundle.id", res.BundleID))
+	defer span.End()
+
+	doc := newReservationDocument(res)
+	doc.ID = primitive.NewObjectID()
+	if err := r.reservations.insert(doc.ID, &doc); err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("reservation.id", doc.ID.Hex()))
+	return doc.ID.Hex(), nil
+}
+
+func (r *boltReservationRepo) GetByID(ctx context.Context, id string) (*domain.Reservation, error) {
+	_, span := r.reservations.startSpan(ctx, "findOne", attribute.String("reservation.id", id))
+	defer span.End()
+
+	doc, err := r.reservations.load(id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *boltReservationRepo) List(ctx context.Context, status domain.ReservationStatus, productID string) ([]*domain.Reservation, error) {
+	_, span := r.reservations.startSpan(ctx, "find", attribute.String("product.id", productID))
+	defer span.End()
+
+	docs, err := r.reservations.find(func(d *reservationDocument) bool {
+		if status != "" && d.Status != string(status) {
+			return false
+		}
+		return productID == "" || slices.ContainsFunc(d.Lines, func(l reservationLineDocument) bool {
+			return l.ProductID == productID
+		})
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	slices.Reverse(docs)
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].CreatedAt.After(docs[j].CreatedAt) })
+	reservations := make([]*domain.Reservation, 0, len(docs))
+	for _, d := range docs {
+		reservations = append(reservations, d.toDomain())
+	}
+	return reservations, nil
+}
+
+func (r *boltReservationRepo) Delete(ctx context.Context, id string) error {
+	_, span := r.reservations.startSpan(ctx, "deleteOne", attribute.String("reservation.id", id))
+	defer span.End()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	err := r.reservations.db.Update(func(tx *bolt.Tx) error {
+		return r.reservations.bucket(tx).Delete(oid[:])
+	})
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *boltReservationRepo) SetLines(ctx context.Context, id string, lines []domain.ReservationLine) error {
+	_, span := r.reservations.startSpan(ctx, "updateOne", attribute.String("reservation.id", id))
+	defer span.End()
+
+	_, err := r.reservations.update(id, func(d *reservationDocument) (bool, error) {
+		d.Lines = toReservationLineDocuments(lines)
+		return true, nil
+	})
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *boltReservationRepo) Release(ctx context.Context, id string, at time.Time) (bool, error) {
+	_, span := r.reservations.startSpan(ctx, "updateOne", attribute.String("reservation.id", id))
+	defer span.End()
+
+	released, err := r.reservations.update(id, func(d *reservationDocument) (bool, error) {
+		if d.Status != string(domain.ReservationActive) {
+			return false, nil
+		}
+		d.Status, d.ReleasedAt = string(domain.ReservationReleased), at
+		return true, nil
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return released, nil
+}
+
+func (r *boltReservationRepo) SetLineReleased(ctx context.Context, id, productID string, released bool) (bool, error) {
+	_, span := r.reservations.startSpan(ctx, "updateOne", attribute.String("reservation.id", id),
+		attribute.String("product.id", productID), attribute.Bool("reservation.line.released", released))
+	defer span.End()
+
+	changed, err := r.reservations.update(id, func(d *reservationDocument) (bool, error) {
+		if released && d.Status != string(domain.ReservationActive) {
+			return false, nil
+		}
+		i := slices.IndexFunc(d.Lines, func(l reservationLineDocument) bool {
+			return l.ProductID == productID && l.Released != released
+		})
+		if i < 0 {
+			return false, nil
+		}
+		d.Lines[i].Released = released
+		if !released {
+			d.Status, d.ReleasedAt = string(domain.ReservationActive), time.Time{}
+		}
+		return true, nil
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return changed, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type boltReturnRepo struct {
+	returns boltCollection[returnDocument]
+}
+
+func NewBoltReturnRepository(db *bolt.DB) (ReturnRepository, error) {
+	returns, err := newBoltCollection[returnDocument](db, "returns")
+	if err != nil {
+		return nil, err
+	}
+	return &boltReturnRepo{returns: returns}, nil
+}
+
+func (r *boltReturnRepo) Create(ctx context.Context, ret *domain.Return) (string, error) {
+	_, span := r.returns.startSpan(ctx, "insertOne", attribute.String("order.id", ret.OrderID))
+	defer span.End()
+
+	doc := newReturnDocument(ret)
+	doc.ID = primitive.NewObjectID()
+	if err := r.returns.insert(doc.ID, &doc); err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("return.id", doc.ID.Hex()))
+	return doc.ID.Hex(), nil
+}
+
+func (r *boltReturnRepo) GetByID(ctx context.Context, id string) (*domain.Return, error) {
+	_, span := r.returns.startSpan(ctx, "findOne", attribute.String("return.id", id))
+	defer span.End()
+
+	doc, err := r.returns.load(id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *boltReturnRepo) ListByOrder(ctx context.Context, orderID string) ([]*domain.Return, error) {
+	_, span := r.returns.startSpan(ctx, "find", attribute.String("order.id", orderID))
+	defer span.End()
+
+	docs, err := r.returns.find(func(d *returnDocument) bool { return d.OrderID == orderID })
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].CreatedAt.Before(docs[j].CreatedAt) })
+	returns := make([]*domain.Return, 0, len(docs))
+	for _, d := range docs {
+		returns = append(returns, d.toDomain())
+	}
+	return returns, nil
+}
+
+func (r *boltReturnRepo) Update(ctx context.Context, ret *domain.Return) error {
+	_, span := r.returns.startSpan(ctx, "replaceOne", attribute.String("return.id", ret.ID))
+	defer span.End()
+
+	doc := newReturnDocument(ret)
+	doc.Version = ret.Version + 1
+	_, err := r.returns.update(ret.ID, func(d *returnDocument) (bool, error) {
+		if d.Version != ret.Version {
+			return false, domain.ErrConflict
+		}
+		doc.ID = d.ID
+		*d = doc
+		return true, nil
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		err = domain.ErrConflict
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	ret.Version = doc.Version
+	return nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type boltScheduledPriceRepo struct {
+	changes boltCollection[scheduledPriceDocument]
+}
+
+func NewBoltScheduledPriceRepository(db *bolt.DB) (ScheduledPriceRepository, error) {
+	changes, err := newBoltCollection[scheduledPriceDocument](db, "scheduled_prices")
+	if err != nil {
+		return nil, err
+	}
+	return &boltScheduledPriceRepo{changes: changes}, nil
+}
+
+func (r *boltScheduledPriceRepo) Create(ctx context.Context, s *domain.ScheduledPrice) (string, error) {
+	_, span := r.changes.startSpan(ctx, "insertOne", attribute.String("product.id", s.ProductID))
+	defer span.End()
+
+	doc := newScheduledPriceDocument(s)
+	doc.ID = primitive.NewObjectID()
+	if err := r.changes.insert(doc.ID, &doc); err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	return doc.ID.Hex(), nil
+}
+
+func (r *boltScheduledPriceRepo) GetByID(ctx context.Context, id string) (*domain.ScheduledPrice, error) {
+	_, span := r.changes.startSpan(ctx, "findOne", attribute.String("scheduled_price.id", id))
+	defer span.End()
+
+	doc, err := r.changes.load(id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *boltScheduledPriceRepo) ListByProduct(ctx context.Context, productID string, statuses ...domain.ScheduledPriceStatus) ([]*domain.ScheduledPrice, error) {
+	_, span := r.changes.startSpan(ctx, "find", attribute.String("product.id", productID))
+	defer span.End()
+
+	changes, err := r.find(func(d *scheduledPriceDocument) bool {
+		return d.ProductID == productID &&
+			(len(statuses) == 0 || slices.Contains(statuses, domain.ScheduledPriceStatus(d.Status)))
+	})
+	tracing.RecordError(span, err)
+	return changes, err
+}
+
+func (r *boltScheduledPriceRepo) ListDue(ctx context.Context, now time.Time) ([]*domain.ScheduledPrice, error) {
+	_, span := r.changes.startSpan(ctx, "find")
+	defer span.End()
+
+	changes, err := r.find(func(d *scheduledPriceDocument) bool {
+		switch {
+		case d.HistoryPending:
+			return true
+		case d.Status == string(domain.ScheduledPricePending):
+			return !d.EffectiveFrom.After(now)
+		case d.Status == string(domain.ScheduledPriceActive):
+			return d.EffectiveTo != nil && !d.EffectiveTo.After(now)
+		}
+		return false
+	})
+	tracing.RecordError(span, err)
+	return changes, err
+}
+
+func (r *boltScheduledPriceRepo) Transition(ctx context.Context, id string, from, to domain.ScheduledPriceStatus, previousPrice float64, historyPending bool) (bool, error) {
+	_, span := r.changes.startSpan(ctx, "updateOne", attribute.String("scheduled_price.id", id))
+	defer span.End()
+
+	moved, err := r.changes.update(id, func(d *scheduledPriceDocument) (bool, error) {
+		if d.Status != string(from) {
+			return false, nil
+		}
+		d.Status, d.PreviousPrice, d.HistoryPending = string(to), previousPrice, historyPending
+		return true, nil
+	})
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return moved, nil
+}
+
+func (r *boltScheduledPriceRepo) ClearHistoryPending(ctx context.Context, id string) error {
+	_, span := r.changes.startSpan(ctx, "updateOne", attribute.String("scheduled_price.id", id))
+	defer span.End()
+
+	_, err := r.changes.update(id, func(d *scheduledPriceDocument) (bool, error) {
+		d.HistoryPending = false
+		return true, nil
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	tracing.RecordError(span, err)
+	return err
+}
+
+// find returns the matching changes ordered by effective_from.
+func (r *boltScheduledPriceRepo) find(keep func(*scheduledPriceDocument) bool) ([]*domain.ScheduledPrice, error) {
+	docs, err := r.changes.find(keep)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].EffectiveFrom.Before(docs[j].EffectiveFrom) })
+	changes := make([]*domain.ScheduledPrice, 0, len(docs))
+	for _, d := range docs {
+		changes = append(changes, d.toDomain())
+	}
+	return changes, nil
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type boltSerialRepo struct {
+	units boltCollection[serialDocument]
+}
+
+// NewBoltSerialRepository keeps serial numbers unique in an index mapping
+// each to its unit.
+func NewBoltSerialRepository(db *bolt.DB) (SerialRepository, error) {
+	units, err := newBoltCollection[serialDocument](db, "serial_units", "serial")
+	if err != nil {
+		return nil, err
+	}
+	return &boltSerialRepo{units: units}, nil
+}
+
+// CreateMany stores all units or, when a serial number is taken, none.
+func (r *boltSerialRepo) CreateMany(ctx context.Context, units []*domain.SerialUnit) error {
+	_, span := r.units.startSpan(ctx, "insertMany", attribute.Int("serial.count", len(units)))
+	defer span.End()
+
+	docs := make([]serialDocument, 0, len(units))
+	for _, u := range units {
+		doc := newSerialDocument(u)
+		doc.ID = primitive.NewObjectID()
+		docs = append(docs, doc)
+	}
+	err := r.units.db.Update(func(tx *bolt.Tx) error {
+		for i := range docs {
+			ok, err := r.units.claim(tx, "serial", docs[i].Serial, docs[i].ID)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("%w: serial %s already exists", domain.ErrInvalidArgument, docs[i].Serial)
+			}
+			if err := r.units.put(tx, docs[i].ID[:], &docs[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	for i, u := range units {
+		u.ID = docs[i].ID.Hex()
+	}
+	return nil
+}
+
+func (r *boltSerialRepo) FindExisting(ctx context.Context, serials []string) ([]string, error) {
+	_, span := r.units.startSpan(ctx, "distinct")
+	defer span.End()
+
+	var existing []string
+	err := r.units.db.View(func(tx *bolt.Tx) error {
+		index := r.units.index(tx, "serial")
+		for _, s := range serials {
+			if index.Get([]byte(s)) != nil && !slices.Contains(existing, s) {
+				existing = append(existing, s)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (r *boltSerialRepo) GetBySerial(ctx context.Context, serial string) (*domain.SerialUnit, error) {
+	_, span := r.units.startSpan(ctx, "findOne", attribute.String("serial", serial))
+	defer span.End()
+
+	var doc *serialDocument
+	err := r.units.db.View(func(tx *bolt.Tx) error {
+		var err error
+		doc, err = r.units.lookup(tx, "serial", serial)
+		return err
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *boltSerialRepo) ListByProduct(ctx context.Context, productID string, status domain.SerialStatus, limit int64) ([]*domain.SerialUnit, error) {
+	_, span := r.units.startSpan(ctx, "find", attribute.String("product.id", productID))
+	defer span.End()
+
+	units, err := r.find(func(d *serialDocument) bool {
+		return d.ProductID == productID && (status == "" || d.Status == string(status))
+	}, limit)
+	tracing.RecordError(span, err)
+	return units, err
+}
+
+func (r *boltSerialRepo) ListAllocatable(ctx context.Context, productID string, limit int64) ([]*domain.SerialUnit, error) {
+	_, span := r.units.startSpan(ctx, "find", attribute.String("product.id", productID))
+	defer span.End()
+
+	units, err := r.find(func(d *serialDocument) bool {
+		return d.ProductID == productID && d.Status == string(domain.SerialAvailable) && d.Booked
+	}, limit)
+	tracing.RecordError(span, err)
+	return units, err
+}
+
+// find returns up to limit matching units, oldest first.
+func (r *boltSerialRepo) find(keep func(*serialDocument) bool, limit int64) ([]*domain.SerialUnit, error) {
+	docs, err := r.units.find(keep)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].ReceivedAt.Before(docs[j].ReceivedAt) })
+	if limit > 0 && int64(len(docs)) > limit {
+		docs = docs[:limit]
+	}
+	units := make([]*domain.SerialUnit, 0, len(docs))
+	for _, d := range docs {
+		units = append(units, d.toDomain())
+	}
+	return units, nil
+}
+
+func (r *boltSerialRepo) Transition(ctx context.Context, u *domain.SerialUnit, from domain.SerialStatus) (bool, error) {
+	_, span := r.units.startSpan(ctx, "updateOne", attribute.String("serial", u.Serial))
+	defer span.End()
+
+	moved, err := r.units.update(u.ID, func(d *serialDocument) (bool, error) {
+		if d.Status != string(from) || !d.Booked {
+			return false, nil
+		}
+		d.Status, d.Location, d.OrderID, d.UpdatedAt = string(u.Status), u.Location, u.OrderID, u.UpdatedAt
+		return true, nil
+	})
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return moved, nil
+}
+
+func (r *boltSerialRepo) SetBooked(ctx context.Context, serial string, booked bool) (bool, error) {
+	_, span := r.units.startSpan(ctx, "updateOne", attribute.String("serial", serial), attribute.Bool("serial.booked", booked))
+	defer span.End()
+
+	changed := false
+	err := r.units.db.Update(func(tx *bolt.Tx) error {
+		id := r.units.index(tx, "serial").Get([]byte(serial))
+		if id == nil {
+			return nil
+		}
+		doc, err := r.units.getKey(tx, id)
+		if err != nil || doc.Booked == booked {
+			return err
+		}
+		doc.Booked, changed = booked, true
+		return r.units.put(tx, id, doc)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return changed, nil
+}
+
+func (r *boltSerialRepo) CountByStatus(ctx context.Context, productID string) (map[domain.SerialStatus]int64, error) {
+	_, span := r.units.startSpan(ctx, "aggregate", attribute.String("product.id", productID))
+	defer span.End()
+
+	docs, err := r.units.find(func(d *serialDocument) bool { return d.ProductID == productID })
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	counts := make(map[domain.SerialStatus]int64)
+	for _, d := range docs {
+		counts[domain.SerialStatus(d.Status)]++
+	}
+	return counts, nil
+}
+
+func (r *boltSerialRepo) CountAvailableByLocation(ctx context.Context, productIDs []string) (map[string]map[string]int32, error) {
+	_, span := r.units.startSpan(ctx, "aggregate", attribute.Int("product.count", len(productIDs)))
+	defer span.End()
+
+	docs, err := r.units.find(func(d *serialDocument) bool {
+		return d.Status == string(domain.SerialAvailable) && d.Booked && slices.Contains(productIDs, d.ProductID)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	counts := make(map[string]map[string]int32)
+	for _, d := range docs {
+		if counts[d.ProductID] == nil {
+			counts[d.ProductID] = make(map[string]int32)
+		}
+		counts[d.ProductID][d.Location]++
+	}
+	return counts, nil
+}
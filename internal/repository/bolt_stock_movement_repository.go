@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type boltStockMovementRepo struct {
+	movements boltCollection[stockMovementDocument]
+}
+
+func NewBoltStockMovementRepository(db *bolt.DB) (StockMovementRepository, error) {
+	movements, err := newBoltCollection[stockMovementDocument](db, "stock_movements")
+	if err != nil {
+		return nil, err
+	}
+	return &boltStockMovementRepo{movements: movements}, nil
+}
+
+func (r *boltStockMovementRepo) Record(ctx context.Context, m *domain.StockMovement) error {
+	_, span := r.movements.startSpan(ctx, "insertOne", attribute.String("product.id", m.ProductID))
+	defer span.End()
+
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now().UTC()
+	}
+	doc := newStockMovementDocument(m)
+	doc.ID = primitive.NewObjectID()
+	if err := r.movements.insert(doc.ID, &doc); err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	m.ID = doc.ID.Hex()
+	return nil
+}
+
+func (r *boltStockMovementRepo) ListByProducts(ctx context.Context, productIDs []string) ([]*domain.StockMovement, error) {
+	_, span := r.movements.startSpan(ctx, "find", attribute.Int("product.count", len(productIDs)))
+	defer span.End()
+
+	movements, err := r.find(func(d *stockMovementDocument) bool { return slices.Contains(productIDs, d.ProductID) })
+	tracing.RecordError(span, err)
+	return movements, err
+}
+
+func (r *boltStockMovementRepo) ListByReasons(ctx context.Context, productIDs []string, reasons []domain.MovementReason, since time.Time) ([]*domain.StockMovement, error) {
+	_, span := r.movements.startSpan(ctx, "find", attribute.Int("product.count", len(productIDs)))
+	defer span.End()
+
+	movements, err := r.find(func(d *stockMovementDocument) bool {
+		return slices.Contains(reasons, domain.MovementReason(d.Reason)) && !d.CreatedAt.Before(since) &&
+			(len(productIDs) == 0 || slices.Contains(productIDs, d.ProductID))
+	})
+	tracing.RecordError(span, err)
+	return movements, err
+}
+
+// find returns the matching movements, oldest first.
+func (r *boltStockMovementRepo) find(keep func(*stockMovementDocument) bool) ([]*domain.StockMovement, error) {
+	docs, err := r.movements.find(keep)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].CreatedAt.Before(docs[j].CreatedAt) })
+	movements := make([]*domain.StockMovement, 0, len(docs))
+	for _, d := range docs {
+		movements = append(movements, d.toDomain())
+	}
+	return movements, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type boltSupplierRepo struct {
+	suppliers boltCollection[supplierDocument]
+}
+
+func NewBoltSupplierRepository(db *bolt.DB) (SupplierRepository, error) {
+	suppliers, err := newBoltCollection[supplierDocument](db, "suppliers")
+	if err != nil {
+		return nil, err
+	}
+	return &boltSupplierRepo{suppliers: suppliers}, nil
+}
+
+func (r *boltSupplierRepo) Create(ctx context.Context, s *domain.Supplier) (string, error) {
+	_, span := r.suppliers.startSpan(ctx, "insertOne")
+	defer span.End()
+
+	doc := newSupplierDocument(s)
+	doc.ID = primitive.NewObjectID()
+	if err := r.suppliers.insert(doc.ID, &doc); err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("supplier.id", doc.ID.Hex()))
+	return doc.ID.Hex(), nil
+}
+
+func (r *boltSupplierRepo) GetByID(ctx context.Context, id string) (*domain.Supplier, error) {
+	_, span := r.suppliers.startSpan(ctx, "findOne", attribute.String("supplier.id", id))
+	defer span.End()
+
+	doc, err := r.suppliers.load(id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *boltSupplierRepo) List(ctx context.Context) ([]*domain.Supplier, error) {
+	_, span := r.suppliers.startSpan(ctx, "find")
+	defer span.End()
+
+	docs, err := r.suppliers.find(func(*supplierDocument) bool { return true })
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].Name < docs[j].Name })
+	suppliers := make([]*domain.Supplier, 0, len(docs))
+	for _, d := range docs {
+		suppliers = append(suppliers, d.toDomain())
+	}
+	return suppliers, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type boltVariantRepo struct {
+	variants boltCollection[variantDocument]
+}
+
+// NewBoltVariantRepository keeps variant SKUs unique in an index mapping
+// each to its variant.
+func NewBoltVariantRepository(db *bolt.DB) (VariantRepository, error) {
+	variants, err := newBoltCollection[variantDocument](db, "product_variants", "sku")
+	if err != nil {
+		return nil, err
+	}
+	return &boltVariantRepo{variants: variants}, nil
+}
+
+func (r *boltVariantRepo) Create(ctx context.Context, v *domain.Variant) (string, error) {
+	_, span := r.variants.startSpan(ctx, "insertOne", attribute.String("product.id", v.ProductID))
+	defer span.End()
+
+	doc := newVariantDocument(v)
+	doc.ID = primitive.NewObjectID()
+	err := r.variants.db.Update(func(tx *bolt.Tx) error {
+		ok, err := r.variants.claim(tx, "sku", v.SKU, doc.ID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: SKU %s is already in use", domain.ErrInvalidArgument, v.SKU)
+		}
+		return r.variants.put(tx, doc.ID[:], &doc)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("variant.id", doc.ID.Hex()))
+	return doc.ID.Hex(), nil
+}
+
+func (r *boltVariantRepo) GetByID(ctx context.Context, id string) (*domain.Variant, error) {
+	_, span := r.variants.startSpan(ctx, "findOne", attribute.String("variant.id", id))
+	defer span.End()
+
+	doc, err := r.variants.load(id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *boltVariantRepo) ListByProduct(ctx context.Context, productID string) ([]*domain.Variant, error) {
+	_, span := r.variants.startSpan(ctx, "find", attribute.String("product.id", productID))
+	defer span.End()
+
+	docs, err := r.variants.find(func(d *variantDocument) bool { return d.ProductID == productID })
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].SKU < docs[j].SKU })
+	variants := make([]*domain.Variant, 0, len(docs))
+	for _, d := range docs {
+		variants = append(variants, d.toDomain())
+	}
+	return variants, nil
+}
+
+func (r *boltVariantRepo) GetBySKU(ctx context.Context, sku string) (*domain.Variant, error) {
+	_, span := r.variants.startSpan(ctx, "findOne", attribute.String("variant.sku", sku))
+	defer span.End()
+
+	var doc *variantDocument
+	err := r.variants.db.View(func(tx *bolt.Tx) error {
+		var err error
+		doc, err = r.variants.lookup(tx, "sku", sku)
+		return err
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *boltVariantRepo) AdjustStock(ctx context.Context, id string, delta int32) (*domain.Variant, error) {
+	_, span := r.variants.startSpan(ctx, "findOneAndUpdate",
+		attribute.String("variant.id", id), attribute.Int("stock.delta", int(delta)))
+	defer span.End()
+
+	var v *domain.Variant
+	_, err := r.variants.update(id, func(d *variantDocument) (bool, error) {
+		if d.Stock+delta < 0 {
+			return false, domain.ErrInsufficientStock
+		}
+		d.Stock += delta
+		v = d.toDomain()
+		return true, nil
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return v, nil
+}
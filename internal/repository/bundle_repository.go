@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+type BundleRepository interface {
+	Create(ctx context.Context, b *domain.Bundle) (string, error)
+	GetByID(ctx context.Context, id string) (*domain.Bundle, error)
+	// ListByComponent returns the bundles that include a product.
+	ListByComponent(ctx context.Context, productID string) ([]*domain.Bundle, error)
+}
+
+type ReservationRepository interface {
+	Create(ctx context.Context, r *domain.Reservation) (string, error)
+	GetByID(ctx context.Context, id string) (*domain.Reservation, error)
+	// List returns reservations newest first, narrowed to a status and to
+	// those holding a product when these are not empty.
+	List(ctx context.Context, status domain.ReservationStatus, productID string) ([]*domain.Reservation, error)
+	Delete(ctx context.Context, id string) error
+	// SetLines replaces the lines of a reservation, recording what it still
+	// holds after a partial rollback.
+	SetLines(ctx context.Context, id string, lines []domain.ReservationLine) error
+	// Release marks an active reservation as released and reports whether
+	// this call did so, which makes releasing idempotent.
+	Release(ctx context.Context, id string, at time.Time) (bool, error)
+	// SetLineReleased sets the released flag of the line holding a product
+	// and reports whether this call changed it. Only lines of an active
+	// reservation can be marked released; marking one unreleased makes the
+	// reservation active again, since it still holds those units.
+	SetLineReleased(ctx context.Context, id, productID string, released bool) (bool, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+type CycleCountRepository interface {
+	Create(ctx context.Context, c *domain.CycleCount) (string, error)
+	GetByID(ctx context.Context, id string) (*domain.CycleCount, error)
+	// Update saves the session if its version is unchanged and returns
+	// ErrConflict otherwise.
+	Update(ctx context.Context, c *domain.CycleCount) error
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+type LotRepository interface {
+	Create(ctx context.Context, l *domain.Lot) (string, error)
+	GetByNumber(ctx context.Context, productID, lotNumber string) (*domain.Lot, error)
+	ListByProduct(ctx context.Context, productID string) ([]*domain.Lot, error)
+	// ListAllocatable returns the available, booked, unexpired lots of a
+	// product that still hold stock, earliest expiry first.
+	ListAllocatable(ctx context.Context, productID string, now time.Time) ([]*domain.Lot, error)
+	// ListExpiring returns available lots with stock expiring in [from, to).
+	ListExpiring(ctx context.Context, from, to time.Time) ([]*domain.Lot, error)
+	// Take removes quantity from an available lot if it still holds that
+	// much and reports whether it did.
+	Take(ctx context.Context, id string, quantity int32) (bool, error)
+	// Return puts quantity back into a lot.
+	Return(ctx context.Context, id string, quantity int32) error
+	// Quarantine moves an available lot to quarantine and returns it as it
+	// was at that moment; ok is false when the lot was not available.
+	Quarantine(ctx context.Context, id string) (lot *domain.Lot, ok bool, err error)
+	// SetBooked sets the booked flag of a lot and reports whether this call
+	// changed it, so that only one caller moves the lot's stock.
+	SetBooked(ctx context.Context, id string, booked bool) (bool, error)
+	// ListQuarantinedBooked returns the quarantined lots whose quantity is
+	// still counted in stock.
+	ListQuarantinedBooked(ctx context.Context) ([]*domain.Lot, error)
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/facelessEmptiness/inventory_service/internal/repository")
+
+func startSpan(ctx context.Context, coll *mongo.Collection, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		semconv.DBSystemMongoDB,
+		semconv.DBCollectionName(coll.Name()),
+		semconv.DBNamespace(coll.Database().Name()),
+		semconv.DBOperationName(op),
+	)
+	return tracer.Start(ctx, "mongo."+coll.Name()+"."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+}
+
+func mapNotFound(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+// mapDuplicateSKU reports a write rejected by a unique SKU index as the
+// invalid argument the SKU checks of the use cases would have returned.
+func mapDuplicateSKU(err error, sku string) error {
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("%w: SKU %s is already in use", domain.ErrInvalidArgument, sku)
+	}
+	return err
+}
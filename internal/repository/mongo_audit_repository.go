@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type mongoAuditRepo struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+type auditEventDocument struct {
+	ID         primitive.ObjectID    `bson:"_id,omitempty"`
+	ProductID  string                `bson:"product_id"`
+	Action     string                `bson:"action"`
+	Actor      string                `bson:"actor,omitempty"`
+	Verified   bool                  `bson:"actor_verified,omitempty"`
+	Peer       string                `bson:"peer,omitempty"`
+	RPC        string                `bson:"rpc,omitempty"`
+	RequestID  string                `bson:"request_id,omitempty"`
+	OccurredAt time.Time             `bson:"occurred_at"`
+	Changes    []fieldChangeDocument `bson:"changes,omitempty"`
+}
+
+type fieldChangeDocument struct {
+	Field  string `bson:"field" json:"field"`
+	Before string `bson:"before" json:"before"`
+	After  string `bson:"after" json:"after"`
+}
+
+func newAuditEventDocument(e *domain.AuditEvent) auditEventDocument {
+	doc := auditEventDocument{
+		ProductID:  e.ProductID,
+		Action:     string(e.Action),
+		Actor:      e.Actor,
+		Verified:   e.ActorVerified,
+		Peer:       e.Peer,
+		RPC:        e.RPC,
+		RequestID:  e.RequestID,
+		OccurredAt: e.OccurredAt,
+	}
+	for _, c := range e.Changes {
+		doc.Changes = append(doc.Changes, fieldChangeDocument{Field: c.Field, Before: c.Before, After: c.After})
+	}
+	return doc
+}
+
+func (d *auditEventDocument) toDomain() *domain.AuditEvent {
+	e := &domain.AuditEvent{
+		ID:            d.ID.Hex(),
+		ProductID:     d.ProductID,
+		Action:        domain.AuditAction(d.Action),
+		Actor:         d.Actor,
+		ActorVerified: d.Verified,
+		Peer:          d.Peer,
+		RPC:           d.RPC,
+		RequestID:     d.RequestID,
+		OccurredAt:    d.OccurredAt,
+	}
+	for _, c := range d.Changes {
+		e.Changes = append(e.Changes, domain.FieldChange{Field: c.Field, Before: c.Before, After: c.After})
+	}
+	return e
+}
+
+func NewMongoAuditRepository(db *mongo.Database, timeout time.Duration) AuditRepository {
+	return &mongoAuditRepo{coll: db.Collection("audit_events"), timeout: timeout}
+}
+
+func (r *mongoAuditRepo) Record(ctx context.Context, e *domain.AuditEvent) error {
+	ctx, span := startSpan(ctx, r.coll, "insertOne",
+		attribute.String("product.id", e.ProductID), attribute.String("audit.action", string(e.Action)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.coll.InsertOne(ctx, newAuditEventDocument(e))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	e.ID = res.InsertedID.(primitive.ObjectID).Hex()
+	return nil
+}
+
+func (r *mongoAuditRepo) List(ctx context.Context, f domain.AuditFilter) ([]*domain.AuditEvent, error) {
+	ctx, span := startSpan(ctx, r.coll, "find")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{}
+	if f.ProductID != "" {
+		filter["product_id"] = f.ProductID
+	}
+	if f.Actor != "" {
+		filter["actor"] = f.Actor
+	}
+	window := bson.M{}
+	if !f.From.IsZero() {
+		window["$gte"] = f.From
+	}
+	if !f.To.IsZero() {
+		window["$lt"] = f.To
+	}
+	if len(window) > 0 {
+		filter["occurred_at"] = window
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "occurred_at", Value: -1}, {Key: "_id", Value: -1}})
+	if f.Limit > 0 {
+		opts.SetLimit(f.Limit)
+	}
+	cur, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	var docs []auditEventDocument
+	if err := cur.All(ctx, &docs); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	events := make([]*domain.AuditEvent, 0, len(docs))
+	for i := range docs {
+		events = append(events, docs[i].toDomain())
+	}
+	return events, nil
+}
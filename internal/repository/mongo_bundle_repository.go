@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type mongoBundleRepo struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+type bundleDocument struct {
+	ID         primitive.ObjectID     `bson:"_id,omitempty"`
+	Name       string                 `bson:"name"`
+	SKU        string                 `bson:"sku,omitempty"`
+	Components []quantityLineDocument `bson:"components"`
+	CreatedAt  time.Time              `bson:"created_at"`
+}
+
+// quantityLineDocument stores a product and a quantity; it is shared by all
+// documents that hold such lines.
+type quantityLineDocument struct {
+	ProductID string `bson:"product_id" json:"product_id"`
+	Quantity  int32  `bson:"quantity" json:"quantity"`
+}
+
+func newBundleDocument(b *domain.Bundle) bundleDocument {
+	doc := bundleDocument{
+		Name:       b.Name,
+		SKU:        b.SKU,
+		Components: make([]quantityLineDocument, 0, len(b.Components)),
+		CreatedAt:  b.CreatedAt,
+	}
+	for _, c := range b.Components {
+		doc.Components = append(doc.Components, quantityLineDocument{ProductID: c.ProductID, Quantity: c.Quantity})
+	}
+	return doc
+}
+
+func (d *bundleDocument) toDomain() *domain.Bundle {
+	b := &domain.Bundle{
+		ID:         d.ID.Hex(),
+		Name:       d.Name,
+		SKU:        d.SKU,
+		Components: make([]domain.BundleComponent, 0, len(d.Components)),
+		CreatedAt:  d.CreatedAt,
+	}
+	for _, c := range d.Components {
+		b.Components = append(b.Components, domain.BundleComponent{ProductID: c.ProductID, Quantity: c.Quantity})
+	}
+	return b
+}
+
+func NewMongoBundleRepository(db *mongo.Database, timeout time.Duration) BundleRepository {
+	return &mongoBundleRepo{coll: db.Collection("bundles"), timeout: timeout}
+}
+
+func (r *mongoBundleRepo) Create(ctx context.Context, b *domain.Bundle) (string, error) {
+	ctx, span := startSpan(ctx, r.coll, "insertOne")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.coll.InsertOne(ctx, newBundleDocument(b))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	oid := res.InsertedID.(primitive.ObjectID).Hex()
+	span.SetAttributes(attribute.String("bundle.id", oid))
+	return oid, nil
+}
+
+func (r *mongoBundleRepo) GetByID(ctx context.Context, id string) (*domain.Bundle, error) {
+	ctx, span := startSpan(ctx, r.coll, "findOne", attribute.String("bundle.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	var doc bundleDocument
+	if err := r.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc); err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoBundleRepo) ListByComponent(ctx context.Context, productID string) ([]*domain.Bundle, error) {
+	ctx, span := startSpan(ctx, r.coll, "find", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cur, err := r.coll.Find(ctx, bson.M{"components.product_id": productID})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	var docs []bundleDocument
+	if err := cur.All(ctx, &docs); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	bundles := make([]*domain.Bundle, 0, len(docs))
+	for i := range docs {
+		bundles = append(bundles, docs[i].toDomain())
+	}
+	return bundles, nil
+}
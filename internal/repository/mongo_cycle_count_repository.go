@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type mongoCycleCountRepo struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+type cycleCountDocument struct {
+	ID         primitive.ObjectID       `bson:"_id,omitempty"`
+	Status     string                   `bson:"status"`
+	Lines      []cycleCountLineDocument `bson:"lines"`
+	CreatedAt  time.Time                `bson:"created_at"`
+	CreatedBy  string                   `bson:"created_by,omitempty"`
+	UpdatedAt  time.Time                `bson:"updated_at"`
+	ApprovedAt time.Time                `bson:"approved_at,omitempty"`
+	ApprovedBy string                   `bson:"approved_by,omitempty"`
+	Version    int64                    `bson:"version"`
+}
+
+type cycleCountLineDocument struct {
+	ProductID   string    `bson:"product_id" json:"product_id"`
+	Counted     int32     `bson:"counted" json:"counted"`
+	SystemStock int32     `bson:"system_stock" json:"system_stock"`
+	CountedAt   time.Time `bson:"counted_at,omitempty" json:"counted_at"`
+	Reason      string    `bson:"reason,omitempty" json:"reason"`
+	Applied     bool      `bson:"applied" json:"applied"`
+}
+
+func newCycleCountDocument(c *domain.CycleCount) cycleCountDocument {
+	doc := cycleCountDocument{
+		Status:     string(c.Status),
+		Lines:      make([]cycleCountLineDocument, 0, len(c.Lines)),
+		CreatedAt:  c.CreatedAt,
+		CreatedBy:  c.CreatedBy,
+		UpdatedAt:  c.UpdatedAt,
+		ApprovedAt: c.ApprovedAt,
+		ApprovedBy: c.ApprovedBy,
+		Version:    c.Version,
+	}
+	for _, l := range c.Lines {
+		doc.Lines = append(doc.Lines, cycleCountLineDocument{
+			ProductID:   l.ProductID,
+			Counted:     l.Counted,
+			SystemStock: l.SystemStock,
+			CountedAt:   l.CountedAt,
+			Reason:      string(l.Reason),
+			Applied:     l.Applied,
+		})
+	}
+	return doc
+}
+
+func (d *cycleCountDocument) toDomain() *domain.CycleCount {
+	c := &domain.CycleCount{
+		ID:         d.ID.Hex(),
+		Status:     domain.CycleCountStatus(d.Status),
+		Lines:      make([]domain.CycleCountLine, 0, len(d.Lines)),
+		CreatedAt:  d.CreatedAt,
+		CreatedBy:  d.CreatedBy,
+		UpdatedAt:  d.UpdatedAt,
+		ApprovedAt: d.ApprovedAt,
+		ApprovedBy: d.ApprovedBy,
+		Version:    d.Version,
+	}
+	for _, l := range d.Lines {
+		c.Lines = append(c.Lines, domain.CycleCountLine{
+			ProductID:   l.ProductID,
+			Counted:     l.Counted,
+			SystemStock: l.SystemStock,
+			CountedAt:   l.CountedAt,
+			Reason:      domain.MovementReason(l.Reason),
+			Applied:     l.Applied,
+		})
+	}
+	return c
+}
+
+func NewMongoCycleCountRepository(db *mongo.Database, timeout time.Duration) CycleCountRepository {
+	return &mongoCycleCountRepo{coll: db.Collection("cycle_counts"), timeout: timeout}
+}
+
+func (r *mongoCycleCountRepo) Create(ctx context.Context, c *domain.CycleCount) (string, error) {
+	ctx, span := startSpan(ctx, r.coll, "insertOne")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.coll.InsertOne(ctx, newCycleCountDocument(c))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	oid := res.InsertedID.(primitive.ObjectID).Hex()
+	span.SetAttributes(attribute.String("cycle_count.id", oid))
+	return oid, nil
+}
+
+func (r *mongoCycleCountRepo) GetByID(ctx context.Context, id string) (*domain.CycleCount, error) {
+	ctx, span := startSpan(ctx, r.coll, "findOne", attribute.String("cycle_count.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	var doc cycleCountDocument
+	if err := r.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc); err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoCycleCountRepo) Update(ctx context.Context, c *domain.CycleCount) error {
+	ctx, span := startSpan(ctx, r.coll, "replaceOne", attribute.String("cycle_count.id", c.ID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(c.ID)
+	doc := newCycleCountDocument(c)
+	doc.ID = oid
+	doc.Version = c.Version + 1
+	res, err := r.coll.ReplaceOne(ctx, bson.M{"_id": oid, "version": c.Version}, doc)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		tracing.RecordError(span, domain.ErrConflict)
+		return domain.ErrConflict
+	}
+	c.Version = doc.Version
+	return nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type mongoLotRepo struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+type lotDocument struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	ProductID      string             `bson:"product_id"`
+	LotNumber      string             `bson:"lot_number"`
+	ManufacturedAt time.Time          `bson:"manufactured_at,omitempty"`
+	ExpiresAt      time.Time          `bson:"expires_at"`
+	Quantity       int32              `bson:"quantity"`
+	UnitCost       float64            `bson:"unit_cost,omitempty"`
+	Status         string             `bson:"status"`
+	ReceivedAt     time.Time          `bson:"received_at"`
+	Booked         bool               `bson:"booked"`
+}
+
+func newLotDocument(l *domain.Lot) lotDocument {
+	return lotDocument{
+		ProductID:      l.ProductID,
+		LotNumber:      l.LotNumber,
+		ManufacturedAt: l.ManufacturedAt,
+		ExpiresAt:      l.ExpiresAt,
+		Quantity:       l.Quantity,
+		UnitCost:       l.UnitCost,
+		Status:         string(l.Status),
+		ReceivedAt:     l.ReceivedAt,
+		Booked:         l.Booked,
+	}
+}
+
+func (d *lotDocument) toDomain() *domain.Lot {
+	return &domain.Lot{
+		ID:             d.ID.Hex(),
+		ProductID:      d.ProductID,
+		LotNumber:      d.LotNumber,
+		ManufacturedAt: d.ManufacturedAt,
+		ExpiresAt:      d.ExpiresAt,
+		Quantity:       d.Quantity,
+		UnitCost:       d.UnitCost,
+		Status:         domain.LotStatus(d.Status),
+		ReceivedAt:     d.ReceivedAt,
+		Booked:         d.Booked,
+	}
+}
+
+func NewMongoLotRepository(db *mongo.Database, timeout time.Duration) LotRepository {
+	return &mongoLotRepo{coll: db.Collection("lots"), timeout: timeout}
+}
+
+func (r *mongoLotRepo) Create(ctx context.Context, l *domain.Lot) (string, error) {
+	ctx, span := startSpan(ctx, r.coll, "insertOne", attribute.String("product.id", l.ProductID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.coll.InsertOne(ctx, newLotDocument(l))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	oid := res.InsertedID.(primitive.ObjectID).Hex()
+	span.SetAttributes(attribute.String("lot.id", oid))
+	return oid, nil
+}
+
+func (r *mongoLotRepo) GetByNumber(ctx context.Context, productID, lotNumber string) (*domain.Lot, error) {
+	ctx, span := startSpan(ctx, r.coll, "findOne",
+		attribute.String("product.id", productID), attribute.String("lot.number", lotNumber))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var doc lotDocument
+	if err := r.coll.FindOne(ctx, bson.M{"product_id": productID, "lot_number": lotNumber}).Decode(&doc); err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoLotRepo) ListByProduct(ctx context.Context, productID string) ([]*domain.Lot, error) {
+	ctx, span := startSpan(ctx, r.coll, "find", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	lots, err := r.find(ctx, bson.M{"product_id": productID})
+	tracing.RecordError(span, err)
+	return lots, err
+}
+
+func (r *mongoLotRepo) ListAllocatable(ctx context.Context, productID string, now time.Time) ([]*domain.Lot, error) {
+	ctx, span := startSpan(ctx, r.coll, "find", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	lots, err := r.find(ctx, bson.M{
+		"product_id": productID,
+		"status":     string(domain.LotAvailable),
+		"booked":     true,
+		"quantity":   bson.M{"$gt": 0},
+		"expires_at": bson.M{"$gt": now},
+	})
+	tracing.RecordError(span, err)
+	return lots, err
+}
+
+func (r *mongoLotRepo) ListExpiring(ctx context.Context, from, to time.Time) ([]*domain.Lot, error) {
+	ctx, span := startSpan(ctx, r.coll, "find")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	lots, err := r.find(ctx, bson.M{
+		"status":     string(domain.LotAvailable),
+		"quantity":   bson.M{"$gt": 0},
+		"expires_at": bson.M{"$gte": from, "$lt": to},
+	})
+	tracing.RecordError(span, err)
+	return lots, err
+}
+
+func (r *mongoLotRepo) Take(ctx context.Context, id string, quantity int32) (bool, error) {
+	ctx, span := startSpan(ctx, r.coll, "updateOne", attribute.String("lot.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	res, err := r.coll.UpdateOne(ctx,
+		bson.M{"_id": oid, "status": string(domain.LotAvailable), "quantity": bson.M{"$gte": quantity}},
+		bson.M{"$inc": bson.M{"quantity": -quantity}})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+func (r *mongoLotRepo) Return(ctx context.Context, id string, quantity int32) error {
+	ctx, span := startSpan(ctx, r.coll, "updateOne", attribute.String("lot.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	_, err := r.coll.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$inc": bson.M{"quantity": quantity}})
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *mongoLotRepo) Quarantine(ctx context.Context, id string) (*domain.Lot, bool, error) {
+	ctx, span := startSpan(ctx, r.coll, "findOneAndUpdate", attribute.String("lot.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	var doc lotDocument
+	err := r.coll.FindOneAndUpdate(ctx,
+		bson.M{"_id": oid, "status": string(domain.LotAvailable)},
+		bson.M{"$set": bson.M{"status": string(domain.LotQuarantined)}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, false, nil
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, false, err
+	}
+	return doc.toDomain(), true, nil
+}
+
+func (r *mongoLotRepo) SetBooked(ctx context.Context, id string, booked bool) (bool, error) {
+	ctx, span := startSpan(ctx, r.coll, "updateOne", attribute.String("lot.id", id), attribute.Bool("lot.booked", booked))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	res, err := r.coll.UpdateOne(ctx,
+		bson.M{"_id": oid, "booked": !booked},
+		bson.M{"$set": bson.M{"booked": booked}})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+func (r *mongoLotRepo) ListQuarantinedBooked(ctx context.Context) ([]*domain.Lot, error) {
+	ctx, span := startSpan(ctx, r.coll, "find")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	lots, err := r.find(ctx, bson.M{"status": string(domain.LotQuarantined), "booked": true})
+	tracing.RecordError(span, err)
+	return lots, err
+}
+
+func (r *mongoLotRepo) find(ctx context.Context, filter interface{}) ([]*domain.Lot, error) {
+	cur, err := r.coll.Find(ctx, filter, options.Find().SetSort(bson.D{
+		{Key: "expires_at", Value: 1},
+		{Key: "_id", Value: 1},
+	}))
+	if err != nil {
+		return nil, err
+	}
+	var docs []lotDocument
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	lots := make([]*domain.Lot, 0, len(docs))
+	for i := range docs {
+		lots = append(lots, docs[i].toDomain())
+	}
+	return lots, nil
+}
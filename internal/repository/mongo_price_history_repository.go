@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type mongoPriceHistoryRepo struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+type priceRecordDocument struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	ProductID     string             `bson:"product_id"`
+	Price         float64            `bson:"price"`
+	EffectiveFrom time.Time          `bson:"effective_from"`
+	EffectiveTo   *time.Time         `bson:"effective_to"`
+	Source        string             `bson:"source"`
+	Actor         string             `bson:"actor,omitempty"`
+}
+
+// newPriceRecordDocument stores a record as the current one, open-ended.
+func newPriceRecordDocument(r *domain.PriceRecord) priceRecordDocument {
+	return priceRecordDocument{
+		ProductID:     r.ProductID,
+		Price:         r.Price,
+		EffectiveFrom: r.EffectiveFrom,
+		Source:        r.Source,
+		Actor:         r.Actor,
+	}
+}
+
+func (d *priceRecordDocument) toDomain() *domain.PriceRecord {
+	r := &domain.PriceRecord{
+		ID:            d.ID.Hex(),
+		ProductID:     d.ProductID,
+		Price:         d.Price,
+		EffectiveFrom: d.EffectiveFrom,
+		Source:        d.Source,
+		Actor:         d.Actor,
+	}
+	if d.EffectiveTo != nil {
+		r.EffectiveTo = *d.EffectiveTo
+	}
+	return r
+}
+
+func NewMongoPriceHistoryRepository(db *mongo.Database, timeout time.Duration) PriceHistoryRepository {
+	return &mongoPriceHistoryRepo{coll: db.Collection("price_history"), timeout: timeout}
+}
+
+func (r *mongoPriceHistoryRepo) Record(ctx context.Context, rec *domain.PriceRecord) error {
+	ctx, span := startSpan(ctx, r.coll, "insertOne", attribute.String("product.id", rec.ProductID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if _, err := r.coll.UpdateMany(ctx,
+		bson.M{"product_id": rec.ProductID, "effective_to": nil},
+		bson.M{"$set": bson.M{"effective_to": rec.EffectiveFrom}}); err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	res, err := r.coll.InsertOne(ctx, newPriceRecordDocument(rec))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	rec.ID = res.InsertedID.(primitive.ObjectID).Hex()
+	return nil
+}
+
+func (r *mongoPriceHistoryRepo) At(ctx context.Context, productID string, t time.Time) (*domain.PriceRecord, error) {
+	ctx, span := startSpan(ctx, r.coll, "findOne", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"product_id":     productID,
+		"effective_from": bson.M{"$lte": t},
+		"$or": bson.A{
+			bson.M{"effective_to": nil},
+			bson.M{"effective_to": bson.M{"$gt": t}},
+		},
+	}
+	var doc priceRecordDocument
+	err := r.coll.FindOne(ctx, filter,
+		options.FindOne().SetSort(bson.D{{Key: "effective_from", Value: -1}, {Key: "_id", Value: -1}})).Decode(&doc)
+	if err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoPriceHistoryRepo) ListByProduct(ctx context.Context, productID string) ([]*domain.PriceRecord, error) {
+	ctx, span := startSpan(ctx, r.coll, "find", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cur, err := r.coll.Find(ctx, bson.M{"product_id": productID},
+		options.Find().SetSort(bson.D{{Key: "effective_from", Value: -1}, {Key: "_id", Value: -1}}))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	var docs []priceRecordDocument
+	if err := cur.All(ctx, &docs); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	records := make([]*domain.PriceRecord, 0, len(docs))
+	for i := range docs {
+		records = append(records, docs[i].toDomain())
+	}
+	return records, nil
+}
@@ -2,49 +2,464 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type mongoProductRepo struct {
-	coll *mongo.Collection
+	coll    *mongo.Collection
+	timeout time.Duration
 }
 
-func NewMongoProductRepository(db *mongo.Database) ProductRepository {
-	return &mongoProductRepo{coll: db.Collection("products")}
+type productDocument struct {
+	ID                primitive.ObjectID `bson:"_id,omitempty"`
+	SKU               string             `bson:"sku,omitempty"`
+	Name              string             `bson:"name"`
+	Description       string             `bson:"description"`
+	Price             float64            `bson:"price"`
+	Stock             int32              `bson:"stock"`
+	DamagedStock      int32              `bson:"damaged_stock,omitempty"`
+	CategoryID        string             `bson:"category_id"`
+	ReorderPoint      int32              `bson:"reorder_point"`
+	ReorderQuantity   int32              `bson:"reorder_quantity"`
+	LowStockAlerted   bool               `bson:"low_stock_alerted"`
+	VariantAttributes []string           `bson:"variant_attributes,omitempty"`
+	LotTracked        bool               `bson:"lot_tracked,omitempty"`
+	Serialized        bool               `bson:"serialized,omitempty"`
+	DeletedAt         *time.Time         `bson:"deleted_at,omitempty"`
+	DeletedBy         string             `bson:"deleted_by,omitempty"`
 }
 
-func (r *mongoProductRepo) Create(p *domain.Product) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (d *productDocument) toDomain() *domain.Product {
+	p := &domain.Product{
+		ID:                d.ID.Hex(),
+		SKU:               d.SKU,
+		Name:              d.Name,
+		Description:       d.Description,
+		Price:             d.Price,
+		Stock:             d.Stock,
+		DamagedStock:      d.DamagedStock,
+		CategoryID:        d.CategoryID,
+		ReorderPoint:      d.ReorderPoint,
+		ReorderQuantity:   d.ReorderQuantity,
+		VariantAttributes: d.VariantAttributes,
+		LotTracked:        d.LotTracked,
+		Serialized:        d.Serialized,
+	}
+	if d.DeletedAt != nil {
+		p.DeletedAt = *d.DeletedAt
+		p.DeletedBy = d.DeletedBy
+	}
+	return p
+}
+
+// notDeleted is the filter that excludes archived products.
+func notDeleted(filter bson.M) bson.M {
+	filter["deleted_at"] = bson.M{"$exists": false}
+	return filter
+}
+
+func NewMongoProductRepository(db *mongo.Database, collection string, timeout time.Duration) ProductRepository {
+	return &mongoProductRepo{coll: db.Collection(collection), timeout: timeout}
+}
+
+func (r *mongoProductRepo) startSpan(ctx context.Context, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return startSpan(ctx, r.coll, op, attrs...)
+}
+
+func (r *mongoProductRepo) Create(ctx context.Context, p *domain.Product) (string, error) {
+	ctx, span := r.startSpan(ctx, "insertOne")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	doc := bson.M{
-		"name":        p.Name,
-		"description": p.Description,
-		"price":       p.Price,
-		"stock":       p.Stock,
-		"category_id": p.CategoryID,
+	doc := productDocument{
+		SKU:               p.SKU,
+		Name:              p.Name,
+		Description:       p.Description,
+		Price:             p.Price,
+		Stock:             p.Stock,
+		CategoryID:        p.CategoryID,
+		ReorderPoint:      p.ReorderPoint,
+		ReorderQuantity:   p.ReorderQuantity,
+		VariantAttributes: p.VariantAttributes,
+		LotTracked:        p.LotTracked,
+		Serialized:        p.Serialized,
 	}
 	res, err := r.coll.InsertOne(ctx, doc)
 	if err != nil {
+		err = mapDuplicateSKU(err, p.SKU)
+		tracing.RecordError(span, err)
 		return "", err
 	}
 	oid := res.InsertedID.(primitive.ObjectID).Hex()
+	span.SetAttributes(attribute.String("product.id", oid))
 	return oid, nil
 }
 
-func (r *mongoProductRepo) GetByID(id string) (*domain.Product, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *mongoProductRepo) GetByID(ctx context.Context, id string) (*domain.Product, error) {
+	oid, _ := primitive.ObjectIDFromHex(id)
+	return r.findOne(ctx, id, notDeleted(bson.M{"_id": oid}))
+}
+
+func (r *mongoProductRepo) GetByIDIncludingDeleted(ctx context.Context, id string) (*domain.Product, error) {
+	oid, _ := primitive.ObjectIDFromHex(id)
+	return r.findOne(ctx, id, bson.M{"_id": oid})
+}
+
+func (r *mongoProductRepo) findOne(ctx context.Context, id string, filter bson.M) (*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "findOne", attribute.String("product.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var doc productDocument
+	if err := r.coll.FindOne(ctx, filter).Decode(&doc); err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoProductRepo) GetMany(ctx context.Context, ids, skus []string) ([]*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "find",
+		attribute.Int("product.ids", len(ids)), attribute.Int("product.skus", len(skus)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var or bson.A
+	if oids := objectIDs(ids); len(oids) > 0 {
+		or = append(or, bson.M{"_id": bson.M{"$in": oids}})
+	}
+	if len(skus) > 0 {
+		or = append(or, bson.M{"sku": bson.M{"$in": skus}})
+	}
+	if len(or) == 0 {
+		return nil, nil
+	}
+	products, err := r.find(ctx, notDeleted(bson.M{"$or": or}))
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+// objectIDs converts hex ids, skipping those that are not valid ObjectIDs
+// as they cannot match any product.
+func objectIDs(ids []string) []primitive.ObjectID {
+	oids := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			oids = append(oids, oid)
+		}
+	}
+	return oids
+}
+
+func (r *mongoProductRepo) List(ctx context.Context, includeDeleted bool) ([]*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "find", attribute.Bool("include_deleted", includeDeleted))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{}
+	if !includeDeleted {
+		filter = notDeleted(filter)
+	}
+	products, err := r.find(ctx, filter)
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+func (r *mongoProductRepo) Search(ctx context.Context, query string, limit int) ([]*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "find", attribute.String("search.query", query))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
+	// Quoting every word makes the text index require all of them rather
+	// than any of them.
+	var phrases []string
+	for _, word := range strings.Fields(strings.ReplaceAll(query, `"`, " ")) {
+		phrases = append(phrases, `"`+word+`"`)
+	}
+	if len(phrases) == 0 {
+		return nil, nil
+	}
+	// A $text query cannot be combined with the SKU in one $or, so the
+	// exact SKU match is looked up first, through its own index.
+	var products []*domain.Product
+	if len(phrases) == 1 {
+		bySKU, err := r.find(ctx, notDeleted(bson.M{"sku": strings.TrimSpace(query)}), options.Find().SetLimit(1))
+		if err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		products = bySKU
+	}
+	if len(products) >= limit {
+		return products, nil
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}}).
+		SetLimit(int64(limit))
+	byText, err := r.find(ctx, notDeleted(bson.M{"$text": bson.M{"$search": strings.Join(phrases, " ")}}), opts)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	for _, p := range byText {
+		if len(products) == limit {
+			break
+		}
+		if len(products) == 0 || p.ID != products[0].ID {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
+func (r *mongoProductRepo) Update(ctx context.Context, id string, u *domain.ProductUpdate) (*domain.Product, *domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "findOneAndUpdate", attribute.String("product.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	set := bson.M{}
+	if u.Name != nil {
+		set["name"] = *u.Name
+	}
+	if u.Description != nil {
+		set["description"] = *u.Description
+	}
+	if u.Price != nil {
+		set["price"] = *u.Price
+	}
+	if u.CategoryID != nil {
+		set["category_id"] = *u.CategoryID
+	}
+	if u.ReorderPoint != nil {
+		set["reorder_point"] = *u.ReorderPoint
+	}
+	if u.ReorderQuantity != nil {
+		set["reorder_quantity"] = *u.ReorderQuantity
+	}
+
+	// The document as it was is returned; the $set only writes the fields
+	// of u, so applying them gives the document as stored.
 	oid, _ := primitive.ObjectIDFromHex(id)
-	var p domain.Product
-	if err := r.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&p); err != nil {
+	var doc productDocument
+	err := r.coll.FindOneAndUpdate(ctx, notDeleted(bson.M{"_id": oid}), bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.Before)).Decode(&doc)
+	if err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	before, after := doc.toDomain(), doc.toDomain()
+	u.Apply(after)
+	return before, after, nil
+}
+
+// AdjustStock atomically adds delta to the stock of a product. A negative
+// delta only applies when enough stock is left, so stock never goes below
+// zero; ErrInsufficientStock is returned otherwise.
+func (r *mongoProductRepo) AdjustStock(ctx context.Context, id string, delta int32) (*domain.Product, error) {
+	return r.adjust(ctx, "stock", id, delta)
+}
+
+func (r *mongoProductRepo) AdjustDamagedStock(ctx context.Context, id string, delta int32) (*domain.Product, error) {
+	return r.adjust(ctx, "damaged_stock", id, delta)
+}
+
+func (r *mongoProductRepo) adjust(ctx context.Context, field, id string, delta int32) (*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "findOneAndUpdate", attribute.String("product.id", id),
+		attribute.String("stock.bucket", field), attribute.Int("stock.delta", int(delta)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	filter := notDeleted(bson.M{"_id": oid})
+	if delta < 0 {
+		filter[field] = bson.M{"$gte": -delta}
+	}
+	var doc productDocument
+	err := r.coll.FindOneAndUpdate(ctx, filter,
+		bson.M{"$inc": bson.M{field: delta}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) && delta < 0 {
+		// Tell a missing product apart from one without enough stock.
+		if n, cerr := r.coll.CountDocuments(ctx, notDeleted(bson.M{"_id": oid})); cerr == nil && n > 0 {
+			err = domain.ErrInsufficientStock
+		}
+	}
+	if err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
 		return nil, err
 	}
-	return &p, nil
+	return doc.toDomain(), nil
+}
+
+func (r *mongoProductRepo) Count(ctx context.Context) (int64, error) {
+	ctx, span := r.startSpan(ctx, "countDocuments")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	n, err := r.coll.CountDocuments(ctx, notDeleted(bson.M{}))
+	tracing.RecordError(span, err)
+	return n, err
+}
+
+func (r *mongoProductRepo) CountOutOfStock(ctx context.Context) (int64, error) {
+	ctx, span := r.startSpan(ctx, "countDocuments")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	n, err := r.coll.CountDocuments(ctx, notDeleted(bson.M{"stock": bson.M{"$lte": 0}}))
+	tracing.RecordError(span, err)
+	return n, err
+}
+
+func (r *mongoProductRepo) ListLowStock(ctx context.Context) ([]*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "find")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := notDeleted(bson.M{
+		"reorder_point": bson.M{"$gt": 0},
+		"$expr":         bson.M{"$lte": bson.A{"$stock", "$reorder_point"}},
+	})
+	products, err := r.find(ctx, filter)
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+func (r *mongoProductRepo) SetLowStockAlerted(ctx context.Context, id string, alerted bool) (bool, error) {
+	ctx, span := r.startSpan(ctx, "updateOne", attribute.String("product.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	res, err := r.coll.UpdateOne(ctx,
+		bson.M{"_id": oid, "low_stock_alerted": bson.M{"$ne": alerted}},
+		bson.M{"$set": bson.M{"low_stock_alerted": alerted}})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+func (r *mongoProductRepo) SoftDelete(ctx context.Context, id, deletedBy string, at time.Time) (*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "findOneAndUpdate", attribute.String("product.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	var doc productDocument
+	err := r.coll.FindOneAndUpdate(ctx, notDeleted(bson.M{"_id": oid}),
+		bson.M{"$set": bson.M{"deleted_at": at, "deleted_by": deletedBy}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&doc)
+	if err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoProductRepo) Restore(ctx context.Context, id string) (*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "findOneAndUpdate", attribute.String("product.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	var doc productDocument
+	err := r.coll.FindOneAndUpdate(ctx,
+		bson.M{"_id": oid, "deleted_at": bson.M{"$exists": true}},
+		bson.M{"$unset": bson.M{"deleted_at": "", "deleted_by": ""}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if n, cerr := r.coll.CountDocuments(ctx, bson.M{"_id": oid}); cerr == nil && n > 0 {
+			err = fmt.Errorf("%w: product %s is not archived", domain.ErrFailedPrecondition, id)
+		}
+	}
+	if err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoProductRepo) ListDeleted(ctx context.Context, before time.Time) ([]*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "find")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	products, err := r.find(ctx, bson.M{"deleted_at": bson.M{"$lt": before}})
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+// PurgeDeleted deletes the products one at a time, which is what tells
+// which of them were still archived.
+func (r *mongoProductRepo) PurgeDeleted(ctx context.Context, ids []string, before time.Time) ([]string, error) {
+	ctx, span := r.startSpan(ctx, "deleteOne", attribute.Int("product.ids", len(ids)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	purged := make([]string, 0, len(ids))
+	for _, id := range ids {
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			continue
+		}
+		res, err := r.coll.DeleteOne(ctx, bson.M{"_id": oid, "deleted_at": bson.M{"$lt": before}})
+		if err != nil {
+			tracing.RecordError(span, err)
+			return purged, err
+		}
+		if res.DeletedCount == 1 {
+			purged = append(purged, id)
+		}
+	}
+	return purged, nil
+}
+
+func (r *mongoProductRepo) find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) ([]*domain.Product, error) {
+	cur, err := r.coll.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var docs []productDocument
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	products := make([]*domain.Product, 0, len(docs))
+	for i := range docs {
+		products = append(products, docs[i].toDomain())
+	}
+	return products, nil
 }
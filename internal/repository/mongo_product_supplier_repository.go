@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type mongoProductSupplierRepo struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+type productSupplierDocument struct {
+	ProductID        string  `bson:"product_id"`
+	SupplierID       string  `bson:"supplier_id"`
+	SupplierSKU      string  `bson:"supplier_sku,omitempty"`
+	UnitCost         float64 `bson:"unit_cost"`
+	LeadTimeDays     int32   `bson:"lead_time_days"`
+	MinOrderQuantity int32   `bson:"min_order_quantity"`
+}
+
+func newProductSupplierDocument(ps *domain.ProductSupplier) productSupplierDocument {
+	return productSupplierDocument{
+		ProductID:        ps.ProductID,
+		SupplierID:       ps.SupplierID,
+		SupplierSKU:      ps.SupplierSKU,
+		UnitCost:         ps.UnitCost,
+		LeadTimeDays:     ps.LeadTimeDays,
+		MinOrderQuantity: ps.MinOrderQuantity,
+	}
+}
+
+func (d *productSupplierDocument) toDomain() *domain.ProductSupplier {
+	return &domain.ProductSupplier{
+		ProductID:        d.ProductID,
+		SupplierID:       d.SupplierID,
+		SupplierSKU:      d.SupplierSKU,
+		UnitCost:         d.UnitCost,
+		LeadTimeDays:     d.LeadTimeDays,
+		MinOrderQuantity: d.MinOrderQuantity,
+	}
+}
+
+func NewMongoProductSupplierRepository(db *mongo.Database, timeout time.Duration) ProductSupplierRepository {
+	return &mongoProductSupplierRepo{coll: db.Collection("product_suppliers"), timeout: timeout}
+}
+
+func (r *mongoProductSupplierRepo) Upsert(ctx context.Context, ps *domain.ProductSupplier) error {
+	ctx, span := startSpan(ctx, r.coll, "replaceOne",
+		attribute.String("product.id", ps.ProductID), attribute.String("supplier.id", ps.SupplierID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.coll.ReplaceOne(ctx,
+		bson.M{"product_id": ps.ProductID, "supplier_id": ps.SupplierID},
+		newProductSupplierDocument(ps),
+		options.Replace().SetUpsert(true))
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *mongoProductSupplierRepo) Delete(ctx context.Context, productID, supplierID string) error {
+	ctx, span := startSpan(ctx, r.coll, "deleteOne",
+		attribute.String("product.id", productID), attribute.String("supplier.id", supplierID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.coll.DeleteOne(ctx, bson.M{"product_id": productID, "supplier_id": supplierID})
+	if err == nil && res.DeletedCount == 0 {
+		err = domain.ErrNotFound
+	}
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *mongoProductSupplierRepo) ListBySupplier(ctx context.Context, supplierID string) ([]*domain.ProductSupplier, error) {
+	ctx, span := startSpan(ctx, r.coll, "find", attribute.String("supplier.id", supplierID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	links, err := r.find(ctx, bson.M{"supplier_id": supplierID}, options.Find().SetSort(bson.D{{Key: "product_id", Value: 1}}))
+	tracing.RecordError(span, err)
+	return links, err
+}
+
+func (r *mongoProductSupplierRepo) ListByProduct(ctx context.Context, productID string) ([]*domain.ProductSupplier, error) {
+	ctx, span := startSpan(ctx, r.coll, "find", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	links, err := r.find(ctx, bson.M{"product_id": productID}, options.Find().SetSort(bson.D{{Key: "unit_cost", Value: 1}}))
+	tracing.RecordError(span, err)
+	return links, err
+}
+
+func (r *mongoProductSupplierRepo) Cheapest(ctx context.Context, productID string, quantity int32) (*domain.ProductSupplier, error) {
+	ctx, span := startSpan(ctx, r.coll, "findOne", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"product_id": productID}
+	if quantity > 0 {
+		filter["min_order_quantity"] = bson.M{"$lte": quantity}
+	}
+	var doc productSupplierDocument
+	err := r.coll.FindOne(ctx, filter, options.FindOne().SetSort(bson.D{
+		{Key: "unit_cost", Value: 1},
+		{Key: "lead_time_days", Value: 1},
+	})).Decode(&doc)
+	if err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoProductSupplierRepo) find(ctx context.Context, filter interface{}, opts *options.FindOptions) ([]*domain.ProductSupplier, error) {
+	cur, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var docs []productSupplierDocument
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	links := make([]*domain.ProductSupplier, 0, len(docs))
+	for i := range docs {
+		links = append(links, docs[i].toDomain())
+	}
+	return links, nil
+}
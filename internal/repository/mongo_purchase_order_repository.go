@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type mongoPurchaseOrderRepo struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+type purchaseOrderDocument struct {
+	ID         primitive.ObjectID          `bson:"_id,omitempty"`
+	SupplierID string                      `bson:"supplier_id"`
+	Lines      []purchaseOrderLineDocument `bson:"lines"`
+	ExpectedAt time.Time                   `bson:"expected_at"`
+	Status     string                      `bson:"status"`
+	Receipts   []receiptDocument           `bson:"receipts,omitempty"`
+	CreatedAt  time.Time                   `bson:"created_at"`
+	UpdatedAt  time.Time                   `bson:"updated_at"`
+	Version    int64                       `bson:"version"`
+}
+
+type purchaseOrderLineDocument struct {
+	ProductID        string `bson:"product_id" json:"product_id"`
+	Quantity         int32  `bson:"quantity" json:"quantity"`
+	ReceivedQuantity int32  `bson:"received_quantity" json:"received_quantity"`
+}
+
+type receiptDocument struct {
+	Lines      []receiptLineDocument `bson:"lines" json:"lines"`
+	ReceivedAt time.Time             `bson:"received_at" json:"received_at"`
+}
+
+type receiptLineDocument struct {
+	ProductID string  `bson:"product_id" json:"product_id"`
+	Quantity  int32   `bson:"quantity" json:"quantity"`
+	UnitCost  float64 `bson:"unit_cost" json:"unit_cost"`
+	Booked    bool    `bson:"booked" json:"booked"`
+}
+
+func newPurchaseOrderDocument(po *domain.PurchaseOrder) purchaseOrderDocument {
+	doc := purchaseOrderDocument{
+		SupplierID: po.SupplierID,
+		Lines:      make([]purchaseOrderLineDocument, 0, len(po.Lines)),
+		ExpectedAt: po.ExpectedAt,
+		Status:     string(po.Status),
+		CreatedAt:  po.CreatedAt,
+		UpdatedAt:  po.UpdatedAt,
+		Version:    po.Version,
+	}
+	for _, l := range po.Lines {
+		doc.Lines = append(doc.Lines, purchaseOrderLineDocument{
+			ProductID:        l.ProductID,
+			Quantity:         l.Quantity,
+			ReceivedQuantity: l.ReceivedQuantity,
+		})
+	}
+	for _, r := range po.Receipts {
+		rd := receiptDocument{Lines: make([]receiptLineDocument, 0, len(r.Lines)), ReceivedAt: r.ReceivedAt}
+		for _, l := range r.Lines {
+			rd.Lines = append(rd.Lines, receiptLineDocument{ProductID: l.ProductID, Quantity: l.Quantity, UnitCost: l.UnitCost, Booked: l.Booked})
+		}
+		doc.Receipts = append(doc.Receipts, rd)
+	}
+	return doc
+}
+
+func (d *purchaseOrderDocument) toDomain() *domain.PurchaseOrder {
+	po := &domain.PurchaseOrder{
+		ID:         d.ID.Hex(),
+		SupplierID: d.SupplierID,
+		Lines:      make([]domain.PurchaseOrderLine, 0, len(d.Lines)),
+		ExpectedAt: d.ExpectedAt,
+		Status:     domain.PurchaseOrderStatus(d.Status),
+		CreatedAt:  d.CreatedAt,
+		UpdatedAt:  d.UpdatedAt,
+		Version:    d.Version,
+	}
+	for _, l := range d.Lines {
+		po.Lines = append(po.Lines, domain.PurchaseOrderLine{
+			ProductID:        l.ProductID,
+			Quantity:         l.Quantity,
+			ReceivedQuantity: l.ReceivedQuantity,
+		})
+	}
+	for _, rd := range d.Receipts {
+		r := domain.Receipt{Lines: make([]domain.ReceiptLine, 0, len(rd.Lines)), ReceivedAt: rd.ReceivedAt}
+		for _, l := range rd.Lines {
+			r.Lines = append(r.Lines, domain.ReceiptLine{ProductID: l.ProductID, Quantity: l.Quantity, UnitCost: l.UnitCost, Booked: l.Booked})
+		}
+		po.Receipts = append(po.Receipts, r)
+	}
+	return po
+}
+
+func NewMongoPurchaseOrderRepository(db *mongo.Database, timeout time.Duration) PurchaseOrderRepository {
+	return &mongoPurchaseOrderRepo{coll: db.Collection("purchase_orders"), timeout: timeout}
+}
+
+func (r *mongoPurchaseOrderRepo) Create(ctx context.Context, po *domain.PurchaseOrder) (string, error) {
+	ctx, span := startSpan(ctx, r.coll, "insertOne")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.coll.InsertOne(ctx, newPurchaseOrderDocument(po))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	oid := res.InsertedID.(primitive.ObjectID).Hex()
+	span.SetAttributes(attribute.String("purchase_order.id", oid))
+	return oid, nil
+}
+
+func (r *mongoPurchaseOrderRepo) GetByID(ctx context.Context, id string) (*domain.PurchaseOrder, error) {
+	ctx, span := startSpan(ctx, r.coll, "findOne", attribute.String("purchase_order.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	var doc purchaseOrderDocument
+	if err := r.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc); err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoPurchaseOrderRepo) ListByStatus(ctx context.Context, statuses ...domain.PurchaseOrderStatus) ([]*domain.PurchaseOrder, error) {
+	ctx, span := startSpan(ctx, r.coll, "find")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	values := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		values = append(values, string(s))
+	}
+	cur, err := r.coll.Find(ctx, bson.M{"status": bson.M{"$in": values}})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	var docs []purchaseOrderDocument
+	if err := cur.All(ctx, &docs); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	orders := make([]*domain.PurchaseOrder, 0, len(docs))
+	for i := range docs {
+		orders = append(orders, docs[i].toDomain())
+	}
+	return orders, nil
+}
+
+func (r *mongoPurchaseOrderRepo) Update(ctx context.Context, po *domain.PurchaseOrder) error {
+	ctx, span := startSpan(ctx, r.coll, "replaceOne", attribute.String("purchase_order.id", po.ID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(po.ID)
+	doc := newPurchaseOrderDocument(po)
+	doc.ID = oid
+	doc.Version = po.Version + 1
+	res, err := r.coll.ReplaceOne(ctx, bson.M{"_id": oid, "version": po.Version}, doc)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		tracing.RecordError(span, domain.ErrConflict)
+		return domain.ErrConflict
+	}
+	po.Version = doc.Version
+	return nil
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type mongoReservationRepo struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+type reservationDocument struct {
+	ID         primitive.ObjectID        `bson:"_id,omitempty"`
+	BundleID   string                    `bson:"bundle_id"`
+	Quantity   int32                     `bson:"quantity"`
+	Lines      []reservationLineDocument `bson:"lines"`
+	Reference  string                    `bson:"reference,omitempty"`
+	Status     string                    `bson:"status"`
+	CreatedAt  time.Time                 `bson:"created_at"`
+	ReleasedAt time.Time                 `bson:"released_at,omitempty"`
+}
+
+type reservationLineDocument struct {
+	ProductID string `bson:"product_id" json:"product_id"`
+	Quantity  int32  `bson:"quantity" json:"quantity"`
+	Released  bool   `bson:"released,omitempty" json:"released"`
+}
+
+func toReservationLineDocuments(lines []domain.ReservationLine) []reservationLineDocument {
+	docs := make([]reservationLineDocument, 0, len(lines))
+	for _, l := range lines {
+		docs = append(docs, reservationLineDocument{ProductID: l.ProductID, Quantity: l.Quantity, Released: l.Released})
+	}
+	return docs
+}
+
+func newReservationDocument(r *domain.Reservation) reservationDocument {
+	return reservationDocument{
+		BundleID:   r.BundleID,
+		Quantity:   r.Quantity,
+		Lines:      toReservationLineDocuments(r.Lines),
+		Reference:  r.Reference,
+		Status:     string(r.Status),
+		CreatedAt:  r.CreatedAt,
+		ReleasedAt: r.ReleasedAt,
+	}
+}
+
+func (d *reservationDocument) toDomain() *domain.Reservation {
+	r := &domain.Reservation{
+		ID:         d.ID.Hex(),
+		BundleID:   d.BundleID,
+		Quantity:   d.Quantity,
+		Lines:      make([]domain.ReservationLine, 0, len(d.Lines)),
+		Reference:  d.Reference,
+		Status:     domain.ReservationStatus(d.Status),
+		CreatedAt:  d.CreatedAt,
+		ReleasedAt: d.ReleasedAt,
+	}
+	for _, l := range d.Lines {
+		r.Lines = append(r.Lines, domain.ReservationLine{ProductID: l.ProductID, Quantity: l.Quantity, Released: l.Released})
+	}
+	return r
+}
+
+func NewMongoReservationRepository(db *mongo.Database, timeout time.Duration) ReservationRepository {
+	return &mongoReservationRepo{coll: db.Collection("reservations"), timeout: timeout}
+}
+
+func (r *mongoReservationRepo) Create(ctx context.Context, res *domain.Reservation) (string, error) {
+	ctx, span := startSpan(ctx, r.coll, "insertOne", attribute.String("bundle.id", res.BundleID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	inserted, err := r.coll.InsertOne(ctx, newReservationDocument(res))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	oid := inserted.InsertedID.(primitive.ObjectID).Hex()
+	span.SetAttributes(attribute.String("reservation.id", oid))
+	return oid, nil
+}
+
+func (r *mongoReservationRepo) GetByID(ctx context.Context, id string) (*domain.Reservation, error) {
+	ctx, span := startSpan(ctx, r.coll, "findOne", attribute.String("reservation.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	var doc reservationDocument
+	if err := r.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc); err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoReservationRepo) List(ctx context.Context, status domain.ReservationStatus, productID string) ([]*domain.Reservation, error) {
+	ctx, span := startSpan(ctx, r.coll, "find", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = string(status)
+	}
+	if productID != "" {
+		filter["lines.product_id"] = productID
+	}
+	cur, err := r.coll.Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	var docs []reservationDocument
+	if err := cur.All(ctx, &docs); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	reservations := make([]*domain.Reservation, 0, len(docs))
+	for i := range docs {
+		reservations = append(reservations, docs[i].toDomain())
+	}
+	return reservations, nil
+}
+
+func (r *mongoReservationRepo) Delete(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, r.coll, "deleteOne", attribute.String("reservation.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	_, err := r.coll.DeleteOne(ctx, bson.M{"_id": oid})
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *mongoReservationRepo) SetLines(ctx context.Context, id string, lines []domain.ReservationLine) error {
+	ctx, span := startSpan(ctx, r.coll, "updateOne", attribute.String("reservation.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	res, err := r.coll.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"lines": toReservationLineDocuments(lines)}})
+	if err == nil && res.MatchedCount == 0 {
+		err = domain.ErrNotFound
+	}
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *mongoReservationRepo) Release(ctx context.Context, id string, at time.Time) (bool, error) {
+	ctx, span := startSpan(ctx, r.coll, "updateOne", attribute.String("reservation.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	res, err := r.coll.UpdateOne(ctx,
+		bson.M{"_id": oid, "status": string(domain.ReservationActive)},
+		bson.M{"$set": bson.M{"status": string(domain.ReservationReleased), "released_at": at}})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+func (r *mongoReservationRepo) SetLineReleased(ctx context.Context, id, productID string, released bool) (bool, error) {
+	ctx, span := startSpan(ctx, r.coll, "updateOne", attribute.String("reservation.id", id),
+		attribute.String("product.id", productID), attribute.Bool("reservation.line.released", released))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	filter := bson.M{"_id": oid, "lines": bson.M{"$elemMatch": bson.M{
+		"product_id": productID,
+		"released":   bson.M{"$ne": released},
+	}}}
+	update := bson.M{"$set": bson.M{"lines.$.released": released}}
+	if released {
+		filter["status"] = string(domain.ReservationActive)
+	} else {
+		update = bson.M{
+			"$set":   bson.M{"lines.$.released": false, "status": string(domain.ReservationActive)},
+			"$unset": bson.M{"released_at": ""},
+		}
+	}
+	res, err := r.coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type mongoReturnRepo struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+type returnDocument struct {
+	ID          primitive.ObjectID   `bson:"_id,omitempty"`
+	OrderID     string               `bson:"order_id"`
+	Reason      string               `bson:"reason,omitempty"`
+	Lines       []returnLineDocument `bson:"lines"`
+	Inspections []inspectionDocument `bson:"inspections,omitempty"`
+	Status      string               `bson:"status"`
+	CreatedAt   time.Time            `bson:"created_at"`
+	CreatedBy   string               `bson:"created_by,omitempty"`
+	UpdatedAt   time.Time            `bson:"updated_at"`
+	Version     int64                `bson:"version"`
+}
+
+type returnLineDocument struct {
+	ProductID    string `bson:"product_id" json:"product_id"`
+	Quantity     int32  `bson:"quantity" json:"quantity"`
+	Restocked    int32  `bson:"restocked" json:"restocked"`
+	Damaged      int32  `bson:"damaged" json:"damaged"`
+	VendorReturn int32  `bson:"vendor_return" json:"vendor_return"`
+}
+
+type inspectionDocument struct {
+	ProductID   string `bson:"product_id" json:"product_id"`
+	Quantity    int32  `bson:"quantity" json:"quantity"`
+	Disposition string `bson:"disposition" json:"disposition"`
+	Booked      bool   `bson:"booked" json:"booked"`
+}
+
+func newReturnDocument(r *domain.Return) returnDocument {
+	doc := returnDocument{
+		OrderID:   r.OrderID,
+		Reason:    r.Reason,
+		Lines:     make([]returnLineDocument, 0, len(r.Lines)),
+		Status:    string(r.Status),
+		CreatedAt: r.CreatedAt,
+		CreatedBy: r.CreatedBy,
+		UpdatedAt: r.UpdatedAt,
+		Version:   r.Version,
+	}
+	for _, l := range r.Lines {
+		doc.Lines = append(doc.Lines, returnLineDocument{
+			ProductID:    l.ProductID,
+			Quantity:     l.Quantity,
+			Restocked:    l.Restocked,
+			Damaged:      l.Damaged,
+			VendorReturn: l.VendorReturn,
+		})
+	}
+	for _, in := range r.Inspections {
+		doc.Inspections = append(doc.Inspections, inspectionDocument{
+			ProductID:   in.ProductID,
+			Quantity:    in.Quantity,
+			Disposition: string(in.Disposition),
+			Booked:      in.Booked,
+		})
+	}
+	return doc
+}
+
+func (d *returnDocument) toDomain() *domain.Return {
+	r := &domain.Return{
+		ID:        d.ID.Hex(),
+		OrderID:   d.OrderID,
+		Reason:    d.Reason,
+		Lines:     make([]domain.ReturnLine, 0, len(d.Lines)),
+		Status:    domain.ReturnStatus(d.Status),
+		CreatedAt: d.CreatedAt,
+		CreatedBy: d.CreatedBy,
+		UpdatedAt: d.UpdatedAt,
+		Version:   d.Version,
+	}
+	for _, l := range d.Lines {
+		r.Lines = append(r.Lines, domain.ReturnLine{
+			ProductID:    l.ProductID,
+			Quantity:     l.Quantity,
+			Restocked:    l.Restocked,
+			Damaged:      l.Damaged,
+			VendorReturn: l.VendorReturn,
+		})
+	}
+	for _, in := range d.Inspections {
+		r.Inspections = append(r.Inspections, domain.Inspection{
+			ProductID:   in.ProductID,
+			Quantity:    in.Quantity,
+			Disposition: domain.Disposition(in.Disposition),
+			Booked:      in.Booked,
+		})
+	}
+	return r
+}
+
+func NewMongoReturnRepository(db *mongo.Database, timeout time.Duration) ReturnRepository {
+	return &mongoReturnRepo{coll: db.Collection("returns"), timeout: timeout}
+}
+
+func (r *mongoReturnRepo) Create(ctx context.Context, ret *domain.Return) (string, error) {
+	ctx, span := startSpan(ctx, r.coll, "insertOne", attribute.String("order.id", ret.OrderID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.coll.InsertOne(ctx, newReturnDocument(ret))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	oid := res.InsertedID.(primitive.ObjectID).Hex()
+	span.SetAttributes(attribute.String("return.id", oid))
+	return oid, nil
+}
+
+func (r *mongoReturnRepo) GetByID(ctx context.Context, id string) (*domain.Return, error) {
+	ctx, span := startSpan(ctx, r.coll, "findOne", attribute.String("return.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	var doc returnDocument
+	if err := r.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc); err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoReturnRepo) ListByOrder(ctx context.Context, orderID string) ([]*domain.Return, error) {
+	ctx, span := startSpan(ctx, r.coll, "find", attribute.String("order.id", orderID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cur, err := r.coll.Find(ctx, bson.M{"order_id": orderID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	var docs []returnDocument
+	if err := cur.All(ctx, &docs); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	returns := make([]*domain.Return, 0, len(docs))
+	for i := range docs {
+		returns = append(returns, docs[i].toDomain())
+	}
+	return returns, nil
+}
+
+func (r *mongoReturnRepo) Update(ctx context.Context, ret *domain.Return) error {
+	ctx, span := startSpan(ctx, r.coll, "replaceOne", attribute.String("return.id", ret.ID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(ret.ID)
+	doc := newReturnDocument(ret)
+	doc.ID = oid
+	doc.Version = ret.Version + 1
+	res, err := r.coll.ReplaceOne(ctx, bson.M{"_id": oid, "version": ret.Version}, doc)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		tracing.RecordError(span, domain.ErrConflict)
+		return domain.ErrConflict
+	}
+	ret.Version = doc.Version
+	return nil
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type mongoScheduledPriceRepo struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+type scheduledPriceDocument struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	ProductID      string             `bson:"product_id"`
+	Price          float64            `bson:"price"`
+	EffectiveFrom  time.Time          `bson:"effective_from"`
+	EffectiveTo    *time.Time         `bson:"effective_to,omitempty"`
+	Status         string             `bson:"status"`
+	PreviousPrice  float64            `bson:"previous_price"`
+	HistoryPending bool               `bson:"history_pending,omitempty"`
+	CreatedAt      time.Time          `bson:"created_at"`
+	CreatedBy      string             `bson:"created_by,omitempty"`
+}
+
+func newScheduledPriceDocument(s *domain.ScheduledPrice) scheduledPriceDocument {
+	doc := scheduledPriceDocument{
+		ProductID:      s.ProductID,
+		Price:          s.Price,
+		EffectiveFrom:  s.EffectiveFrom,
+		Status:         string(s.Status),
+		PreviousPrice:  s.PreviousPrice,
+		HistoryPending: s.HistoryPending,
+		CreatedAt:      s.CreatedAt,
+		CreatedBy:      s.CreatedBy,
+	}
+	if !s.EffectiveTo.IsZero() {
+		doc.EffectiveTo = &s.EffectiveTo
+	}
+	return doc
+}
+
+func (d *scheduledPriceDocument) toDomain() *domain.ScheduledPrice {
+	s := &domain.ScheduledPrice{
+		ID:             d.ID.Hex(),
+		ProductID:      d.ProductID,
+		Price:          d.Price,
+		EffectiveFrom:  d.EffectiveFrom,
+		Status:         domain.ScheduledPriceStatus(d.Status),
+		PreviousPrice:  d.PreviousPrice,
+		HistoryPending: d.HistoryPending,
+		CreatedAt:      d.CreatedAt,
+		CreatedBy:      d.CreatedBy,
+	}
+	if d.EffectiveTo != nil {
+		s.EffectiveTo = *d.EffectiveTo
+	}
+	return s
+}
+
+func NewMongoScheduledPriceRepository(db *mongo.Database, timeout time.Duration) ScheduledPriceRepository {
+	return &mongoScheduledPriceRepo{coll: db.Collection("scheduled_prices"), timeout: timeout}
+}
+
+func (r *mongoScheduledPriceRepo) Create(ctx context.Context, s *domain.ScheduledPrice) (string, error) {
+	ctx, span := startSpan(ctx, r.coll, "insertOne", attribute.String("product.id", s.ProductID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.coll.InsertOne(ctx, newScheduledPriceDocument(s))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+func (r *mongoScheduledPriceRepo) GetByID(ctx context.Context, id string) (*domain.ScheduledPrice, error) {
+	ctx, span := startSpan(ctx, r.coll, "findOne", attribute.String("scheduled_price.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	var doc scheduledPriceDocument
+	if err := r.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc); err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoScheduledPriceRepo) ListByProduct(ctx context.Context, productID string, statuses ...domain.ScheduledPriceStatus) ([]*domain.ScheduledPrice, error) {
+	ctx, span := startSpan(ctx, r.coll, "find", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"product_id": productID}
+	if len(statuses) > 0 {
+		in := make(bson.A, 0, len(statuses))
+		for _, s := range statuses {
+			in = append(in, string(s))
+		}
+		filter["status"] = bson.M{"$in": in}
+	}
+	changes, err := r.find(ctx, filter)
+	tracing.RecordError(span, err)
+	return changes, err
+}
+
+func (r *mongoScheduledPriceRepo) ListDue(ctx context.Context, now time.Time) ([]*domain.ScheduledPrice, error) {
+	ctx, span := startSpan(ctx, r.coll, "find")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	changes, err := r.find(ctx, bson.M{"$or": bson.A{
+		bson.M{"status": string(domain.ScheduledPricePending), "effective_from": bson.M{"$lte": now}},
+		bson.M{"status": string(domain.ScheduledPriceActive), "effective_to": bson.M{"$lte": now}},
+		bson.M{"history_pending": true},
+	}})
+	tracing.RecordError(span, err)
+	return changes, err
+}
+
+func (r *mongoScheduledPriceRepo) Transition(ctx context.Context, id string, from, to domain.ScheduledPriceStatus, previousPrice float64, historyPending bool) (bool, error) {
+	ctx, span := startSpan(ctx, r.coll, "updateOne", attribute.String("scheduled_price.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	res, err := r.coll.UpdateOne(ctx,
+		bson.M{"_id": oid, "status": string(from)},
+		bson.M{"$set": bson.M{"status": string(to), "previous_price": previousPrice, "history_pending": historyPending}})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+func (r *mongoScheduledPriceRepo) ClearHistoryPending(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, r.coll, "updateOne", attribute.String("scheduled_price.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	_, err := r.coll.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"history_pending": false}})
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *mongoScheduledPriceRepo) find(ctx context.Context, filter interface{}) ([]*domain.ScheduledPrice, error) {
+	cur, err := r.coll.Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "effective_from", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var docs []scheduledPriceDocument
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	changes := make([]*domain.ScheduledPrice, 0, len(docs))
+	for i := range docs {
+		changes = append(changes, docs[i].toDomain())
+	}
+	return changes, nil
+}
@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type mongoSerialRepo struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+type serialDocument struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	ProductID  string             `bson:"product_id"`
+	Serial     string             `bson:"serial"`
+	Status     string             `bson:"status"`
+	Location   string             `bson:"location,omitempty"`
+	OrderID    string             `bson:"order_id,omitempty"`
+	Booked     bool               `bson:"booked"`
+	ReceivedAt time.Time          `bson:"received_at"`
+	UpdatedAt  time.Time          `bson:"updated_at"`
+}
+
+func newSerialDocument(u *domain.SerialUnit) serialDocument {
+	return serialDocument{
+		ProductID:  u.ProductID,
+		Serial:     u.Serial,
+		Status:     string(u.Status),
+		Location:   u.Location,
+		OrderID:    u.OrderID,
+		Booked:     u.Booked,
+		ReceivedAt: u.ReceivedAt,
+		UpdatedAt:  u.UpdatedAt,
+	}
+}
+
+func (d *serialDocument) toDomain() *domain.SerialUnit {
+	return &domain.SerialUnit{
+		ID:         d.ID.Hex(),
+		ProductID:  d.ProductID,
+		Serial:     d.Serial,
+		Status:     domain.SerialStatus(d.Status),
+		Location:   d.Location,
+		OrderID:    d.OrderID,
+		Booked:     d.Booked,
+		ReceivedAt: d.ReceivedAt,
+		UpdatedAt:  d.UpdatedAt,
+	}
+}
+
+func NewMongoSerialRepository(db *mongo.Database, timeout time.Duration) SerialRepository {
+	return &mongoSerialRepo{coll: db.Collection("serial_units"), timeout: timeout}
+}
+
+func (r *mongoSerialRepo) CreateMany(ctx context.Context, units []*domain.SerialUnit) error {
+	ctx, span := startSpan(ctx, r.coll, "insertMany", attribute.Int("serial.count", len(units)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	docs := make([]interface{}, 0, len(units))
+	for _, u := range units {
+		doc := newSerialDocument(u)
+		doc.ID = primitive.NewObjectID()
+		u.ID = doc.ID.Hex()
+		docs = append(docs, doc)
+	}
+	_, err := r.coll.InsertMany(ctx, docs)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *mongoSerialRepo) FindExisting(ctx context.Context, serials []string) ([]string, error) {
+	ctx, span := startSpan(ctx, r.coll, "distinct")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	values, err := r.coll.Distinct(ctx, "serial", bson.M{"serial": bson.M{"$in": serials}})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	existing := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			existing = append(existing, s)
+		}
+	}
+	return existing, nil
+}
+
+func (r *mongoSerialRepo) GetBySerial(ctx context.Context, serial string) (*domain.SerialUnit, error) {
+	ctx, span := startSpan(ctx, r.coll, "findOne", attribute.String("serial", serial))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var doc serialDocument
+	if err := r.coll.FindOne(ctx, bson.M{"serial": serial}).Decode(&doc); err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoSerialRepo) ListByProduct(ctx context.Context, productID string, status domain.SerialStatus, limit int64) ([]*domain.SerialUnit, error) {
+	ctx, span := startSpan(ctx, r.coll, "find", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"product_id": productID}
+	if status != "" {
+		filter["status"] = string(status)
+	}
+	units, err := r.find(ctx, filter, limit)
+	tracing.RecordError(span, err)
+	return units, err
+}
+
+func (r *mongoSerialRepo) ListAllocatable(ctx context.Context, productID string, limit int64) ([]*domain.SerialUnit, error) {
+	ctx, span := startSpan(ctx, r.coll, "find", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	units, err := r.find(ctx, bson.M{
+		"product_id": productID,
+		"status":     string(domain.SerialAvailable),
+		"booked":     true,
+	}, limit)
+	tracing.RecordError(span, err)
+	return units, err
+}
+
+func (r *mongoSerialRepo) find(ctx context.Context, filter interface{}, limit int64) ([]*domain.SerialUnit, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "received_at", Value: 1}, {Key: "_id", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+	cur, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var docs []serialDocument
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	units := make([]*domain.SerialUnit, 0, len(docs))
+	for i := range docs {
+		units = append(units, docs[i].toDomain())
+	}
+	return units, nil
+}
+
+func (r *mongoSerialRepo) Transition(ctx context.Context, u *domain.SerialUnit, from domain.SerialStatus) (bool, error) {
+	ctx, span := startSpan(ctx, r.coll, "updateOne", attribute.String("serial", u.Serial))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(u.ID)
+	res, err := r.coll.UpdateOne(ctx,
+		bson.M{"_id": oid, "status": string(from), "booked": true},
+		bson.M{"$set": bson.M{
+			"status":     string(u.Status),
+			"location":   u.Location,
+			"order_id":   u.OrderID,
+			"updated_at": u.UpdatedAt,
+		}})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+func (r *mongoSerialRepo) SetBooked(ctx context.Context, serial string, booked bool) (bool, error) {
+	ctx, span := startSpan(ctx, r.coll, "updateOne", attribute.String("serial", serial), attribute.Bool("serial.booked", booked))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.coll.UpdateOne(ctx,
+		bson.M{"serial": serial, "booked": !booked},
+		bson.M{"$set": bson.M{"booked": booked}})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+func (r *mongoSerialRepo) CountByStatus(ctx context.Context, productID string) (map[domain.SerialStatus]int64, error) {
+	ctx, span := startSpan(ctx, r.coll, "aggregate", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cur, err := r.coll.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"product_id": productID}}},
+		{{Key: "$group", Value: bson.M{"_id": "$status", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	var rows []struct {
+		Status string `bson:"_id"`
+		Count  int64  `bson:"count"`
+	}
+	if err := cur.All(ctx, &rows); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	counts := make(map[domain.SerialStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[domain.SerialStatus(row.Status)] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *mongoSerialRepo) CountAvailableByLocation(ctx context.Context, productIDs []string) (map[string]map[string]int32, error) {
+	ctx, span := startSpan(ctx, r.coll, "aggregate", attribute.Int("product.count", len(productIDs)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cur, err := r.coll.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"product_id": bson.M{"$in": productIDs},
+			"status":     string(domain.SerialAvailable),
+			"booked":     true,
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"product_id": "$product_id", "location": "$location"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	var rows []struct {
+		Key struct {
+			ProductID string `bson:"product_id"`
+			Location  string `bson:"location"`
+		} `bson:"_id"`
+		Count int32 `bson:"count"`
+	}
+	if err := cur.All(ctx, &rows); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	counts := make(map[string]map[string]int32)
+	for _, row := range rows {
+		if counts[row.Key.ProductID] == nil {
+			counts[row.Key.ProductID] = make(map[string]int32)
+		}
+		counts[row.Key.ProductID][row.Key.Location] += row.Count
+	}
+	return counts, nil
+}
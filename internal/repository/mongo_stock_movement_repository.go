@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type mongoStockMovementRepo struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+type stockMovementDocument struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	ProductID string             `bson:"product_id"`
+	VariantID string             `bson:"variant_id,omitempty"`
+	Quantity  int32              `bson:"quantity"`
+	Reason    string             `bson:"reason"`
+	Bucket    string             `bson:"bucket,omitempty"`
+	UnitCost  float64            `bson:"unit_cost,omitempty"`
+	Reference string             `bson:"reference,omitempty"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+func newStockMovementDocument(m *domain.StockMovement) stockMovementDocument {
+	return stockMovementDocument{
+		ProductID: m.ProductID,
+		VariantID: m.VariantID,
+		Quantity:  m.Quantity,
+		Reason:    string(m.Reason),
+		Bucket:    string(m.Bucket),
+		UnitCost:  m.UnitCost,
+		Reference: m.Reference,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func (d *stockMovementDocument) toDomain() *domain.StockMovement {
+	return &domain.StockMovement{
+		ID:        d.ID.Hex(),
+		ProductID: d.ProductID,
+		VariantID: d.VariantID,
+		Quantity:  d.Quantity,
+		Reason:    domain.MovementReason(d.Reason),
+		Bucket:    domain.StockBucket(d.Bucket),
+		UnitCost:  d.UnitCost,
+		Reference: d.Reference,
+		CreatedAt: d.CreatedAt,
+	}
+}
+
+func NewMongoStockMovementRepository(db *mongo.Database, timeout time.Duration) StockMovementRepository {
+	return &mongoStockMovementRepo{coll: db.Collection("stock_movements"), timeout: timeout}
+}
+
+func (r *mongoStockMovementRepo) Record(ctx context.Context, m *domain.StockMovement) error {
+	ctx, span := startSpan(ctx, r.coll, "insertOne", attribute.String("product.id", m.ProductID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now().UTC()
+	}
+	res, err := r.coll.InsertOne(ctx, newStockMovementDocument(m))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	m.ID = res.InsertedID.(primitive.ObjectID).Hex()
+	return nil
+}
+
+func (r *mongoStockMovementRepo) ListByProducts(ctx context.Context, productIDs []string) ([]*domain.StockMovement, error) {
+	ctx, span := startSpan(ctx, r.coll, "find", attribute.Int("product.count", len(productIDs)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	movements, err := r.find(ctx, bson.M{"product_id": bson.M{"$in": productIDs}})
+	tracing.RecordError(span, err)
+	return movements, err
+}
+
+func (r *mongoStockMovementRepo) ListByReasons(ctx context.Context, productIDs []string, reasons []domain.MovementReason, since time.Time) ([]*domain.StockMovement, error) {
+	ctx, span := startSpan(ctx, r.coll, "find", attribute.Int("product.count", len(productIDs)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	values := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		values = append(values, string(reason))
+	}
+	filter := bson.M{"reason": bson.M{"$in": values}, "created_at": bson.M{"$gte": since}}
+	if len(productIDs) > 0 {
+		filter["product_id"] = bson.M{"$in": productIDs}
+	}
+	movements, err := r.find(ctx, filter)
+	tracing.RecordError(span, err)
+	return movements, err
+}
+
+// find returns the movements matching filter, oldest first.
+func (r *mongoStockMovementRepo) find(ctx context.Context, filter bson.M) ([]*domain.StockMovement, error) {
+	cur, err := r.coll.Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var docs []stockMovementDocument
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	movements := make([]*domain.StockMovement, 0, len(docs))
+	for i := range docs {
+		movements = append(movements, docs[i].toDomain())
+	}
+	return movements, nil
+}
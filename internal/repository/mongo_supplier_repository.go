@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type mongoSupplierRepo struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+type supplierDocument struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	Name         string             `bson:"name"`
+	ContactEmail string             `bson:"contact_email,omitempty"`
+	Phone        string             `bson:"phone,omitempty"`
+	CreatedAt    time.Time          `bson:"created_at"`
+}
+
+func newSupplierDocument(s *domain.Supplier) supplierDocument {
+	return supplierDocument{
+		Name:         s.Name,
+		ContactEmail: s.ContactEmail,
+		Phone:        s.Phone,
+		CreatedAt:    s.CreatedAt,
+	}
+}
+
+func (d *supplierDocument) toDomain() *domain.Supplier {
+	return &domain.Supplier{
+		ID:           d.ID.Hex(),
+		Name:         d.Name,
+		ContactEmail: d.ContactEmail,
+		Phone:        d.Phone,
+		CreatedAt:    d.CreatedAt,
+	}
+}
+
+func NewMongoSupplierRepository(db *mongo.Database, timeout time.Duration) SupplierRepository {
+	return &mongoSupplierRepo{coll: db.Collection("suppliers"), timeout: timeout}
+}
+
+func (r *mongoSupplierRepo) Create(ctx context.Context, s *domain.Supplier) (string, error) {
+	ctx, span := startSpan(ctx, r.coll, "insertOne")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.coll.InsertOne(ctx, newSupplierDocument(s))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	oid := res.InsertedID.(primitive.ObjectID).Hex()
+	span.SetAttributes(attribute.String("supplier.id", oid))
+	return oid, nil
+}
+
+func (r *mongoSupplierRepo) GetByID(ctx context.Context, id string) (*domain.Supplier, error) {
+	ctx, span := startSpan(ctx, r.coll, "findOne", attribute.String("supplier.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	var doc supplierDocument
+	if err := r.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc); err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoSupplierRepo) List(ctx context.Context) ([]*domain.Supplier, error) {
+	ctx, span := startSpan(ctx, r.coll, "find")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cur, err := r.coll.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	var docs []supplierDocument
+	if err := cur.All(ctx, &docs); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	suppliers := make([]*domain.Supplier, 0, len(docs))
+	for i := range docs {
+		suppliers = append(suppliers, docs[i].toDomain())
+	}
+	return suppliers, nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type mongoVariantRepo struct {
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+type variantDocument struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	ProductID     string             `bson:"product_id"`
+	SKU           string             `bson:"sku"`
+	Attributes    map[string]string  `bson:"attributes"`
+	PriceOverride *float64           `bson:"price_override,omitempty"`
+	Stock         int32              `bson:"stock"`
+	CreatedAt     time.Time          `bson:"created_at"`
+}
+
+func newVariantDocument(v *domain.Variant) variantDocument {
+	return variantDocument{
+		ProductID:     v.ProductID,
+		SKU:           v.SKU,
+		Attributes:    v.Attributes,
+		PriceOverride: v.PriceOverride,
+		Stock:         v.Stock,
+		CreatedAt:     v.CreatedAt,
+	}
+}
+
+func (d *variantDocument) toDomain() *domain.Variant {
+	return &domain.Variant{
+		ID:            d.ID.Hex(),
+		ProductID:     d.ProductID,
+		SKU:           d.SKU,
+		Attributes:    d.Attributes,
+		PriceOverride: d.PriceOverride,
+		Stock:         d.Stock,
+		CreatedAt:     d.CreatedAt,
+	}
+}
+
+func NewMongoVariantRepository(db *mongo.Database, timeout time.Duration) VariantRepository {
+	return &mongoVariantRepo{coll: db.Collection("product_variants"), timeout: timeout}
+}
+
+func (r *mongoVariantRepo) Create(ctx context.Context, v *domain.Variant) (string, error) {
+	ctx, span := startSpan(ctx, r.coll, "insertOne", attribute.String("product.id", v.ProductID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.coll.InsertOne(ctx, newVariantDocument(v))
+	if err != nil {
+		err = mapDuplicateSKU(err, v.SKU)
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	oid := res.InsertedID.(primitive.ObjectID).Hex()
+	span.SetAttributes(attribute.String("variant.id", oid))
+	return oid, nil
+}
+
+func (r *mongoVariantRepo) GetByID(ctx context.Context, id string) (*domain.Variant, error) {
+	ctx, span := startSpan(ctx, r.coll, "findOne", attribute.String("variant.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	var doc variantDocument
+	if err := r.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc); err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoVariantRepo) ListByProduct(ctx context.Context, productID string) ([]*domain.Variant, error) {
+	ctx, span := startSpan(ctx, r.coll, "find", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cur, err := r.coll.Find(ctx, bson.M{"product_id": productID}, options.Find().SetSort(bson.D{{Key: "sku", Value: 1}}))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	var docs []variantDocument
+	if err := cur.All(ctx, &docs); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	variants := make([]*domain.Variant, 0, len(docs))
+	for i := range docs {
+		variants = append(variants, docs[i].toDomain())
+	}
+	return variants, nil
+}
+
+func (r *mongoVariantRepo) GetBySKU(ctx context.Context, sku string) (*domain.Variant, error) {
+	ctx, span := startSpan(ctx, r.coll, "findOne", attribute.String("variant.sku", sku))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var doc variantDocument
+	if err := r.coll.FindOne(ctx, bson.M{"sku": sku}).Decode(&doc); err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+func (r *mongoVariantRepo) AdjustStock(ctx context.Context, id string, delta int32) (*domain.Variant, error) {
+	ctx, span := startSpan(ctx, r.coll, "findOneAndUpdate",
+		attribute.String("variant.id", id), attribute.Int("stock.delta", int(delta)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	oid, _ := primitive.ObjectIDFromHex(id)
+	filter := bson.M{"_id": oid}
+	if delta < 0 {
+		filter["stock"] = bson.M{"$gte": -delta}
+	}
+	var doc variantDocument
+	err := r.coll.FindOneAndUpdate(ctx, filter,
+		bson.M{"$inc": bson.M{"stock": delta}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) && delta < 0 {
+		// Tell a missing variant apart from one without enough stock.
+		if n, cerr := r.coll.CountDocuments(ctx, bson.M{"_id": oid}); cerr == nil && n > 0 {
+			err = domain.ErrInsufficientStock
+		}
+	}
+	if err != nil {
+		err = mapNotFound(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The PostgreSQL repositories other than the product one keep each MongoDB
+// collection in a table of the same name, created by
+// migrate.PostgresMigrations. Rows are scanned into the documents the
+// MongoDB repositories store, so the conversions to and from the domain are
+// shared, and ids are ObjectIDs generated here, so they look alike whichever
+// store is used and sort in creation order. Nested lists are jsonb columns.
+// Changes that depend on the stored row are conditional UPDATEs, or a
+// SELECT ... FOR UPDATE in a transaction where the row has to be read first.
+
+func postgresStartSpan(ctx context.Context, table, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		semconv.DBSystemPostgreSQL,
+		semconv.DBCollectionName(table),
+		semconv.DBOperationName(op),
+	)
+	return tracer.Start(ctx, "postgres."+table+"."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+}
+
+func mapNoRows(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+// isUniqueViolation reports whether PostgreSQL refused a row because a
+// unique index already holds its key.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// queryRows runs a query and scans every row it returns.
+func queryRows[T any](ctx context.Context, pool *pgxpool.Pool, scan func(pgx.Row) (*T, error), sql string, args ...any) ([]*T, error) {
+	rows, err := pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := make([]*T, 0)
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// objectID reads back an id generated with primitive.NewObjectID.
+func objectID(hex string) primitive.ObjectID {
+	oid, _ := primitive.ObjectIDFromHex(hex)
+	return oid
+}
+
+// nullTime stores a zero time as NULL.
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// utcTime reads back a nullable timestamptz, NULL as the zero time.
+func utcTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.UTC()
+}
+
+// utcTimePtr reads back a nullable timestamptz kept as a pointer.
+func utcTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const auditEventColumns = `id, product_id, action, actor, actor_verified, peer, rpc, request_id, occurred_at, changes`
+
+type postgresAuditRepo struct {
+	pool    *pgxpool.Pool
+	timeout time.Duration
+}
+
+func NewPostgresAuditRepository(pool *pgxpool.Pool, timeout time.Duration) AuditRepository {
+	return &postgresAuditRepo{pool: pool, timeout: timeout}
+}
+
+func scanAuditEvent(row pgx.Row) (*domain.AuditEvent, error) {
+	var (
+		d  auditEventDocument
+		id string
+	)
+	err := row.Scan(&id, &d.ProductID, &d.Action, &d.Actor, &d.Verified, &d.Peer, &d.RPC, &d.RequestID,
+		&d.OccurredAt, &d.Changes)
+	if err != nil {
+		return nil, err
+	}
+	d.ID, d.OccurredAt = objectID(id), d.OccurredAt.UTC()
+	return d.toDomain(), nil
+}
+
+func (r *postgresAuditRepo) Record(ctx context.Context, e *domain.AuditEvent) error {
+	ctx, span := postgresStartSpan(ctx, "audit_events", "INSERT",
+		attribute.String("product.id", e.ProductID), attribute.String("audit.action", string(e.Action)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	d := newAuditEventDocument(e)
+	d.ID = primitive.NewObjectID()
+	_, err := r.pool.Exec(ctx, `
+INSERT INTO audit_events (`+auditEventColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		d.ID.Hex(), d.ProductID, d.Action, d.Actor, d.Verified, d.Peer, d.RPC, d.RequestID, d.OccurredAt, d.Changes)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	e.ID = d.ID.Hex()
+	return nil
+}
+
+func (r *postgresAuditRepo) List(ctx context.Context, f domain.AuditFilter) ([]*domain.AuditEvent, error) {
+	ctx, span := postgresStartSpan(ctx, "audit_events", "SELECT")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var (
+		conds = []string{"true"}
+		args  []any
+	)
+	add := func(cond string, v any) {
+		args = append(args, v)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if f.ProductID != "" {
+		add("product_id = $%d", f.ProductID)
+	}
+	if f.Actor != "" {
+		add("actor = $%d", f.Actor)
+	}
+	if !f.From.IsZero() {
+		add("occurred_at >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		add("occurred_at < $%d", f.To)
+	}
+	limit := "ALL"
+	if f.Limit > 0 {
+		limit = fmt.Sprint(f.Limit)
+	}
+	events, err := queryRows(ctx, r.pool, scanAuditEvent, `
+SELECT `+auditEventColumns+` FROM audit_events
+WHERE `+strings.Join(conds, " AND ")+`
+ORDER BY occurred_at DESC, id DESC
+LIMIT `+limit, args...)
+	tracing.RecordError(span, err)
+	return events, err
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const bundleColumns = `id, name, sku, components, created_at`
+
+type postgresBundleRepo struct {
+	pool    *pgxpool.Pool
+	timeout time.Duration
+}
+
+func NewPostgresBundleRepository(pool *pgxpool.Pool, timeout time.Duration) BundleRepository {
+	return &postgresBundleRepo{pool: pool, timeout: timeout}
+}
+
+func scanBundle(row pgx.Row) (*domain.Bundle, error) {
+	var (
+		d  bundleDocument
+		id string
+	)
+	if err := row.Scan(&id, &d.Name, &d.SKU, &d.Components, &d.CreatedAt); err != nil {
+		return nil, err
+	}
+	d.ID, d.CreatedAt = objectID(id), d.CreatedAt.UTC()
+	return d.toDomain(), nil
+}
+
+func (r *postgresBundleRepo) Create(ctx context.Context, b *domain.Bundle) (string, error) {
+	ctx, span := postgresStartSpan(ctx, "bundles", "INSERT")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	d := newBundleDocument(b)
+	d.ID = primitive.NewObjectID()
+	_, err := r.pool.Exec(ctx, `INSERT INTO bundles (`+bundleColumns+`) VALUES ($1, $2, $3, $4, $5)`,
+		d.ID.Hex(), d.Name, d.SKU, d.Components, d.CreatedAt)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("bundle.id", d.ID.Hex()))
+	return d.ID.Hex(), nil
+}
+
+func (r *postgresBundleRepo) GetByID(ctx context.Context, id string) (*domain.Bundle, error) {
+	ctx, span := postgresStartSpan(ctx, "bundles", "SELECT", attribute.String("bundle.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	b, err := scanBundle(r.pool.QueryRow(ctx, `SELECT `+bundleColumns+` FROM bundles WHERE id = $1`, id))
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return b, nil
+}
+
+func (r *postgresBundleRepo) ListByComponent(ctx context.Context, productID string) ([]*domain.Bundle, error) {
+	ctx, span := postgresStartSpan(ctx, "bundles", "SELECT", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	bundles, err := queryRows(ctx, r.pool, scanBundle, `
+SELECT `+bundleColumns+` FROM bundles
+WHERE components @> jsonb_build_array(jsonb_build_object('product_id', $1::text))
+ORDER BY id`, productID)
+	tracing.RecordError(span, err)
+	return bundles, err
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const cycleCountColumns = `id, status, lines, created_at, created_by, updated_at, approved_at, approved_by, version`
+
+type postgresCycleCountRepo struct {
+	pool    *pgxpool.Pool
+	timeout time.Duration
+}
+
+func NewPostgresCycleCountRepository(pool *pgxpool.Pool, timeout time.Duration) CycleCountRepository {
+	return &postgresCycleCountRepo{pool: pool, timeout: timeout}
+}
+
+func scanCycleCount(row pgx.Row) (*domain.CycleCount, error) {
+	var (
+		d          cycleCountDocument
+		id         string
+		approvedAt *time.Time
+	)
+	err := row.Scan(&id, &d.Status, &d.Lines, &d.CreatedAt, &d.CreatedBy, &d.UpdatedAt, &approvedAt,
+		&d.ApprovedBy, &d.Version)
+	if err != nil {
+		return nil, err
+	}
+	d.ID, d.ApprovedAt = objectID(id), utcTime(approvedAt)
+	d.CreatedAt, d.UpdatedAt = d.CreatedAt.UTC(), d.UpdatedAt.UTC()
+	return d.toDomain(), nil
+}
+
+func (r *postgresCycleCountRepo) Create(ctx context.Context, c *domain.CycleCount) (string, error) {
+	ctx, span := postgresStartSpan(ctx, "cycle_counts", "INSERT")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	d := newCycleCountDocument(c)
+	d.ID = primitive.NewObjectID()
+	_, err := r.pool.Exec(ctx, `
+INSERT INTO cycle_counts (`+cycleCountColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		d.ID.Hex(), d.Status, d.Lines, d.CreatedAt, d.CreatedBy, d.UpdatedAt, nullTime(d.ApprovedAt),
+		d.ApprovedBy, d.Version)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("cycle_count.id", d.ID.Hex()))
+	return d.ID.Hex(), nil
+}
+
+func (r *postgresCycleCountRepo) GetByID(ctx context.Context, id string) (*domain.CycleCount, error) {
+	ctx, span := postgresStartSpan(ctx, "cycle_counts", "SELECT", attribute.String("cycle_count.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	c, err := scanCycleCount(r.pool.QueryRow(ctx, `SELECT `+cycleCountColumns+` FROM cycle_counts WHERE id = $1`, id))
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *postgresCycleCountRepo) Update(ctx context.Context, c *domain.CycleCount) error {
+	ctx, span := postgresStartSpan(ctx, "cycle_counts", "UPDATE", attribute.String("cycle_count.id", c.ID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	d := newCycleCountDocument(c)
+	tag, err := r.pool.Exec(ctx, `
+UPDATE cycle_counts SET status = $3, lines = $4, created_at = $5, created_by = $6, updated_at = $7,
+	approved_at = $8, approved_by = $9, version = version + 1
+WHERE id = $1 AND version = $2`,
+		c.ID, c.Version, d.Status, d.Lines, d.CreatedAt, d.CreatedBy, d.UpdatedAt, nullTime(d.ApprovedAt), d.ApprovedBy)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = domain.ErrConflict
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	c.Version++
+	return nil
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const lotColumns = `id, product_id, lot_number, manufactured_at, expires_at, quantity, unit_cost, status,
+	received_at, booked`
+
+type postgresLotRepo struct {
+	pool    *pgxpool.Pool
+	timeout time.Duration
+}
+
+func NewPostgresLotRepository(pool *pgxpool.Pool, timeout time.Duration) LotRepository {
+	return &postgresLotRepo{pool: pool, timeout: timeout}
+}
+
+func scanLot(row pgx.Row) (*domain.Lot, error) {
+	var (
+		d              lotDocument
+		id             string
+		manufacturedAt *time.Time
+	)
+	err := row.Scan(&id, &d.ProductID, &d.LotNumber, &manufacturedAt, &d.ExpiresAt, &d.Quantity, &d.UnitCost,
+		&d.Status, &d.ReceivedAt, &d.Booked)
+	if err != nil {
+		return nil, err
+	}
+	d.ID, d.ManufacturedAt = objectID(id), utcTime(manufacturedAt)
+	d.ExpiresAt, d.ReceivedAt = d.ExpiresAt.UTC(), d.ReceivedAt.UTC()
+	return d.toDomain(), nil
+}
+
+func (r *postgresLotRepo) Create(ctx context.Context, l *domain.Lot) (string, error) {
+	ctx, span := postgresStartSpan(ctx, "lots", "INSERT", attribute.String("product.id", l.ProductID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	d := newLotDocument(l)
+	d.ID = primitive.NewObjectID()
+	_, err := r.pool.Exec(ctx, `
+INSERT INTO lots (`+lotColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		d.ID.Hex(), d.ProductID, d.LotNumber, nullTime(d.ManufacturedAt), d.ExpiresAt, d.Quantity, d.UnitCost,
+		d.Status, d.ReceivedAt, d.Booked)
+	if err != nil {
+		if isUniqueViolation(err) {
+			err = fmt.Errorf("%w: lot %s of product %s already exists", domain.ErrInvalidArgument, l.LotNumber, l.ProductID)
+		}
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("lot.id", d.ID.Hex()))
+	return d.ID.Hex(), nil
+}
+
+func (r *postgresLotRepo) GetByNumber(ctx context.Context, productID, lotNumber string) (*domain.Lot, error) {
+	ctx, span := postgresStartSpan(ctx, "lots", "SELECT",
+		attribute.String("product.id", productID), attribute.String("lot.number", lotNumber))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	l, err := scanLot(r.pool.QueryRow(ctx,
+		`SELECT `+lotColumns+` FROM lots WHERE product_id = $1 AND lot_number = $2`, productID, lotNumber))
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return l, nil
+}
+
+func (r *postgresLotRepo) ListByProduct(ctx context.Context, productID string) ([]*domain.Lot, error) {
+	ctx, span := postgresStartSpan(ctx, "lots", "SELECT", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	lots, err := r.find(ctx, `product_id = $1`, productID)
+	tracing.RecordError(span, err)
+	return lots, err
+}
+
+func (r *postgresLotRepo) ListAllocatable(ctx context.Context, productID string, now time.Time) ([]*domain.Lot, error) {
+	ctx, span := postgresStartSpan(ctx, "lots", "SELECT", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	lots, err := r.find(ctx, `product_id = $1 AND status = $2 AND booked AND quantity > 0 AND expires_at > $3`,
+		productID, string(domain.LotAvailable), now)
+	tracing.RecordError(span, err)
+	return lots, err
+}
+
+func (r *postgresLotRepo) ListExpiring(ctx context.Context, from, to time.Time) ([]*domain.Lot, error) {
+	ctx, span := postgresStartSpan(ctx, "lots", "SELECT")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	lots, err := r.find(ctx, `status = $1 AND quantity > 0 AND expires_at >= $2 AND expires_at < $3`,
+		string(domain.LotAvailable), from, to)
+	tracing.RecordError(span, err)
+	return lots, err
+}
+
+func (r *postgresLotRepo) Take(ctx context.Context, id string, quantity int32) (bool, error) {
+	ctx, span := postgresStartSpan(ctx, "lots", "UPDATE", attribute.String("lot.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	tag, err := r.pool.Exec(ctx, `
+UPDATE lots SET quantity = quantity - $2
+WHERE id = $1 AND status = $3 AND quantity >= $2`, id, quantity, string(domain.LotAvailable))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *postgresLotRepo) Return(ctx context.Context, id string, quantity int32) error {
+	ctx, span := postgresStartSpan(ctx, "lots", "UPDATE", attribute.String("lot.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.pool.Exec(ctx, `UPDATE lots SET quantity = quantity + $2 WHERE id = $1`, id, quantity)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *postgresLotRepo) Quarantine(ctx context.Context, id string) (*domain.Lot, bool, error) {
+	ctx, span := postgresStartSpan(ctx, "lots", "UPDATE", attribute.String("lot.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	l, err := scanLot(r.pool.QueryRow(ctx, `
+UPDATE lots SET status = $2
+WHERE id = $1 AND status = $3
+RETURNING `+lotColumns, id, string(domain.LotQuarantined), string(domain.LotAvailable)))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, false, err
+	}
+	return l, true, nil
+}
+
+func (r *postgresLotRepo) SetBooked(ctx context.Context, id string, booked bool) (bool, error) {
+	ctx, span := postgresStartSpan(ctx, "lots", "UPDATE", attribute.String("lot.id", id), attribute.Bool("lot.booked", booked))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	tag, err := r.pool.Exec(ctx, `UPDATE lots SET booked = $2 WHERE id = $1 AND booked <> $2`, id, booked)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *postgresLotRepo) ListQuarantinedBooked(ctx context.Context) ([]*domain.Lot, error) {
+	ctx, span := postgresStartSpan(ctx, "lots", "SELECT")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	lots, err := r.find(ctx, `status = $1 AND booked`, string(domain.LotQuarantined))
+	tracing.RecordError(span, err)
+	return lots, err
+}
+
+// find returns the lots matching cond, earliest expiry first.
+func (r *postgresLotRepo) find(ctx context.Context, cond string, args ...any) ([]*domain.Lot, error) {
+	return queryRows(ctx, r.pool, scanLot,
+		`SELECT `+lotColumns+` FROM lots WHERE `+cond+` ORDER BY expires_at, id`, args...)
+}
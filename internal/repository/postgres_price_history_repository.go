@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const priceRecordColumns = `id, product_id, price, effective_from, effective_to, source, actor`
+
+type postgresPriceHistoryRepo struct {
+	pool    *pgxpool.Pool
+	timeout time.Duration
+}
+
+func NewPostgresPriceHistoryRepository(pool *pgxpool.Pool, timeout time.Duration) PriceHistoryRepository {
+	return &postgresPriceHistoryRepo{pool: pool, timeout: timeout}
+}
+
+func scanPriceRecord(row pgx.Row) (*domain.PriceRecord, error) {
+	var (
+		d  priceRecordDocument
+		id string
+	)
+	if err := row.Scan(&id, &d.ProductID, &d.Price, &d.EffectiveFrom, &d.EffectiveTo, &d.Source, &d.Actor); err != nil {
+		return nil, err
+	}
+	d.ID, d.EffectiveFrom, d.EffectiveTo = objectID(id), d.EffectiveFrom.UTC(), utcTimePtr(d.EffectiveTo)
+	return d.toDomain(), nil
+}
+
+// Record closes the current record and adds the new one in one transaction.
+func (r *postgresPriceHistoryRepo) Record(ctx context.Context, rec *domain.PriceRecord) error {
+	ctx, span := postgresStartSpan(ctx, "price_history", "INSERT", attribute.String("product.id", rec.ProductID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	d := newPriceRecordDocument(rec)
+	d.ID = primitive.NewObjectID()
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `
+UPDATE price_history SET effective_to = $2
+WHERE product_id = $1 AND effective_to IS NULL`, d.ProductID, d.EffectiveFrom); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, `
+INSERT INTO price_history (`+priceRecordColumns+`)
+VALUES ($1, $2, $3, $4, NULL, $5, $6)`,
+			d.ID.Hex(), d.ProductID, d.Price, d.EffectiveFrom, d.Source, d.Actor)
+		return err
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	rec.ID = d.ID.Hex()
+	return nil
+}
+
+func (r *postgresPriceHistoryRepo) At(ctx context.Context, productID string, t time.Time) (*domain.PriceRecord, error) {
+	ctx, span := postgresStartSpan(ctx, "price_history", "SELECT", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	rec, err := scanPriceRecord(r.pool.QueryRow(ctx, `
+SELECT `+priceRecordColumns+` FROM price_history
+WHERE product_id = $1 AND effective_from <= $2 AND (effective_to IS NULL OR effective_to > $2)
+ORDER BY effective_from DESC, id DESC
+LIMIT 1`, productID, t))
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return rec, nil
+}
+
+func (r *postgresPriceHistoryRepo) ListByProduct(ctx context.Context, productID string) ([]*domain.PriceRecord, error) {
+	ctx, span := postgresStartSpan(ctx, "price_history", "SELECT", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	records, err := queryRows(ctx, r.pool, scanPriceRecord, `
+SELECT `+priceRecordColumns+` FROM price_history
+WHERE product_id = $1
+ORDER BY effective_from DESC, id DESC`, productID)
+	tracing.RecordError(span, err)
+	return records, err
+}
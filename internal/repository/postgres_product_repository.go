@@ -0,0 +1,416 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// productColumns are selected in the order scanProduct reads them.
+const productColumns = `id, coalesce(sku, ''), name, description, price, stock, damaged_stock,
+	category_id, reorder_point, reorder_quantity, variant_attributes, lot_tracked, serialized,
+	deleted_at, deleted_by`
+
+// postgresProductRepo keeps products in the products table created by
+// migrate.PostgresMigrations. Stock changes are single conditional UPDATEs,
+// which PostgreSQL applies atomically under the row lock.
+type postgresProductRepo struct {
+	pool    *pgxpool.Pool
+	timeout time.Duration
+}
+
+func NewPostgresProductRepository(pool *pgxpool.Pool, timeout time.Duration) ProductRepository {
+	return &postgresProductRepo{pool: pool, timeout: timeout}
+}
+
+func (r *postgresProductRepo) startSpan(ctx context.Context, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return postgresStartSpan(ctx, "products", op, attrs...)
+}
+
+func scanProduct(row pgx.Row) (*domain.Product, error) {
+	var (
+		p         domain.Product
+		deletedAt *time.Time
+	)
+	err := row.Scan(&p.ID, &p.SKU, &p.Name, &p.Description, &p.Price, &p.Stock, &p.DamagedStock,
+		&p.CategoryID, &p.ReorderPoint, &p.ReorderQuantity, &p.VariantAttributes, &p.LotTracked, &p.Serialized,
+		&deletedAt, &p.DeletedBy)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.VariantAttributes) == 0 {
+		p.VariantAttributes = nil
+	}
+	if deletedAt != nil {
+		p.DeletedAt = deletedAt.UTC()
+	} else {
+		p.DeletedBy = ""
+	}
+	return &p, nil
+}
+
+func (r *postgresProductRepo) Create(ctx context.Context, p *domain.Product) (string, error) {
+	ctx, span := r.startSpan(ctx, "INSERT")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	attrs := p.VariantAttributes
+	if attrs == nil {
+		attrs = []string{}
+	}
+	var id string
+	err := r.pool.QueryRow(ctx, `
+INSERT INTO products (sku, name, description, price, stock, category_id, reorder_point, reorder_quantity,
+	variant_attributes, lot_tracked, serialized)
+VALUES (nullif($1, ''), $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id`,
+		p.SKU, p.Name, p.Description, p.Price, p.Stock, p.CategoryID, p.ReorderPoint, p.ReorderQuantity,
+		attrs, p.LotTracked, p.Serialized).Scan(&id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			err = fmt.Errorf("%w: SKU %s is already in use", domain.ErrInvalidArgument, p.SKU)
+		}
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("product.id", id))
+	return id, nil
+}
+
+func (r *postgresProductRepo) GetByID(ctx context.Context, id string) (*domain.Product, error) {
+	return r.getOne(ctx, id, `AND deleted_at IS NULL`)
+}
+
+func (r *postgresProductRepo) GetByIDIncludingDeleted(ctx context.Context, id string) (*domain.Product, error) {
+	return r.getOne(ctx, id, "")
+}
+
+func (r *postgresProductRepo) getOne(ctx context.Context, id, cond string) (*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "SELECT", attribute.String("product.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	p, err := scanProduct(r.pool.QueryRow(ctx,
+		`SELECT `+productColumns+` FROM products WHERE id = $1 `+cond, id))
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *postgresProductRepo) GetMany(ctx context.Context, ids, skus []string) ([]*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "SELECT",
+		attribute.Int("product.ids", len(ids)), attribute.Int("product.skus", len(skus)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if len(ids) == 0 && len(skus) == 0 {
+		return nil, nil
+	}
+	if ids == nil {
+		ids = []string{}
+	}
+	if skus == nil {
+		skus = []string{}
+	}
+	products, err := r.query(ctx, `
+SELECT `+productColumns+` FROM products
+WHERE (id = ANY($1) OR sku = ANY($2)) AND deleted_at IS NULL
+ORDER BY created_at, id`, ids, skus)
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+func (r *postgresProductRepo) List(ctx context.Context, includeDeleted bool) ([]*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "SELECT", attribute.Bool("include_deleted", includeDeleted))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cond := "WHERE deleted_at IS NULL"
+	if includeDeleted {
+		cond = ""
+	}
+	products, err := r.query(ctx, `SELECT `+productColumns+` FROM products `+cond+` ORDER BY created_at, id`)
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+func (r *postgresProductRepo) Search(ctx context.Context, query string, limit int) ([]*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "SELECT", attribute.String("search.query", query))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var (
+		conds []string
+		args  []any
+	)
+	for _, word := range strings.Fields(query) {
+		args = append(args, "%"+likeEscaper.Replace(word)+"%")
+		n := len(args)
+		conds = append(conds, fmt.Sprintf("(name ILIKE $%d OR description ILIKE $%d OR sku ILIKE $%d)", n, n, n))
+	}
+	if len(conds) == 0 {
+		return nil, nil
+	}
+	args = append(args, limit)
+	products, err := r.query(ctx, `
+SELECT `+productColumns+` FROM products
+WHERE `+strings.Join(conds, " AND ")+` AND deleted_at IS NULL
+ORDER BY created_at, id
+LIMIT $`+fmt.Sprint(len(args)), args...)
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+// likeEscaper makes the wildcards of LIKE patterns match literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *postgresProductRepo) Update(ctx context.Context, id string, u *domain.ProductUpdate) (*domain.Product, *domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "UPDATE", attribute.String("product.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var (
+		set  []string
+		args = []any{id}
+	)
+	add := func(column string, v any) {
+		args = append(args, v)
+		set = append(set, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+	if u.Name != nil {
+		add("name", *u.Name)
+	}
+	if u.Description != nil {
+		add("description", *u.Description)
+	}
+	if u.Price != nil {
+		add("price", *u.Price)
+	}
+	if u.CategoryID != nil {
+		add("category_id", *u.CategoryID)
+	}
+	if u.ReorderPoint != nil {
+		add("reorder_point", *u.ReorderPoint)
+	}
+	if u.ReorderQuantity != nil {
+		add("reorder_quantity", *u.ReorderQuantity)
+	}
+	if len(set) == 0 {
+		// Nothing to change; still report a missing product.
+		set = append(set, "id = id")
+	}
+
+	// The row is locked by the SELECT, so the UPDATE sees the same product
+	// and the one read is the product as it was.
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+	before, err := scanProduct(tx.QueryRow(ctx, `
+SELECT `+productColumns+` FROM products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id))
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	after, err := scanProduct(tx.QueryRow(ctx, `
+UPDATE products SET `+strings.Join(set, ", ")+`
+WHERE id = $1
+RETURNING `+productColumns, args...))
+	if err == nil {
+		err = tx.Commit(ctx)
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	return before, after, nil
+}
+
+// AdjustStock atomically adds delta to the stock of a product. A negative
+// delta only applies when enough stock is left, so stock never goes below
+// zero; ErrInsufficientStock is returned otherwise.
+func (r *postgresProductRepo) AdjustStock(ctx context.Context, id string, delta int32) (*domain.Product, error) {
+	return r.adjust(ctx, "stock", id, delta)
+}
+
+func (r *postgresProductRepo) AdjustDamagedStock(ctx context.Context, id string, delta int32) (*domain.Product, error) {
+	return r.adjust(ctx, "damaged_stock", id, delta)
+}
+
+func (r *postgresProductRepo) adjust(ctx context.Context, column, id string, delta int32) (*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "UPDATE", attribute.String("product.id", id),
+		attribute.String("stock.bucket", column), attribute.Int("stock.delta", int(delta)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	p, err := scanProduct(r.pool.QueryRow(ctx, `
+UPDATE products SET `+column+` = `+column+` + $2
+WHERE id = $1 AND deleted_at IS NULL AND `+column+` + $2 >= 0
+RETURNING `+productColumns, id, delta))
+	if errors.Is(err, pgx.ErrNoRows) && delta < 0 {
+		// Tell a missing product apart from one without enough stock.
+		var exists bool
+		if qerr := r.pool.QueryRow(ctx,
+			`SELECT EXISTS (SELECT 1 FROM products WHERE id = $1 AND deleted_at IS NULL)`, id).Scan(&exists); qerr == nil && exists {
+			err = domain.ErrInsufficientStock
+		}
+	}
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *postgresProductRepo) Count(ctx context.Context) (int64, error) {
+	return r.count(ctx, `deleted_at IS NULL`)
+}
+
+func (r *postgresProductRepo) CountOutOfStock(ctx context.Context) (int64, error) {
+	return r.count(ctx, `deleted_at IS NULL AND stock <= 0`)
+}
+
+func (r *postgresProductRepo) count(ctx context.Context, cond string) (int64, error) {
+	ctx, span := r.startSpan(ctx, "SELECT")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var n int64
+	err := r.pool.QueryRow(ctx, `SELECT count(*) FROM products WHERE `+cond).Scan(&n)
+	tracing.RecordError(span, err)
+	return n, err
+}
+
+func (r *postgresProductRepo) ListLowStock(ctx context.Context) ([]*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "SELECT")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	products, err := r.query(ctx, `
+SELECT `+productColumns+` FROM products
+WHERE reorder_point > 0 AND stock <= reorder_point AND deleted_at IS NULL
+ORDER BY created_at, id`)
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+func (r *postgresProductRepo) SetLowStockAlerted(ctx context.Context, id string, alerted bool) (bool, error) {
+	ctx, span := r.startSpan(ctx, "UPDATE", attribute.String("product.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE products SET low_stock_alerted = $2 WHERE id = $1 AND low_stock_alerted <> $2`, id, alerted)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *postgresProductRepo) SoftDelete(ctx context.Context, id, deletedBy string, at time.Time) (*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "UPDATE", attribute.String("product.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	p, err := scanProduct(r.pool.QueryRow(ctx, `
+UPDATE products SET deleted_at = $2, deleted_by = $3
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING `+productColumns, id, at, deletedBy))
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *postgresProductRepo) Restore(ctx context.Context, id string) (*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "UPDATE", attribute.String("product.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	p, err := scanProduct(r.pool.QueryRow(ctx, `
+UPDATE products SET deleted_at = NULL, deleted_by = ''
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING `+productColumns, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		var exists bool
+		if qerr := r.pool.QueryRow(ctx,
+			`SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)`, id).Scan(&exists); qerr == nil && exists {
+			err = fmt.Errorf("%w: product %s is not archived", domain.ErrFailedPrecondition, id)
+		}
+	}
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *postgresProductRepo) ListDeleted(ctx context.Context, before time.Time) ([]*domain.Product, error) {
+	ctx, span := r.startSpan(ctx, "SELECT")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	products, err := r.query(ctx, `
+SELECT `+productColumns+` FROM products WHERE deleted_at < $1 ORDER BY created_at, id`, before)
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+func (r *postgresProductRepo) PurgeDeleted(ctx context.Context, ids []string, before time.Time) ([]string, error) {
+	ctx, span := r.startSpan(ctx, "DELETE", attribute.Int("product.ids", len(ids)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	purged := make([]string, 0, len(ids))
+	if len(ids) == 0 {
+		return purged, nil
+	}
+	rows, err := r.pool.Query(ctx, `DELETE FROM products WHERE id = ANY($1) AND deleted_at < $2 RETURNING id`, ids, before)
+	if err == nil {
+		purged, err = pgx.AppendRows(purged, rows, pgx.RowTo[string])
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return purged, nil
+}
+
+func (r *postgresProductRepo) query(ctx context.Context, sql string, args ...any) ([]*domain.Product, error) {
+	return queryRows(ctx, r.pool, scanProduct, sql, args...)
+}
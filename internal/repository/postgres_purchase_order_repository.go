@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const purchaseOrderColumns = `id, supplier_id, lines, expected_at, status, receipts, created_at, updated_at, version`
+
+type postgresPurchaseOrderRepo struct {
+	pool    *pgxpool.Pool
+	timeout time.Duration
+}
+
+func NewPostgresPurchaseOrderRepository(pool *pgxpool.Pool, timeout time.Duration) PurchaseOrderRepository {
+	return &postgresPurchaseOrderRepo{pool: pool, timeout: timeout}
+}
+
+func scanPurchaseOrder(row pgx.Row) (*domain.PurchaseOrder, error) {
+	var (
+		d          purchaseOrderDocument
+		id         string
+		expectedAt *time.Time
+	)
+	err := row.Scan(&id, &d.SupplierID, &d.Lines, &expectedAt, &d.Status, &d.Receipts, &d.CreatedAt,
+		&d.UpdatedAt, &d.Version)
+	if err != nil {
+		return nil, err
+	}
+	d.ID, d.ExpectedAt = objectID(id), utcTime(expectedAt)
+	d.CreatedAt, d.UpdatedAt = d.CreatedAt.UTC(), d.UpdatedAt.UTC()
+	return d.toDomain(), nil
+}
+
+func (r *postgresPurchaseOrderRepo) Create(ctx context.Context, po *domain.PurchaseOrder) (string, error) {
+	ctx, span := postgresStartSpan(ctx, "purchase_orders", "INSERT")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	d := newPurchaseOrderDocument(po)
+	d.ID = primitive.NewObjectID()
+	_, err := r.pool.Exec(ctx, `
+INSERT INTO purchase_orders (`+purchaseOrderColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		d.ID.Hex(), d.SupplierID, d.Lines, nullTime(d.ExpectedAt), d.Status, d.Receipts, d.CreatedAt,
+		d.UpdatedAt, d.Version)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("purchase_order.id", d.ID.Hex()))
+	return d.ID.Hex(), nil
+}
+
+func (r *postgresPurchaseOrderRepo) GetByID(ctx context.Context, id string) (*domain.PurchaseOrder, error) {
+	ctx, span := postgresStartSpan(ctx, "purchase_orders", "SELECT", attribute.String("purchase_order.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	po, err := scanPurchaseOrder(r.pool.QueryRow(ctx,
+		`SELECT `+purchaseOrderColumns+` FROM purchase_orders WHERE id = $1`, id))
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return po, nil
+}
+
+func (r *postgresPurchaseOrderRepo) ListByStatus(ctx context.Context, statuses ...domain.PurchaseOrderStatus) ([]*domain.PurchaseOrder, error) {
+	ctx, span := postgresStartSpan(ctx, "purchase_orders", "SELECT")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	names := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		names = append(names, string(s))
+	}
+	orders, err := queryRows(ctx, r.pool, scanPurchaseOrder,
+		`SELECT `+purchaseOrderColumns+` FROM purchase_orders WHERE status = ANY($1) ORDER BY id`, names)
+	tracing.RecordError(span, err)
+	return orders, err
+}
+
+func (r *postgresPurchaseOrderRepo) Update(ctx context.Context, po *domain.PurchaseOrder) error {
+	ctx, span := postgresStartSpan(ctx, "purchase_orders", "UPDATE", attribute.String("purchase_order.id", po.ID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	d := newPurchaseOrderDocument(po)
+	tag, err := r.pool.Exec(ctx, `
+UPDATE purchase_orders SET supplier_id = $3, lines = $4, expected_at = $5, status = $6, receipts = $7,
+	created_at = $8, updated_at = $9, version = version + 1
+WHERE id = $1 AND version = $2`,
+		po.ID, po.Version, d.SupplierID, d.Lines, nullTime(d.ExpectedAt), d.Status, d.Receipts, d.CreatedAt, d.UpdatedAt)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = domain.ErrConflict
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	po.Version++
+	return nil
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const reservationColumns = `id, bundle_id, quantity, lines, reference, status, created_at, released_at`
+
+type postgresReservationRepo struct {
+	pool    *pgxpool.Pool
+	timeout time.Duration
+}
+
+func NewPostgresReservationRepository(pool *pgxpool.Pool, timeout time.Duration) ReservationRepository {
+	return &postgresReservationRepo{pool: pool, timeout: timeout}
+}
+
+func scanReservation(row pgx.Row) (*domain.Reservation, error) {
+	var (
+		d          reservationDocument
+		id         string
+		releasedAt *time.Time
+	)
+	err := row.Scan(&id, &d.BundleID, &d.Quantity, &d.Lines, &d.Reference, &d.Status, &d.CreatedAt, &releasedAt)
+	if err != nil {
+		return nil, err
+	}
+	d.ID, d.CreatedAt, d.ReleasedAt = objectID(id), d.CreatedAt.UTC(), utcTime(releasedAt)
+	return d.toDomain(), nil
+}
+
+func (r *postgresReservationRepo) Create(ctx context.Context, res *domain.Reservation) (string, error) {
+	ctx, span := postgresStartSpan(ctx, "reservations", "INSERT", attribute.String("bundle.id", res.BundleID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	d := newReservationDocument(res)
+	d.ID = primitive.NewObjectID()
+	_, err := r.pool.Exec(ctx, `
+INSERT INTO reservations (`+reservationColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		d.ID.Hex(), d.BundleID, d.Quantity, d.Lines, d.Reference, d.Status, d.CreatedAt, nullTime(d.ReleasedAt))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("reservation.id", d.ID.Hex()))
+	return d.ID.Hex(), nil
+}
+
+func (r *postgresReservationRepo) GetByID(ctx context.Context, id string) (*domain.Reservation, error) {
+	ctx, span := postgresStartSpan(ctx, "reservations", "SELECT", attribute.String("reservation.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := scanReservation(r.pool.QueryRow(ctx, `SELECT `+reservationColumns+` FROM reservations WHERE id = $1`, id))
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *postgresReservationRepo) List(ctx context.Context, status domain.ReservationStatus, productID string) ([]*domain.Reservation, error) {
+	ctx, span := postgresStartSpan(ctx, "reservations", "SELECT", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	reservations, err := queryRows(ctx, r.pool, scanReservation, `
+SELECT `+reservationColumns+` FROM reservations
+WHERE ($1::text = '' OR status = $1)
+	AND ($2::text = '' OR lines @> jsonb_build_array(jsonb_build_object('product_id', $2)))
+ORDER BY created_at DESC, id DESC`, string(status), productID)
+	tracing.RecordError(span, err)
+	return reservations, err
+}
+
+func (r *postgresReservationRepo) Delete(ctx context.Context, id string) error {
+	ctx, span := postgresStartSpan(ctx, "reservations", "DELETE", attribute.String("reservation.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.pool.Exec(ctx, `DELETE FROM reservations WHERE id = $1`, id)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *postgresReservationRepo) SetLines(ctx context.Context, id string, lines []domain.ReservationLine) error {
+	ctx, span := postgresStartSpan(ctx, "reservations", "UPDATE", attribute.String("reservation.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	tag, err := r.pool.Exec(ctx, `UPDATE reservations SET lines = $2 WHERE id = $1`, id, toReservationLineDocuments(lines))
+	if err == nil && tag.RowsAffected() == 0 {
+		err = domain.ErrNotFound
+	}
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *postgresReservationRepo) Release(ctx context.Context, id string, at time.Time) (bool, error) {
+	ctx, span := postgresStartSpan(ctx, "reservations", "UPDATE", attribute.String("reservation.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	tag, err := r.pool.Exec(ctx, `
+UPDATE reservations SET status = $2, released_at = $3
+WHERE id = $1 AND status = $4`,
+		id, string(domain.ReservationReleased), at, string(domain.ReservationActive))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// SetLineReleased locks the reservation while it finds the line to change,
+// since the lines are one jsonb value.
+func (r *postgresReservationRepo) SetLineReleased(ctx context.Context, id, productID string, released bool) (bool, error) {
+	ctx, span := postgresStartSpan(ctx, "reservations", "UPDATE", attribute.String("reservation.id", id),
+		attribute.String("product.id", productID), attribute.Bool("reservation.line.released", released))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	changed := false
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var d reservationDocument
+		err := tx.QueryRow(ctx, `SELECT status, lines FROM reservations WHERE id = $1 FOR UPDATE`, id).
+			Scan(&d.Status, &d.Lines)
+		if err != nil {
+			return err
+		}
+		if released && d.Status != string(domain.ReservationActive) {
+			return nil
+		}
+		i := slices.IndexFunc(d.Lines, func(l reservationLineDocument) bool {
+			return l.ProductID == productID && l.Released != released
+		})
+		if i < 0 {
+			return nil
+		}
+		d.Lines[i].Released = released
+		if released {
+			_, err = tx.Exec(ctx, `UPDATE reservations SET lines = $2 WHERE id = $1`, id, d.Lines)
+		} else {
+			_, err = tx.Exec(ctx, `UPDATE reservations SET lines = $2, status = $3, released_at = NULL WHERE id = $1`,
+				id, d.Lines, string(domain.ReservationActive))
+		}
+		changed = err == nil
+		return err
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return changed, nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const returnColumns = `id, order_id, reason, lines, inspections, status, created_at, created_by, updated_at, version`
+
+type postgresReturnRepo struct {
+	pool    *pgxpool.Pool
+	timeout time.Duration
+}
+
+func NewPostgresReturnRepository(pool *pgxpool.Pool, timeout time.Duration) ReturnRepository {
+	return &postgresReturnRepo{pool: pool, timeout: timeout}
+}
+
+func scanReturn(row pgx.Row) (*domain.Return, error) {
+	var (
+		d  returnDocument
+		id string
+	)
+	err := row.Scan(&id, &d.OrderID, &d.Reason, &d.Lines, &d.Inspections, &d.Status, &d.CreatedAt, &d.CreatedBy,
+		&d.UpdatedAt, &d.Version)
+	if err != nil {
+		return nil, err
+	}
+	d.ID, d.CreatedAt, d.UpdatedAt = objectID(id), d.CreatedAt.UTC(), d.UpdatedAt.UTC()
+	return d.toDomain(), nil
+}
+
+func (r *postgresReturnRepo) Create(ctx context.Context, ret *domain.Return) (string, error) {
+	ctx, span := postgresStartSpan(ctx, "returns", "INSERT", attribute.String("order.id", ret.OrderID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	d := newReturnDocument(ret)
+	d.ID = primitive.NewObjectID()
+	_, err := r.pool.Exec(ctx, `
+INSERT INTO returns (`+returnColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		d.ID.Hex(), d.OrderID, d.Reason, d.Lines, d.Inspections, d.Status, d.CreatedAt, d.CreatedBy,
+		d.UpdatedAt, d.Version)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("return.id", d.ID.Hex()))
+	return d.ID.Hex(), nil
+}
+
+func (r *postgresReturnRepo) GetByID(ctx context.Context, id string) (*domain.Return, error) {
+	ctx, span := postgresStartSpan(ctx, "returns", "SELECT", attribute.String("return.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	ret, err := scanReturn(r.pool.QueryRow(ctx, `SELECT `+returnColumns+` FROM returns WHERE id = $1`, id))
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *postgresReturnRepo) ListByOrder(ctx context.Context, orderID string) ([]*domain.Return, error) {
+	ctx, span := postgresStartSpan(ctx, "returns", "SELECT", attribute.String("order.id", orderID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	returns, err := queryRows(ctx, r.pool, scanReturn,
+		`SELECT `+returnColumns+` FROM returns WHERE order_id = $1 ORDER BY created_at, id`, orderID)
+	tracing.RecordError(span, err)
+	return returns, err
+}
+
+func (r *postgresReturnRepo) Update(ctx context.Context, ret *domain.Return) error {
+	ctx, span := postgresStartSpan(ctx, "returns", "UPDATE", attribute.String("return.id", ret.ID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	d := newReturnDocument(ret)
+	tag, err := r.pool.Exec(ctx, `
+UPDATE returns SET order_id = $3, reason = $4, lines = $5, inspections = $6, status = $7, created_at = $8,
+	created_by = $9, updated_at = $10, version = version + 1
+WHERE id = $1 AND version = $2`,
+		ret.ID, ret.Version, d.OrderID, d.Reason, d.Lines, d.Inspections, d.Status, d.CreatedAt, d.CreatedBy, d.UpdatedAt)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = domain.ErrConflict
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	ret.Version++
+	return nil
+}
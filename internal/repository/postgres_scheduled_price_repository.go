@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const scheduledPriceColumns = `id, product_id, price, effective_from, effective_to, status, previous_price,
+	history_pending, created_at, created_by`
+
+type postgresScheduledPriceRepo struct {
+	pool    *pgxpool.Pool
+	timeout time.Duration
+}
+
+func NewPostgresScheduledPriceRepository(pool *pgxpool.Pool, timeout time.Duration) ScheduledPriceRepository {
+	return &postgresScheduledPriceRepo{pool: pool, timeout: timeout}
+}
+
+func scanScheduledPrice(row pgx.Row) (*domain.ScheduledPrice, error) {
+	var (
+		d  scheduledPriceDocument
+		id string
+	)
+	err := row.Scan(&id, &d.ProductID, &d.Price, &d.EffectiveFrom, &d.EffectiveTo, &d.Status, &d.PreviousPrice,
+		&d.HistoryPending, &d.CreatedAt, &d.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	d.ID, d.EffectiveFrom, d.EffectiveTo = objectID(id), d.EffectiveFrom.UTC(), utcTimePtr(d.EffectiveTo)
+	d.CreatedAt = d.CreatedAt.UTC()
+	return d.toDomain(), nil
+}
+
+func (r *postgresScheduledPriceRepo) Create(ctx context.Context, s *domain.ScheduledPrice) (string, error) {
+	ctx, span := postgresStartSpan(ctx, "scheduled_prices", "INSERT", attribute.String("product.id", s.ProductID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	d := newScheduledPriceDocument(s)
+	d.ID = primitive.NewObjectID()
+	_, err := r.pool.Exec(ctx, `
+INSERT INTO scheduled_prices (`+scheduledPriceColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		d.ID.Hex(), d.ProductID, d.Price, d.EffectiveFrom, d.EffectiveTo, d.Status, d.PreviousPrice,
+		d.HistoryPending, d.CreatedAt, d.CreatedBy)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	return d.ID.Hex(), nil
+}
+
+func (r *postgresScheduledPriceRepo) GetByID(ctx context.Context, id string) (*domain.ScheduledPrice, error) {
+	ctx, span := postgresStartSpan(ctx, "scheduled_prices", "SELECT", attribute.String("scheduled_price.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	s, err := scanScheduledPrice(r.pool.QueryRow(ctx,
+		`SELECT `+scheduledPriceColumns+` FROM scheduled_prices WHERE id = $1`, id))
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *postgresScheduledPriceRepo) ListByProduct(ctx context.Context, productID string, statuses ...domain.ScheduledPriceStatus) ([]*domain.ScheduledPrice, error) {
+	ctx, span := postgresStartSpan(ctx, "scheduled_prices", "SELECT", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	names := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		names = append(names, string(s))
+	}
+	changes, err := r.find(ctx, `product_id = $1 AND (cardinality($2::text[]) = 0 OR status = ANY($2))`, productID, names)
+	tracing.RecordError(span, err)
+	return changes, err
+}
+
+func (r *postgresScheduledPriceRepo) ListDue(ctx context.Context, now time.Time) ([]*domain.ScheduledPrice, error) {
+	ctx, span := postgresStartSpan(ctx, "scheduled_prices", "SELECT")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	changes, err := r.find(ctx, `
+(status = $2 AND effective_from <= $1) OR (status = $3 AND effective_to <= $1) OR history_pending`,
+		now, string(domain.ScheduledPricePending), string(domain.ScheduledPriceActive))
+	tracing.RecordError(span, err)
+	return changes, err
+}
+
+func (r *postgresScheduledPriceRepo) Transition(ctx context.Context, id string, from, to domain.ScheduledPriceStatus, previousPrice float64, historyPending bool) (bool, error) {
+	ctx, span := postgresStartSpan(ctx, "scheduled_prices", "UPDATE", attribute.String("scheduled_price.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	tag, err := r.pool.Exec(ctx, `
+UPDATE scheduled_prices SET status = $3, previous_price = $4, history_pending = $5
+WHERE id = $1 AND status = $2`, id, string(from), string(to), previousPrice, historyPending)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *postgresScheduledPriceRepo) ClearHistoryPending(ctx context.Context, id string) error {
+	ctx, span := postgresStartSpan(ctx, "scheduled_prices", "UPDATE", attribute.String("scheduled_price.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.pool.Exec(ctx, `UPDATE scheduled_prices SET history_pending = false WHERE id = $1`, id)
+	tracing.RecordError(span, err)
+	return err
+}
+
+// find returns the changes matching cond ordered by effective_from.
+func (r *postgresScheduledPriceRepo) find(ctx context.Context, cond string, args ...any) ([]*domain.ScheduledPrice, error) {
+	return queryRows(ctx, r.pool, scanScheduledPrice,
+		`SELECT `+scheduledPriceColumns+` FROM scheduled_prices WHERE `+cond+` ORDER BY effective_from, id`, args...)
+}
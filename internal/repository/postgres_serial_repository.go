@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const serialColumns = `id, product_id, serial, status, location, order_id, booked, received_at, updated_at`
+
+type postgresSerialRepo struct {
+	pool    *pgxpool.Pool
+	timeout time.Duration
+}
+
+func NewPostgresSerialRepository(pool *pgxpool.Pool, timeout time.Duration) SerialRepository {
+	return &postgresSerialRepo{pool: pool, timeout: timeout}
+}
+
+func scanSerial(row pgx.Row) (*domain.SerialUnit, error) {
+	var (
+		d  serialDocument
+		id string
+	)
+	err := row.Scan(&id, &d.ProductID, &d.Serial, &d.Status, &d.Location, &d.OrderID, &d.Booked,
+		&d.ReceivedAt, &d.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	d.ID, d.ReceivedAt, d.UpdatedAt = objectID(id), d.ReceivedAt.UTC(), d.UpdatedAt.UTC()
+	return d.toDomain(), nil
+}
+
+// CreateMany stores all units in one transaction or, when a serial number is
+// taken, none.
+func (r *postgresSerialRepo) CreateMany(ctx context.Context, units []*domain.SerialUnit) error {
+	ctx, span := postgresStartSpan(ctx, "serial_units", "INSERT", attribute.Int("serial.count", len(units)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	docs := make([]serialDocument, 0, len(units))
+	batch := &pgx.Batch{}
+	for _, u := range units {
+		d := newSerialDocument(u)
+		d.ID = primitive.NewObjectID()
+		docs = append(docs, d)
+		batch.Queue(`INSERT INTO serial_units (`+serialColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			d.ID.Hex(), d.ProductID, d.Serial, d.Status, d.Location, d.OrderID, d.Booked, d.ReceivedAt, d.UpdatedAt)
+	}
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		results := tx.SendBatch(ctx, batch)
+		for i := range docs {
+			if _, err := results.Exec(); err != nil {
+				results.Close()
+				if isUniqueViolation(err) {
+					err = fmt.Errorf("%w: serial %s already exists", domain.ErrInvalidArgument, docs[i].Serial)
+				}
+				return err
+			}
+		}
+		return results.Close()
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	for i, u := range units {
+		u.ID = docs[i].ID.Hex()
+	}
+	return nil
+}
+
+func (r *postgresSerialRepo) FindExisting(ctx context.Context, serials []string) ([]string, error) {
+	ctx, span := postgresStartSpan(ctx, "serial_units", "SELECT")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if serials == nil {
+		serials = []string{}
+	}
+	existing := make([]string, 0)
+	rows, err := r.pool.Query(ctx, `SELECT serial FROM serial_units WHERE serial = ANY($1)`, serials)
+	if err == nil {
+		existing, err = pgx.AppendRows(existing, rows, pgx.RowTo[string])
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (r *postgresSerialRepo) GetBySerial(ctx context.Context, serial string) (*domain.SerialUnit, error) {
+	ctx, span := postgresStartSpan(ctx, "serial_units", "SELECT", attribute.String("serial", serial))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	u, err := scanSerial(r.pool.QueryRow(ctx, `SELECT `+serialColumns+` FROM serial_units WHERE serial = $1`, serial))
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return u, nil
+}
+
+func (r *postgresSerialRepo) ListByProduct(ctx context.Context, productID string, status domain.SerialStatus, limit int64) ([]*domain.SerialUnit, error) {
+	ctx, span := postgresStartSpan(ctx, "serial_units", "SELECT", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	units, err := r.find(ctx, limit, `product_id = $1 AND ($2::text = '' OR status = $2)`, productID, string(status))
+	tracing.RecordError(span, err)
+	return units, err
+}
+
+func (r *postgresSerialRepo) ListAllocatable(ctx context.Context, productID string, limit int64) ([]*domain.SerialUnit, error) {
+	ctx, span := postgresStartSpan(ctx, "serial_units", "SELECT", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	units, err := r.find(ctx, limit, `product_id = $1 AND status = $2 AND booked`, productID, string(domain.SerialAvailable))
+	tracing.RecordError(span, err)
+	return units, err
+}
+
+// find returns up to limit units matching cond, oldest first.
+func (r *postgresSerialRepo) find(ctx context.Context, limit int64, cond string, args ...any) ([]*domain.SerialUnit, error) {
+	sql := `SELECT ` + serialColumns + ` FROM serial_units WHERE ` + cond + ` ORDER BY received_at, id`
+	if limit > 0 {
+		sql += fmt.Sprintf(" LIMIT %d", limit)
+	}
+	return queryRows(ctx, r.pool, scanSerial, sql, args...)
+}
+
+func (r *postgresSerialRepo) Transition(ctx context.Context, u *domain.SerialUnit, from domain.SerialStatus) (bool, error) {
+	ctx, span := postgresStartSpan(ctx, "serial_units", "UPDATE", attribute.String("serial", u.Serial))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	tag, err := r.pool.Exec(ctx, `
+UPDATE serial_units SET status = $3, location = $4, order_id = $5, updated_at = $6
+WHERE id = $1 AND status = $2 AND booked`,
+		u.ID, string(from), string(u.Status), u.Location, u.OrderID, u.UpdatedAt)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *postgresSerialRepo) SetBooked(ctx context.Context, serial string, booked bool) (bool, error) {
+	ctx, span := postgresStartSpan(ctx, "serial_units", "UPDATE", attribute.String("serial", serial), attribute.Bool("serial.booked", booked))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	tag, err := r.pool.Exec(ctx, `UPDATE serial_units SET booked = $2 WHERE serial = $1 AND booked <> $2`, serial, booked)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *postgresSerialRepo) CountByStatus(ctx context.Context, productID string) (map[domain.SerialStatus]int64, error) {
+	ctx, span := postgresStartSpan(ctx, "serial_units", "SELECT", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	counts := make(map[domain.SerialStatus]int64)
+	rows, err := r.pool.Query(ctx, `SELECT status, count(*) FROM serial_units WHERE product_id = $1 GROUP BY status`, productID)
+	if err == nil {
+		var (
+			status string
+			n      int64
+		)
+		_, err = pgx.ForEachRow(rows, []any{&status, &n}, func() error {
+			counts[domain.SerialStatus(status)] = n
+			return nil
+		})
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *postgresSerialRepo) CountAvailableByLocation(ctx context.Context, productIDs []string) (map[string]map[string]int32, error) {
+	ctx, span := postgresStartSpan(ctx, "serial_units", "SELECT", attribute.Int("product.count", len(productIDs)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if productIDs == nil {
+		productIDs = []string{}
+	}
+	counts := make(map[string]map[string]int32)
+	rows, err := r.pool.Query(ctx, `
+SELECT product_id, location, count(*) FROM serial_units
+WHERE product_id = ANY($1) AND status = $2 AND booked
+GROUP BY product_id, location`, productIDs, string(domain.SerialAvailable))
+	if err == nil {
+		var (
+			productID, location string
+			n                   int32
+		)
+		_, err = pgx.ForEachRow(rows, []any{&productID, &location, &n}, func() error {
+			if counts[productID] == nil {
+				counts[productID] = make(map[string]int32)
+			}
+			counts[productID][location] = n
+			return nil
+		})
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return counts, nil
+}
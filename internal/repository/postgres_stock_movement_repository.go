@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const stockMovementColumns = `id, product_id, variant_id, quantity, reason, bucket, unit_cost, reference, created_at`
+
+type postgresStockMovementRepo struct {
+	pool    *pgxpool.Pool
+	timeout time.Duration
+}
+
+func NewPostgresStockMovementRepository(pool *pgxpool.Pool, timeout time.Duration) StockMovementRepository {
+	return &postgresStockMovementRepo{pool: pool, timeout: timeout}
+}
+
+func scanStockMovement(row pgx.Row) (*domain.StockMovement, error) {
+	var (
+		d  stockMovementDocument
+		id string
+	)
+	err := row.Scan(&id, &d.ProductID, &d.VariantID, &d.Quantity, &d.Reason, &d.Bucket, &d.UnitCost,
+		&d.Reference, &d.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	d.ID, d.CreatedAt = objectID(id), d.CreatedAt.UTC()
+	return d.toDomain(), nil
+}
+
+func (r *postgresStockMovementRepo) Record(ctx context.Context, m *domain.StockMovement) error {
+	ctx, span := postgresStartSpan(ctx, "stock_movements", "INSERT", attribute.String("product.id", m.ProductID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now().UTC()
+	}
+	d := newStockMovementDocument(m)
+	d.ID = primitive.NewObjectID()
+	_, err := r.pool.Exec(ctx, `
+INSERT INTO stock_movements (`+stockMovementColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		d.ID.Hex(), d.ProductID, d.VariantID, d.Quantity, d.Reason, d.Bucket, d.UnitCost, d.Reference, d.CreatedAt)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	m.ID = d.ID.Hex()
+	return nil
+}
+
+func (r *postgresStockMovementRepo) ListByProducts(ctx context.Context, productIDs []string) ([]*domain.StockMovement, error) {
+	ctx, span := postgresStartSpan(ctx, "stock_movements", "SELECT", attribute.Int("product.count", len(productIDs)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if productIDs == nil {
+		productIDs = []string{}
+	}
+	movements, err := queryRows(ctx, r.pool, scanStockMovement, `
+SELECT `+stockMovementColumns+` FROM stock_movements
+WHERE product_id = ANY($1)
+ORDER BY created_at, id`, productIDs)
+	tracing.RecordError(span, err)
+	return movements, err
+}
+
+func (r *postgresStockMovementRepo) ListByReasons(ctx context.Context, productIDs []string, reasons []domain.MovementReason, since time.Time) ([]*domain.StockMovement, error) {
+	ctx, span := postgresStartSpan(ctx, "stock_movements", "SELECT", attribute.Int("product.count", len(productIDs)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	names := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		names = append(names, string(reason))
+	}
+	if productIDs == nil {
+		productIDs = []string{}
+	}
+	movements, err := queryRows(ctx, r.pool, scanStockMovement, `
+SELECT `+stockMovementColumns+` FROM stock_movements
+WHERE reason = ANY($1) AND created_at >= $2 AND (cardinality($3::text[]) = 0 OR product_id = ANY($3))
+ORDER BY created_at, id`, names, since, productIDs)
+	tracing.RecordError(span, err)
+	return movements, err
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const supplierColumns = `id, name, contact_email, phone, created_at`
+
+type postgresSupplierRepo struct {
+	pool    *pgxpool.Pool
+	timeout time.Duration
+}
+
+func NewPostgresSupplierRepository(pool *pgxpool.Pool, timeout time.Duration) SupplierRepository {
+	return &postgresSupplierRepo{pool: pool, timeout: timeout}
+}
+
+func scanSupplier(row pgx.Row) (*domain.Supplier, error) {
+	var (
+		d  supplierDocument
+		id string
+	)
+	if err := row.Scan(&id, &d.Name, &d.ContactEmail, &d.Phone, &d.CreatedAt); err != nil {
+		return nil, err
+	}
+	d.ID, d.CreatedAt = objectID(id), d.CreatedAt.UTC()
+	return d.toDomain(), nil
+}
+
+func (r *postgresSupplierRepo) Create(ctx context.Context, s *domain.Supplier) (string, error) {
+	ctx, span := postgresStartSpan(ctx, "suppliers", "INSERT")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	d := newSupplierDocument(s)
+	d.ID = primitive.NewObjectID()
+	_, err := r.pool.Exec(ctx, `INSERT INTO suppliers (`+supplierColumns+`) VALUES ($1, $2, $3, $4, $5)`,
+		d.ID.Hex(), d.Name, d.ContactEmail, d.Phone, d.CreatedAt)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("supplier.id", d.ID.Hex()))
+	return d.ID.Hex(), nil
+}
+
+func (r *postgresSupplierRepo) GetByID(ctx context.Context, id string) (*domain.Supplier, error) {
+	ctx, span := postgresStartSpan(ctx, "suppliers", "SELECT", attribute.String("supplier.id", id))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	s, err := scanSupplier(r.pool.QueryRow(ctx, `SELECT `+supplierColumns+` FROM suppliers WHERE id = $1`, id))
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *postgresSupplierRepo) List(ctx context.Context) ([]*domain.Supplier, error) {
+	ctx, span := postgresStartSpan(ctx, "suppliers", "SELECT")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	suppliers, err := queryRows(ctx, r.pool, scanSupplier,
+		`SELECT `+supplierColumns+` FROM suppliers ORDER BY name, id`)
+	tracing.RecordError(span, err)
+	return suppliers, err
+}
+
+const productSupplierColumns = `product_id, supplier_id, supplier_sku, unit_cost, lead_time_days, min_order_quantity`
+
+type postgresProductSupplierRepo struct {
+	pool    *pgxpool.Pool
+	timeout time.Duration
+}
+
+func NewPostgresProductSupplierRepository(pool *pgxpool.Pool, timeout time.Duration) ProductSupplierRepository {
+	return &postgresProductSupplierRepo{pool: pool, timeout: timeout}
+}
+
+func scanProductSupplier(row pgx.Row) (*domain.ProductSupplier, error) {
+	var d productSupplierDocument
+	err := row.Scan(&d.ProductID, &d.SupplierID, &d.SupplierSKU, &d.UnitCost, &d.LeadTimeDays, &d.MinOrderQuantity)
+	if err != nil {
+		return nil, err
+	}
+	return d.toDomain(), nil
+}
+
+func (r *postgresProductSupplierRepo) Upsert(ctx context.Context, ps *domain.ProductSupplier) error {
+	ctx, span := postgresStartSpan(ctx, "product_suppliers", "INSERT",
+		attribute.String("product.id", ps.ProductID), attribute.String("supplier.id", ps.SupplierID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	d := newProductSupplierDocument(ps)
+	_, err := r.pool.Exec(ctx, `
+INSERT INTO product_suppliers (`+productSupplierColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (product_id, supplier_id) DO UPDATE SET
+	supplier_sku = excluded.supplier_sku,
+	unit_cost = excluded.unit_cost,
+	lead_time_days = excluded.lead_time_days,
+	min_order_quantity = excluded.min_order_quantity`,
+		d.ProductID, d.SupplierID, d.SupplierSKU, d.UnitCost, d.LeadTimeDays, d.MinOrderQuantity)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *postgresProductSupplierRepo) Delete(ctx context.Context, productID, supplierID string) error {
+	ctx, span := postgresStartSpan(ctx, "product_suppliers", "DELETE",
+		attribute.String("product.id", productID), attribute.String("supplier.id", supplierID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	tag, err := r.pool.Exec(ctx,
+		`DELETE FROM product_suppliers WHERE product_id = $1 AND supplier_id = $2`, productID, supplierID)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = domain.ErrNotFound
+	}
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (r *postgresProductSupplierRepo) ListBySupplier(ctx context.Context, supplierID string) ([]*domain.ProductSupplier, error) {
+	ctx, span := postgresStartSpan(ctx, "product_suppliers", "SELECT", attribute.String("supplier.id", supplierID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	links, err := queryRows(ctx, r.pool, scanProductSupplier, `
+SELECT `+productSupplierColumns+` FROM product_suppliers WHERE supplier_id = $1 ORDER BY product_id`, supplierID)
+	tracing.RecordError(span, err)
+	return links, err
+}
+
+func (r *postgresProductSupplierRepo) ListByProduct(ctx context.Context, productID string) ([]*domain.ProductSupplier, error) {
+	ctx, span := postgresStartSpan(ctx, "product_suppliers", "SELECT", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	links, err := queryRows(ctx, r.pool, scanProductSupplier, `
+SELECT `+productSupplierColumns+` FROM product_suppliers WHERE product_id = $1 ORDER BY unit_cost, supplier_id`, productID)
+	tracing.RecordError(span, err)
+	return links, err
+}
+
+func (r *postgresProductSupplierRepo) Cheapest(ctx context.Context, productID string, quantity int32) (*domain.ProductSupplier, error) {
+	ctx, span := postgresStartSpan(ctx, "product_suppliers", "SELECT", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	link, err := scanProductSupplier(r.pool.QueryRow(ctx, `
+SELECT `+productSupplierColumns+` FROM product_suppliers
+WHERE product_id = $1 AND ($2 <= 0 OR min_order_quantity <= $2)
+ORDER BY unit_cost, lead_time_days, supplier_id
+LIMIT 1`, productID, quantity))
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return link, nil
+}
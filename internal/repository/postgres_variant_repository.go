@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const variantColumns = `id, product_id, sku, attributes, price_override, stock, created_at`
+
+type postgresVariantRepo struct {
+	pool    *pgxpool.Pool
+	timeout time.Duration
+}
+
+func NewPostgresVariantRepository(pool *pgxpool.Pool, timeout time.Duration) VariantRepository {
+	return &postgresVariantRepo{pool: pool, timeout: timeout}
+}
+
+func scanVariant(row pgx.Row) (*domain.Variant, error) {
+	var (
+		d  variantDocument
+		id string
+	)
+	if err := row.Scan(&id, &d.ProductID, &d.SKU, &d.Attributes, &d.PriceOverride, &d.Stock, &d.CreatedAt); err != nil {
+		return nil, err
+	}
+	d.ID, d.CreatedAt = objectID(id), d.CreatedAt.UTC()
+	return d.toDomain(), nil
+}
+
+func (r *postgresVariantRepo) Create(ctx context.Context, v *domain.Variant) (string, error) {
+	ctx, span := postgresStartSpan(ctx, "product_variants", "INSERT", attribute.String("product.id", v.ProductID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	d := newVariantDocument(v)
+	d.ID = primitive.NewObjectID()
+	_, err := r.pool.Exec(ctx, `INSERT INTO product_variants (`+variantColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		d.ID.Hex(), d.ProductID, d.SKU, d.Attributes, d.PriceOverride, d.Stock, d.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			err = fmt.Errorf("%w: SKU %s is already in use", domain.ErrInvalidArgument, v.SKU)
+		}
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("variant.id", d.ID.Hex()))
+	return d.ID.Hex(), nil
+}
+
+func (r *postgresVariantRepo) GetByID(ctx context.Context, id string) (*domain.Variant, error) {
+	return r.getOne(ctx, "id", id, attribute.String("variant.id", id))
+}
+
+func (r *postgresVariantRepo) GetBySKU(ctx context.Context, sku string) (*domain.Variant, error) {
+	return r.getOne(ctx, "sku", sku, attribute.String("variant.sku", sku))
+}
+
+func (r *postgresVariantRepo) getOne(ctx context.Context, column, value string, attr attribute.KeyValue) (*domain.Variant, error) {
+	ctx, span := postgresStartSpan(ctx, "product_variants", "SELECT", attr)
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	v, err := scanVariant(r.pool.QueryRow(ctx,
+		`SELECT `+variantColumns+` FROM product_variants WHERE `+column+` = $1`, value))
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return v, nil
+}
+
+func (r *postgresVariantRepo) ListByProduct(ctx context.Context, productID string) ([]*domain.Variant, error) {
+	ctx, span := postgresStartSpan(ctx, "product_variants", "SELECT", attribute.String("product.id", productID))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	variants, err := queryRows(ctx, r.pool, scanVariant,
+		`SELECT `+variantColumns+` FROM product_variants WHERE product_id = $1 ORDER BY sku`, productID)
+	tracing.RecordError(span, err)
+	return variants, err
+}
+
+func (r *postgresVariantRepo) AdjustStock(ctx context.Context, id string, delta int32) (*domain.Variant, error) {
+	ctx, span := postgresStartSpan(ctx, "product_variants", "UPDATE",
+		attribute.String("variant.id", id), attribute.Int("stock.delta", int(delta)))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	v, err := scanVariant(r.pool.QueryRow(ctx, `
+UPDATE product_variants SET stock = stock + $2
+WHERE id = $1 AND stock + $2 >= 0
+RETURNING `+variantColumns, id, delta))
+	if errors.Is(err, pgx.ErrNoRows) && delta < 0 {
+		var exists bool
+		if qerr := r.pool.QueryRow(ctx,
+			`SELECT EXISTS (SELECT 1 FROM product_variants WHERE id = $1)`, id).Scan(&exists); qerr == nil && exists {
+			err = domain.ErrInsufficientStock
+		}
+	}
+	if err != nil {
+		err = mapNoRows(err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return v, nil
+}
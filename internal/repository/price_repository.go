@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+type PriceHistoryRepository interface {
+	// Record closes the product's current record at r.EffectiveFrom and
+	// makes r the current one.
+	Record(ctx context.Context, r *domain.PriceRecord) error
+	// At returns the record in effect at t, or ErrNotFound.
+	At(ctx context.Context, productID string, t time.Time) (*domain.PriceRecord, error)
+	// ListByProduct returns the price history of a product, newest first.
+	ListByProduct(ctx context.Context, productID string) ([]*domain.PriceRecord, error)
+}
+
+type ScheduledPriceRepository interface {
+	Create(ctx context.Context, s *domain.ScheduledPrice) (string, error)
+	GetByID(ctx context.Context, id string) (*domain.ScheduledPrice, error)
+	// ListByProduct returns the scheduled changes of a product ordered by
+	// effective_from, limited to the given statuses when any are given.
+	ListByProduct(ctx context.Context, productID string, statuses ...domain.ScheduledPriceStatus) ([]*domain.ScheduledPrice, error)
+	// ListDue returns pending changes whose window has started, active
+	// changes whose window has ended by now and changes whose history is
+	// pending.
+	ListDue(ctx context.Context, now time.Time) ([]*domain.ScheduledPrice, error)
+	// Transition moves a change from one status to another, storing the
+	// given previous price and history flag, and reports whether it was
+	// still in from.
+	Transition(ctx context.Context, id string, from, to domain.ScheduledPriceStatus, previousPrice float64, historyPending bool) (bool, error)
+	// ClearHistoryPending records that the price a change set has been
+	// written to the price history.
+	ClearHistoryPending(ctx context.Context, id string) error
+}
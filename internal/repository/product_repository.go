@@ -1,10 +1,50 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/facelessEmptiness/inventory_service/internal/domain"
 )
 
+// ProductRepository treats archived (soft-deleted) products as missing
+// unless a method says otherwise.
 type ProductRepository interface {
-	Create(p *domain.Product) (string, error)
-	GetByID(id string) (*domain.Product, error)
+	Create(ctx context.Context, p *domain.Product) (string, error)
+	GetByID(ctx context.Context, id string) (*domain.Product, error)
+	GetByIDIncludingDeleted(ctx context.Context, id string) (*domain.Product, error)
+	// GetMany returns the products matching any of the ids or SKUs in one
+	// query; ids and SKUs that match nothing are simply left out.
+	GetMany(ctx context.Context, ids, skus []string) ([]*domain.Product, error)
+	List(ctx context.Context, includeDeleted bool) ([]*domain.Product, error)
+	// Search returns at most limit products whose name or description
+	// contains every word of query, ignoring case, or whose SKU is query.
+	// MongoDB matches whole, stemmed words through its text index; the
+	// other stores also match parts of words and of the SKU.
+	Search(ctx context.Context, query string, limit int) ([]*domain.Product, error)
+	// Update applies u and returns the product as it was just before and
+	// just after, both read atomically with the change.
+	Update(ctx context.Context, id string, u *domain.ProductUpdate) (before, after *domain.Product, err error)
+	AdjustStock(ctx context.Context, id string, delta int32) (*domain.Product, error)
+	// AdjustDamagedStock works like AdjustStock on the damaged bucket.
+	AdjustDamagedStock(ctx context.Context, id string, delta int32) (*domain.Product, error)
+	Count(ctx context.Context) (int64, error)
+	CountOutOfStock(ctx context.Context) (int64, error)
+	ListLowStock(ctx context.Context) ([]*domain.Product, error)
+	// SetLowStockAlerted flips the low-stock alert flag of a product and
+	// reports whether this call changed it, so that concurrent evaluators
+	// raise at most one alert per dip.
+	SetLowStockAlerted(ctx context.Context, id string, alerted bool) (bool, error)
+	// SoftDelete archives a product; ErrNotFound is returned for missing
+	// and already archived products alike.
+	SoftDelete(ctx context.Context, id, deletedBy string, at time.Time) (*domain.Product, error)
+	// Restore brings an archived product back; ErrFailedPrecondition is
+	// returned when it is not archived.
+	Restore(ctx context.Context, id string) (*domain.Product, error)
+	// ListDeleted returns the products archived before the given time.
+	ListDeleted(ctx context.Context, before time.Time) ([]*domain.Product, error)
+	// PurgeDeleted permanently removes those of the given products that were
+	// archived before the given time and returns the ids of those removed.
+	// Products restored or archived again since are left alone.
+	PurgeDeleted(ctx context.Context, ids []string, before time.Time) ([]string, error)
 }
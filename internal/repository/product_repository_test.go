@@ -0,0 +1,345 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/migrate"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+)
+
+// missingID is well formed for every store yet names no product.
+const missingID = "ffffffffffffffffffffffff"
+
+func TestBoltProductRepository(t *testing.T) {
+	testProductRepository(t, func(t *testing.T) repository.ProductRepository {
+		db, err := bolt.Open(filepath.Join(t.TempDir(), "inventory.db"), 0o600, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+		repo, err := repository.NewBoltProductRepository(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repo
+	})
+}
+
+// TestMongoProductRepository runs against the server at TEST_MONGO_URI, in a
+// database of its own that is dropped afterwards.
+func TestMongoProductRepository(t *testing.T) {
+	uri := os.Getenv("TEST_MONGO_URI")
+	if uri == "" {
+		t.Skip("TEST_MONGO_URI is not set")
+	}
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Disconnect(ctx) })
+
+	n := 0
+	testProductRepository(t, func(t *testing.T) repository.ProductRepository {
+		n++
+		db := client.Database(fmt.Sprintf("inventory_test_%d_%d", time.Now().UnixNano(), n))
+		t.Cleanup(func() { db.Drop(ctx) })
+		if err := migrate.EnsureIndexes(ctx, db, "products"); err != nil {
+			t.Fatal(err)
+		}
+		return repository.NewMongoProductRepository(db, "products", 5*time.Second)
+	})
+}
+
+// TestPostgresProductRepository runs against the database at
+// TEST_POSTGRES_URL, whose products table it empties before every case.
+func TestPostgresProductRepository(t *testing.T) {
+	url := os.Getenv("TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("TEST_POSTGRES_URL is not set")
+	}
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	if _, err := migrate.UpPostgres(ctx, pool, migrate.PostgresMigrations()); err != nil {
+		t.Fatal(err)
+	}
+
+	testProductRepository(t, func(t *testing.T) repository.ProductRepository {
+		if _, err := pool.Exec(ctx, `TRUNCATE products`); err != nil {
+			t.Fatal(err)
+		}
+		return repository.NewPostgresProductRepository(pool, 5*time.Second)
+	})
+}
+
+// testProductRepository checks the behaviour every ProductRepository must
+// share; newRepo returns an empty store.
+func testProductRepository(t *testing.T, newRepo func(t *testing.T) repository.ProductRepository) {
+	ctx := context.Background()
+
+	create := func(t *testing.T, repo repository.ProductRepository, p domain.Product) *domain.Product {
+		t.Helper()
+		id, err := repo.Create(ctx, &p)
+		if err != nil {
+			t.Fatalf("Create(%q): %v", p.Name, err)
+		}
+		got, err := repo.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("GetByID(%s): %v", id, err)
+		}
+		return got
+	}
+
+	t.Run("CreateAndGet", func(t *testing.T) {
+		repo := newRepo(t)
+		p := create(t, repo, domain.Product{SKU: "W-1", Name: "Widget", Description: "A widget", Price: 2.5, Stock: 7, CategoryID: "tools"})
+		if p.SKU != "W-1" || p.Name != "Widget" || p.Description != "A widget" || p.Price != 2.5 || p.Stock != 7 || p.CategoryID != "tools" {
+			t.Errorf("GetByID = %+v", p)
+		}
+		if _, err := repo.GetByID(ctx, missingID); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("GetByID(missing) error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("AdjustStock", func(t *testing.T) {
+		repo := newRepo(t)
+		p := create(t, repo, domain.Product{Name: "Widget", Stock: 5})
+
+		got, err := repo.AdjustStock(ctx, p.ID, -2)
+		if err != nil || got.Stock != 3 {
+			t.Fatalf("AdjustStock(-2) = %v, %v; want stock 3", got, err)
+		}
+		if _, err := repo.AdjustStock(ctx, p.ID, -4); !errors.Is(err, domain.ErrInsufficientStock) {
+			t.Errorf("AdjustStock(-4) error = %v, want ErrInsufficientStock", err)
+		}
+		if _, err := repo.AdjustStock(ctx, missingID, 1); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("AdjustStock(missing) error = %v, want ErrNotFound", err)
+		}
+		if _, err := repo.AdjustStock(ctx, missingID, -1); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("AdjustStock(missing, -1) error = %v, want ErrNotFound", err)
+		}
+		if _, err := repo.AdjustDamagedStock(ctx, p.ID, -1); !errors.Is(err, domain.ErrInsufficientStock) {
+			t.Errorf("AdjustDamagedStock(-1) error = %v, want ErrInsufficientStock", err)
+		}
+		if got, err := repo.GetByID(ctx, p.ID); err != nil || got.Stock != 3 || got.DamagedStock != 0 {
+			t.Errorf("after rejected adjustments = %v, %v; want stock 3, damaged 0", got, err)
+		}
+	})
+
+	t.Run("AdjustStockConcurrently", func(t *testing.T) {
+		repo := newRepo(t)
+		p := create(t, repo, domain.Product{Name: "Widget", Stock: 10})
+
+		var (
+			wg                 sync.WaitGroup
+			mu                 sync.Mutex
+			succeeded, refused int
+		)
+		for i := 0; i < 25; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repo.AdjustStock(ctx, p.ID, -1)
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err == nil:
+					succeeded++
+				case errors.Is(err, domain.ErrInsufficientStock):
+					refused++
+				default:
+					t.Errorf("AdjustStock(-1): %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+		if succeeded != 10 || refused != 15 {
+			t.Errorf("succeeded %d, refused %d; want 10 and 15", succeeded, refused)
+		}
+		if got, err := repo.GetByID(ctx, p.ID); err != nil || got.Stock != 0 {
+			t.Errorf("final stock = %v, %v; want 0", got, err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepo(t)
+		p := create(t, repo, domain.Product{Name: "Widget", Price: 2, Stock: 5})
+
+		price := 3.5
+		before, after, err := repo.Update(ctx, p.ID, &domain.ProductUpdate{Price: &price})
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if before.Price != 2 || after.Price != 3.5 || before.Name != "Widget" || after.Name != "Widget" || after.Stock != 5 {
+			t.Errorf("Update = %+v, %+v; want price 2 before and 3.5 after, other fields kept", before, after)
+		}
+		if got, err := repo.GetByID(ctx, p.ID); err != nil || got.Price != 3.5 {
+			t.Errorf("GetByID(updated) = %+v, %v; want price 3.5", got, err)
+		}
+		if _, _, err := repo.Update(ctx, missingID, &domain.ProductUpdate{Price: &price}); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Update(missing) error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("UniqueSKU", func(t *testing.T) {
+		repo := newRepo(t)
+		create(t, repo, domain.Product{SKU: "W-1", Name: "Widget"})
+		if _, err := repo.Create(ctx, &domain.Product{SKU: "W-1", Name: "Other"}); !errors.Is(err, domain.ErrInvalidArgument) {
+			t.Errorf("Create(duplicate SKU) error = %v, want ErrInvalidArgument", err)
+		}
+		// Any number of products may go without a SKU.
+		create(t, repo, domain.Product{Name: "Loose one"})
+		create(t, repo, domain.Product{Name: "Loose two"})
+	})
+
+	t.Run("GetMany", func(t *testing.T) {
+		repo := newRepo(t)
+		a := create(t, repo, domain.Product{SKU: "A", Name: "Alpha"})
+		b := create(t, repo, domain.Product{SKU: "B", Name: "Beta"})
+		create(t, repo, domain.Product{SKU: "C", Name: "Gamma"})
+
+		got, err := repo.GetMany(ctx, []string{a.ID, missingID}, []string{"B", "nope"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ids, want := productIDs(got), sortedIDs(a.ID, b.ID); fmt.Sprint(ids) != fmt.Sprint(want) {
+			t.Errorf("GetMany = %v, want %v", ids, want)
+		}
+		if got, err := repo.GetMany(ctx, nil, nil); err != nil || len(got) != 0 {
+			t.Errorf("GetMany(nothing) = %v, %v; want none", got, err)
+		}
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		repo := newRepo(t)
+		redWidget := create(t, repo, domain.Product{SKU: "RW-1", Name: "Red widget", Description: "Shiny"})
+		create(t, repo, domain.Product{SKU: "BW-1", Name: "Blue widget"})
+		create(t, repo, domain.Product{SKU: "RG-1", Name: "Red gadget"})
+		archived := create(t, repo, domain.Product{Name: "Red widget", Description: "Old"})
+		if _, err := repo.SoftDelete(ctx, archived.ID, "tester", time.Now()); err != nil {
+			t.Fatal(err)
+		}
+
+		cases := []struct {
+			query string
+			limit int
+			want  []string
+		}{
+			{"red widget", 10, []string{redWidget.ID}},
+			{"WIDGET shiny", 10, []string{redWidget.ID}},
+			{"RW-1", 10, []string{redWidget.ID}},
+			{"purple", 10, nil},
+			{"   ", 10, nil},
+		}
+		for _, c := range cases {
+			got, err := repo.Search(ctx, c.query, c.limit)
+			if err != nil {
+				t.Errorf("Search(%q): %v", c.query, err)
+				continue
+			}
+			if ids, want := productIDs(got), sortedIDs(c.want...); fmt.Sprint(ids) != fmt.Sprint(want) {
+				t.Errorf("Search(%q) = %v, want %v", c.query, ids, want)
+			}
+		}
+		if got, err := repo.Search(ctx, "widget", 1); err != nil || len(got) != 1 {
+			t.Errorf("Search(widget, 1) = %v, %v; want one product", got, err)
+		}
+	})
+
+	t.Run("SoftDeleteRestorePurge", func(t *testing.T) {
+		repo := newRepo(t)
+		p := create(t, repo, domain.Product{SKU: "W-1", Name: "Widget"})
+		kept := create(t, repo, domain.Product{Name: "Kept"})
+		at := time.Now().Truncate(time.Millisecond)
+
+		if _, err := repo.SoftDelete(ctx, p.ID, "tester", at); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.GetByID(ctx, p.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("GetByID(archived) error = %v, want ErrNotFound", err)
+		}
+		if got, err := repo.GetByIDIncludingDeleted(ctx, p.ID); err != nil || got.DeletedAt.IsZero() || got.DeletedBy != "tester" {
+			t.Errorf("GetByIDIncludingDeleted = %+v, %v; want archived by tester", got, err)
+		}
+		if _, err := repo.SoftDelete(ctx, p.ID, "tester", at); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("SoftDelete(archived) error = %v, want ErrNotFound", err)
+		}
+		if got, err := repo.List(ctx, false); err != nil || fmt.Sprint(productIDs(got)) != fmt.Sprint([]string{kept.ID}) {
+			t.Errorf("List(false) = %v, %v; want only the kept product", productIDs(got), err)
+		}
+		if got, err := repo.List(ctx, true); err != nil || len(got) != 2 {
+			t.Errorf("List(true) = %v, %v; want both products", productIDs(got), err)
+		}
+
+		if _, err := repo.Restore(ctx, p.ID); err != nil {
+			t.Fatalf("Restore: %v", err)
+		}
+		if got, err := repo.GetByID(ctx, p.ID); err != nil || !got.DeletedAt.IsZero() {
+			t.Errorf("GetByID(restored) = %+v, %v", got, err)
+		}
+		if _, err := repo.Restore(ctx, p.ID); !errors.Is(err, domain.ErrFailedPrecondition) {
+			t.Errorf("Restore(active) error = %v, want ErrFailedPrecondition", err)
+		}
+
+		if _, err := repo.SoftDelete(ctx, p.ID, "tester", at); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := repo.ListDeleted(ctx, at.Add(-time.Second)); err != nil || len(got) != 0 {
+			t.Errorf("ListDeleted(before archiving) = %v, %v; want none", productIDs(got), err)
+		}
+		if got, err := repo.ListDeleted(ctx, at.Add(time.Second)); err != nil || fmt.Sprint(productIDs(got)) != fmt.Sprint([]string{p.ID}) {
+			t.Errorf("ListDeleted(after archiving) = %v, %v; want the archived product", productIDs(got), err)
+		}
+		if ids, err := repo.PurgeDeleted(ctx, []string{p.ID}, at.Add(-time.Second)); err != nil || len(ids) != 0 {
+			t.Errorf("PurgeDeleted(before archiving) = %v, %v; want none", ids, err)
+		}
+		// Only archived products among those named are purged.
+		if ids, err := repo.PurgeDeleted(ctx, []string{kept.ID, missingID}, at.Add(time.Second)); err != nil || len(ids) != 0 {
+			t.Errorf("PurgeDeleted(active and missing) = %v, %v; want none", ids, err)
+		}
+		if ids, err := repo.PurgeDeleted(ctx, []string{kept.ID, p.ID}, at.Add(time.Second)); err != nil || fmt.Sprint(ids) != fmt.Sprint([]string{p.ID}) {
+			t.Errorf("PurgeDeleted(after archiving) = %v, %v; want the archived product", ids, err)
+		}
+		if _, err := repo.GetByIDIncludingDeleted(ctx, p.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("GetByIDIncludingDeleted(purged) error = %v, want ErrNotFound", err)
+		}
+		if _, err := repo.GetByID(ctx, kept.ID); err != nil {
+			t.Errorf("GetByID(kept): %v", err)
+		}
+		// Purging frees the SKU.
+		create(t, repo, domain.Product{SKU: "W-1", Name: "Widget again"})
+	})
+}
+
+func productIDs(products []*domain.Product) []string {
+	ids := make([]string, 0, len(products))
+	for _, p := range products {
+		ids = append(ids, p.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func sortedIDs(ids ...string) []string {
+	out := append([]string{}, ids...)
+	sort.Strings(out)
+	return out
+}
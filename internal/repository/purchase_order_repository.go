@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+type PurchaseOrderRepository interface {
+	Create(ctx context.Context, po *domain.PurchaseOrder) (string, error)
+	GetByID(ctx context.Context, id string) (*domain.PurchaseOrder, error)
+	// ListByStatus returns the purchase orders in any of the given statuses.
+	ListByStatus(ctx context.Context, statuses ...domain.PurchaseOrderStatus) ([]*domain.PurchaseOrder, error)
+	// Update stores po if it still has the version it was read with and
+	// returns ErrConflict otherwise. On success po.Version is incremented.
+	Update(ctx context.Context, po *domain.PurchaseOrder) error
+}
@@ -0,0 +1,258 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/migrate"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+)
+
+// stores holds the repositories of one empty store.
+type stores struct {
+	lots         repository.LotRepository
+	serials      repository.SerialRepository
+	reservations repository.ReservationRepository
+	orders       repository.PurchaseOrderRepository
+	variants     repository.VariantRepository
+	history      repository.PriceHistoryRepository
+}
+
+func openBolt(t *testing.T) *bolt.DB {
+	t.Helper()
+	db, err := bolt.Open(filepath.Join(t.TempDir(), "inventory.db"), 0o600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func newBoltRepository[R any](t *testing.T, db *bolt.DB, create func(*bolt.DB) (R, error)) R {
+	t.Helper()
+	r, err := create(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestBoltRepositories(t *testing.T) {
+	testRepositories(t, func(t *testing.T) stores {
+		db := openBolt(t)
+		return stores{
+			lots:         newBoltRepository(t, db, repository.NewBoltLotRepository),
+			serials:      newBoltRepository(t, db, repository.NewBoltSerialRepository),
+			reservations: newBoltRepository(t, db, repository.NewBoltReservationRepository),
+			orders:       newBoltRepository(t, db, repository.NewBoltPurchaseOrderRepository),
+			variants:     newBoltRepository(t, db, repository.NewBoltVariantRepository),
+			history:      newBoltRepository(t, db, repository.NewBoltPriceHistoryRepository),
+		}
+	})
+}
+
+// TestPostgresRepositories runs against the database at TEST_POSTGRES_URL,
+// whose tables it empties before every case.
+func TestPostgresRepositories(t *testing.T) {
+	url := os.Getenv("TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("TEST_POSTGRES_URL is not set")
+	}
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	if _, err := migrate.UpPostgres(ctx, pool, migrate.PostgresMigrations()); err != nil {
+		t.Fatal(err)
+	}
+
+	testRepositories(t, func(t *testing.T) stores {
+		if _, err := pool.Exec(ctx, `
+TRUNCATE lots, serial_units, reservations, purchase_orders, product_variants, price_history`); err != nil {
+			t.Fatal(err)
+		}
+		return stores{
+			lots:         repository.NewPostgresLotRepository(pool, 5*time.Second),
+			serials:      repository.NewPostgresSerialRepository(pool, 5*time.Second),
+			reservations: repository.NewPostgresReservationRepository(pool, 5*time.Second),
+			orders:       repository.NewPostgresPurchaseOrderRepository(pool, 5*time.Second),
+			variants:     repository.NewPostgresVariantRepository(pool, 5*time.Second),
+			history:      repository.NewPostgresPriceHistoryRepository(pool, 5*time.Second),
+		}
+	})
+}
+
+// testRepositories checks the conditional updates the use cases rely on;
+// newStores returns the repositories of an empty store. MongoDB is left out
+// because its serial batches are not atomic.
+func testRepositories(t *testing.T, newStores func(t *testing.T) stores) {
+	ctx := context.Background()
+	at := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("lots", func(t *testing.T) {
+		lots := newStores(t).lots
+		lot := &domain.Lot{ProductID: "p1", LotNumber: "L1", ExpiresAt: at.AddDate(0, 1, 0), Quantity: 5, Status: domain.LotAvailable, Booked: true}
+		id, err := lots.Create(ctx, lot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lots.Create(ctx, lot); !errors.Is(err, domain.ErrInvalidArgument) {
+			t.Errorf("Create of a taken lot number error = %v, want ErrInvalidArgument", err)
+		}
+		if got, err := lots.GetByNumber(ctx, "p1", "L1"); err != nil || got.ID != id {
+			t.Errorf("GetByNumber = %v, %v; want lot %s", got, err, id)
+		}
+		if ok, err := lots.Take(ctx, id, 6); ok || err != nil {
+			t.Errorf("Take(6) of 5 = %v, %v; want false", ok, err)
+		}
+		if ok, err := lots.Take(ctx, id, 5); !ok || err != nil {
+			t.Errorf("Take(5) of 5 = %v, %v; want true", ok, err)
+		}
+		if got, _ := lots.ListAllocatable(ctx, "p1", at); len(got) != 0 {
+			t.Errorf("ListAllocatable of an empty lot = %v, want none", got)
+		}
+		if ok, err := lots.SetBooked(ctx, id, true); ok || err != nil {
+			t.Errorf("SetBooked(true) of a booked lot = %v, %v; want false", ok, err)
+		}
+		if _, ok, err := lots.Quarantine(ctx, id); !ok || err != nil {
+			t.Errorf("Quarantine = %v, %v; want true", ok, err)
+		}
+		if _, ok, err := lots.Quarantine(ctx, id); ok || err != nil {
+			t.Errorf("second Quarantine = %v, %v; want false", ok, err)
+		}
+	})
+
+	t.Run("serials", func(t *testing.T) {
+		serials := newStores(t).serials
+		units := []*domain.SerialUnit{
+			{ProductID: "p1", Serial: "S1", Status: domain.SerialAvailable, Location: "A", Booked: true, ReceivedAt: at},
+			{ProductID: "p1", Serial: "S2", Status: domain.SerialAvailable, Booked: true, ReceivedAt: at.Add(time.Hour)},
+		}
+		if err := serials.CreateMany(ctx, units); err != nil {
+			t.Fatal(err)
+		}
+		taken := []*domain.SerialUnit{{ProductID: "p1", Serial: "S3"}, {ProductID: "p1", Serial: "S1"}}
+		if err := serials.CreateMany(ctx, taken); !errors.Is(err, domain.ErrInvalidArgument) {
+			t.Errorf("CreateMany with a taken serial error = %v, want ErrInvalidArgument", err)
+		}
+		if got, _ := serials.FindExisting(ctx, []string{"S1", "S3"}); len(got) != 1 || got[0] != "S1" {
+			t.Errorf("FindExisting = %v, want [S1]: a rejected batch stores nothing", got)
+		}
+		if got, _ := serials.ListAllocatable(ctx, "p1", 1); len(got) != 1 || got[0].Serial != "S1" {
+			t.Errorf("ListAllocatable(1) = %v, want the oldest unit", got)
+		}
+		sold := *units[0]
+		sold.Status, sold.OrderID = domain.SerialSold, "o1"
+		if ok, err := serials.Transition(ctx, &sold, domain.SerialAvailable); !ok || err != nil {
+			t.Errorf("Transition = %v, %v; want true", ok, err)
+		}
+		if ok, err := serials.Transition(ctx, &sold, domain.SerialAvailable); ok || err != nil {
+			t.Errorf("second Transition = %v, %v; want false", ok, err)
+		}
+		if ok, err := serials.SetBooked(ctx, "S2", false); !ok || err != nil {
+			t.Errorf("SetBooked(false) = %v, %v; want true", ok, err)
+		}
+		if ok, err := serials.SetBooked(ctx, "S9", false); ok || err != nil {
+			t.Errorf("SetBooked of a missing serial = %v, %v; want false", ok, err)
+		}
+		counts, err := serials.CountAvailableByLocation(ctx, []string{"p1"})
+		if err != nil || len(counts) != 0 {
+			t.Errorf("CountAvailableByLocation = %v, %v; want nothing available and booked", counts, err)
+		}
+	})
+
+	t.Run("reservations", func(t *testing.T) {
+		reservations := newStores(t).reservations
+		id, err := reservations.Create(ctx, &domain.Reservation{
+			BundleID: "b1", Quantity: 1, Status: domain.ReservationActive, CreatedAt: at,
+			Lines: []domain.ReservationLine{{ProductID: "p1", Quantity: 2}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := reservations.Release(ctx, id, at); !ok || err != nil {
+			t.Errorf("Release = %v, %v; want true", ok, err)
+		}
+		if ok, err := reservations.Release(ctx, id, at); ok || err != nil {
+			t.Errorf("second Release = %v, %v; want false", ok, err)
+		}
+		if ok, err := reservations.SetLineReleased(ctx, id, "p1", true); ok || err != nil {
+			t.Errorf("SetLineReleased(true) on a released reservation = %v, %v; want false", ok, err)
+		}
+		if ok, err := reservations.SetLineReleased(ctx, id, "p1", false); ok || err != nil {
+			t.Errorf("SetLineReleased(false) of an unreleased line = %v, %v; want false", ok, err)
+		}
+		if got, _ := reservations.List(ctx, domain.ReservationReleased, "p1"); len(got) != 1 || !got[0].ReleasedAt.Equal(at) {
+			t.Errorf("List(released, p1) = %v, want the reservation released at %v", got, at)
+		}
+	})
+
+	t.Run("purchase order versions", func(t *testing.T) {
+		orders := newStores(t).orders
+		po := &domain.PurchaseOrder{SupplierID: "s1", Status: domain.PurchaseOrderDraft}
+		id, err := orders.Create(ctx, po)
+		if err != nil {
+			t.Fatal(err)
+		}
+		po.ID = id
+		stale := *po
+		po.Status = domain.PurchaseOrderSent
+		if err := orders.Update(ctx, po); err != nil || po.Version != 1 {
+			t.Fatalf("Update = %v, version %d; want version 1", err, po.Version)
+		}
+		if err := orders.Update(ctx, &stale); !errors.Is(err, domain.ErrConflict) {
+			t.Errorf("Update of a stale order error = %v, want ErrConflict", err)
+		}
+		if got, _ := orders.ListByStatus(ctx, domain.PurchaseOrderSent); len(got) != 1 || got[0].Version != 1 {
+			t.Errorf("ListByStatus(sent) = %v, want the updated order", got)
+		}
+	})
+
+	t.Run("variants", func(t *testing.T) {
+		variants := newStores(t).variants
+		id, err := variants.Create(ctx, &domain.Variant{ProductID: "p1", SKU: "V1", Stock: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := variants.Create(ctx, &domain.Variant{ProductID: "p2", SKU: "V1"}); !errors.Is(err, domain.ErrInvalidArgument) {
+			t.Errorf("Create with a taken SKU error = %v, want ErrInvalidArgument", err)
+		}
+		if _, err := variants.AdjustStock(ctx, id, -3); !errors.Is(err, domain.ErrInsufficientStock) {
+			t.Errorf("AdjustStock(-3) of 2 error = %v, want ErrInsufficientStock", err)
+		}
+		if _, err := variants.AdjustStock(ctx, missingID, 1); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("AdjustStock of a missing variant error = %v, want ErrNotFound", err)
+		}
+		if v, err := variants.GetBySKU(ctx, "V1"); err != nil || v.Stock != 2 {
+			t.Errorf("GetBySKU = %v, %v; want stock 2", v, err)
+		}
+	})
+
+	t.Run("price history", func(t *testing.T) {
+		history := newStores(t).history
+		for i, price := range []float64{10, 12} {
+			if err := history.Record(ctx, &domain.PriceRecord{ProductID: "p1", Price: price, EffectiveFrom: at.AddDate(0, 0, i)}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if r, err := history.At(ctx, "p1", at.Add(time.Hour)); err != nil || r.Price != 10 {
+			t.Errorf("At the first day = %v, %v; want price 10", r, err)
+		}
+		if r, err := history.At(ctx, "p1", at.AddDate(0, 1, 0)); err != nil || r.Price != 12 {
+			t.Errorf("At a later day = %v, %v; want price 12", r, err)
+		}
+		if _, err := history.At(ctx, "p1", at.Add(-time.Hour)); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("At before the history error = %v, want ErrNotFound", err)
+		}
+	})
+}
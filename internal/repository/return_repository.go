@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+type ReturnRepository interface {
+	Create(ctx context.Context, r *domain.Return) (string, error)
+	GetByID(ctx context.Context, id string) (*domain.Return, error)
+	ListByOrder(ctx context.Context, orderID string) ([]*domain.Return, error)
+	// Update saves the return if its version is unchanged and returns
+	// ErrConflict otherwise.
+	Update(ctx context.Context, r *domain.Return) error
+}
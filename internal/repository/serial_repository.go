@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+type SerialRepository interface {
+	CreateMany(ctx context.Context, units []*domain.SerialUnit) error
+	// FindExisting returns which of the given serial numbers already exist.
+	FindExisting(ctx context.Context, serials []string) ([]string, error)
+	GetBySerial(ctx context.Context, serial string) (*domain.SerialUnit, error)
+	// ListByProduct returns the units of a product, oldest first, optionally
+	// limited to one status.
+	ListByProduct(ctx context.Context, productID string, status domain.SerialStatus, limit int64) ([]*domain.SerialUnit, error)
+	// ListAllocatable returns up to limit available, booked units of a
+	// product, oldest first.
+	ListAllocatable(ctx context.Context, productID string, limit int64) ([]*domain.SerialUnit, error)
+	// Transition moves a booked unit from one status to another, storing the
+	// given unit's order and location, and reports whether the unit was still
+	// in the expected status.
+	Transition(ctx context.Context, u *domain.SerialUnit, from domain.SerialStatus) (bool, error)
+	// SetBooked sets the booked flag of a unit and reports whether this call
+	// changed it.
+	SetBooked(ctx context.Context, serial string, booked bool) (bool, error)
+	CountByStatus(ctx context.Context, productID string) (map[domain.SerialStatus]int64, error)
+	// CountAvailableByLocation counts the available, booked units of the
+	// given products per product and location.
+	CountAvailableByLocation(ctx context.Context, productIDs []string) (map[string]map[string]int32, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+type StockMovementRepository interface {
+	Record(ctx context.Context, m *domain.StockMovement) error
+	// ListByProducts returns the movements of the given products, oldest
+	// first.
+	ListByProducts(ctx context.Context, productIDs []string) ([]*domain.StockMovement, error)
+	// ListByReasons returns the movements with any of the given reasons
+	// recorded since the given time, of the given products or, when none
+	// are given, of every product.
+	ListByReasons(ctx context.Context, productIDs []string, reasons []domain.MovementReason, since time.Time) ([]*domain.StockMovement, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+type SupplierRepository interface {
+	Create(ctx context.Context, s *domain.Supplier) (string, error)
+	GetByID(ctx context.Context, id string) (*domain.Supplier, error)
+	List(ctx context.Context) ([]*domain.Supplier, error)
+}
+
+type ProductSupplierRepository interface {
+	// Upsert creates the link between a product and a supplier or replaces
+	// its terms if it already exists.
+	Upsert(ctx context.Context, ps *domain.ProductSupplier) error
+	Delete(ctx context.Context, productID, supplierID string) error
+	ListBySupplier(ctx context.Context, supplierID string) ([]*domain.ProductSupplier, error)
+	ListByProduct(ctx context.Context, productID string) ([]*domain.ProductSupplier, error)
+	// Cheapest returns the link with the lowest unit cost for the product,
+	// preferring the shorter lead time on ties. When quantity is positive only
+	// suppliers whose minimum order quantity allows it are considered.
+	Cheapest(ctx context.Context, productID string, quantity int32) (*domain.ProductSupplier, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+)
+
+type VariantRepository interface {
+	Create(ctx context.Context, v *domain.Variant) (string, error)
+	GetByID(ctx context.Context, id string) (*domain.Variant, error)
+	ListByProduct(ctx context.Context, productID string) ([]*domain.Variant, error)
+	GetBySKU(ctx context.Context, sku string) (*domain.Variant, error)
+	// AdjustStock atomically adds delta to the stock of a variant, never
+	// taking it below zero; ErrInsufficientStock is returned instead.
+	AdjustStock(ctx context.Context, id string, delta int32) (*domain.Variant, error)
+}
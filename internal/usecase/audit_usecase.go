@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+type AuditUseCase struct {
+	events repository.AuditRepository
+}
+
+func NewAuditUseCase(e repository.AuditRepository) *AuditUseCase {
+	return &AuditUseCase{events: e}
+}
+
+func (uc *AuditUseCase) ListAuditEvents(ctx context.Context, f domain.AuditFilter) ([]*domain.AuditEvent, error) {
+	ctx, span := tracer.Start(ctx, "AuditUseCase.ListAuditEvents")
+	defer span.End()
+
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		err := fmt.Errorf("%w: window start must be before its end", domain.ErrInvalidArgument)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	switch {
+	case f.Limit < 0 || f.Limit > maxAuditLimit:
+		err := fmt.Errorf("%w: limit must be between 0 and %d", domain.ErrInvalidArgument, maxAuditLimit)
+		tracing.RecordError(span, err)
+		return nil, err
+	case f.Limit == 0:
+		f.Limit = defaultAuditLimit
+	}
+	events, err := uc.events.List(ctx, f)
+	tracing.RecordError(span, err)
+	return events, err
+}
@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type BundleUseCase struct {
+	bundles      repository.BundleRepository
+	reservations repository.ReservationRepository
+	products     repository.ProductRepository
+	ledger       *StockLedger
+}
+
+func NewBundleUseCase(b repository.BundleRepository, r repository.ReservationRepository, p repository.ProductRepository, l *StockLedger) *BundleUseCase {
+	return &BundleUseCase{bundles: b, reservations: r, products: p, ledger: l}
+}
+
+func (uc *BundleUseCase) CreateBundle(ctx context.Context, b *domain.Bundle) (*domain.Bundle, int32, error) {
+	ctx, span := tracer.Start(ctx, "BundleUseCase.CreateBundle")
+	defer span.End()
+
+	if err := b.Validate(); err != nil {
+		tracing.RecordError(span, err)
+		return nil, 0, err
+	}
+	available, err := uc.availability(ctx, b, false)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, 0, err
+	}
+	b.CreatedAt = time.Now().UTC()
+	id, err := uc.bundles.Create(ctx, b)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, 0, err
+	}
+	b.ID = id
+	span.SetAttributes(attribute.String("bundle.id", id))
+	return b, available, nil
+}
+
+// GetBundle returns the bundle together with the number of complete bundles
+// the current component stock allows; none while a component is archived.
+func (uc *BundleUseCase) GetBundle(ctx context.Context, id string) (*domain.Bundle, int32, error) {
+	ctx, span := tracer.Start(ctx, "BundleUseCase.GetBundle",
+		trace.WithAttributes(attribute.String("bundle.id", id)))
+	defer span.End()
+
+	b, err := uc.bundles.GetByID(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, 0, err
+	}
+	available, err := uc.availability(ctx, b, true)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, 0, err
+	}
+	return b, available, nil
+}
+
+// maxReservationQuantity caps the bundles one reservation may take.
+const maxReservationQuantity = 1_000_000
+
+// ReserveBundle takes quantity bundles worth of every component out of stock.
+// Components are decremented one by one through the stock ledger, each with
+// the usual guard against going negative; if any of them fails, the ones
+// already taken are put back so the reservation is all or nothing. The put
+// back runs even when the caller has gone away; components it cannot return
+// stay on the reservation, which releasing then gives back.
+func (uc *BundleUseCase) ReserveBundle(ctx context.Context, bundleID string, quantity int32, reference string) (*domain.Reservation, error) {
+	ctx, span := tracer.Start(ctx, "BundleUseCase.ReserveBundle", trace.WithAttributes(
+		attribute.String("bundle.id", bundleID), attribute.Int("bundle.quantity", int(quantity))))
+	defer span.End()
+
+	if quantity <= 0 || quantity > maxReservationQuantity {
+		err := fmt.Errorf("%w: quantity must be between 1 and %d", domain.ErrInvalidArgument, maxReservationQuantity)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	b, err := uc.bundles.GetByID(ctx, bundleID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	r := &domain.Reservation{
+		BundleID:  b.ID,
+		Quantity:  quantity,
+		Lines:     make([]domain.ReservationLine, 0, len(b.Components)),
+		Reference: reference,
+		Status:    domain.ReservationActive,
+		CreatedAt: time.Now().UTC(),
+	}
+	for _, c := range b.Components {
+		total := int64(c.Quantity) * int64(quantity)
+		if total > math.MaxInt32 {
+			err := fmt.Errorf("%w: %d bundles need more units of product %s than can be reserved", domain.ErrInvalidArgument, quantity, c.ProductID)
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		r.Lines = append(r.Lines, domain.ReservationLine{ProductID: c.ProductID, Quantity: int32(total)})
+	}
+	// The reservation is stored first so that the ledger entries can point at it.
+	if r.ID, err = uc.reservations.Create(ctx, r); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("reservation.id", r.ID))
+
+	ledgerRef := "reservation:" + r.ID
+	for i, l := range r.Lines {
+		if _, err := uc.ledger.Apply(ctx, l.ProductID, -l.Quantity, domain.MovementReservation, ledgerRef); err != nil {
+			err = fmt.Errorf("reserve product %s: %w", l.ProductID, err)
+			if rerr := uc.rollback(context.WithoutCancel(ctx), r.ID, r.Lines[:i], ledgerRef); rerr != nil {
+				err = fmt.Errorf("%w; rolling back reservation %s: %v", err, r.ID, rerr)
+			}
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// ReleaseReservation gives the stock of a reservation back. Each line is
+// marked released before its units go back through the ledger and unmarked
+// when that fails, leaving the reservation active; releasing it again
+// returns only the lines still held.
+func (uc *BundleUseCase) ReleaseReservation(ctx context.Context, id string) (*domain.Reservation, error) {
+	ctx, span := tracer.Start(ctx, "BundleUseCase.ReleaseReservation",
+		trace.WithAttributes(attribute.String("reservation.id", id)))
+	defer span.End()
+
+	r, err := uc.reservations.GetByID(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if r.Status != domain.ReservationActive {
+		err = fmt.Errorf("%w: reservation %s is not active", domain.ErrFailedPrecondition, id)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	for i, l := range r.Lines {
+		if l.Released {
+			continue
+		}
+		claimed, err := uc.reservations.SetLineReleased(ctx, r.ID, l.ProductID, true)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		if !claimed {
+			// A concurrent release took this line, or finished the
+			// reservation altogether.
+			continue
+		}
+		if _, err := uc.ledger.Apply(ctx, l.ProductID, l.Quantity, domain.MovementReservationRelease, "reservation:"+r.ID); err != nil {
+			err = fmt.Errorf("release product %s: %w", l.ProductID, err)
+			if _, uerr := uc.reservations.SetLineReleased(context.WithoutCancel(ctx), r.ID, l.ProductID, false); uerr != nil {
+				err = fmt.Errorf("%w; marking the line unreleased: %v", err, uerr)
+			}
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		r.Lines[i].Released = true
+	}
+
+	now := time.Now().UTC()
+	if _, err := uc.reservations.Release(ctx, id, now); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	r, err = uc.reservations.GetByID(ctx, id)
+	tracing.RecordError(span, err)
+	return r, err
+}
+
+func (uc *BundleUseCase) GetReservation(ctx context.Context, id string) (*domain.Reservation, error) {
+	ctx, span := tracer.Start(ctx, "BundleUseCase.GetReservation",
+		trace.WithAttributes(attribute.String("reservation.id", id)))
+	defer span.End()
+
+	r, err := uc.reservations.GetByID(ctx, id)
+	tracing.RecordError(span, err)
+	return r, err
+}
+
+func (uc *BundleUseCase) ListReservations(ctx context.Context, status domain.ReservationStatus, productID string) ([]*domain.Reservation, error) {
+	ctx, span := tracer.Start(ctx, "BundleUseCase.ListReservations", trace.WithAttributes(
+		attribute.String("reservation.status", string(status)), attribute.String("product.id", productID)))
+	defer span.End()
+
+	reservations, err := uc.reservations.List(ctx, status, productID)
+	tracing.RecordError(span, err)
+	return reservations, err
+}
+
+// rollback puts back the lines of a failed reservation and deletes it. Lines
+// that cannot be put back are left on the reservation so that they are not
+// lost, and reported in the returned error.
+func (uc *BundleUseCase) rollback(ctx context.Context, id string, lines []domain.ReservationLine, reference string) error {
+	var (
+		held []domain.ReservationLine
+		errs []error
+	)
+	for _, l := range lines {
+		if _, err := uc.ledger.Apply(ctx, l.ProductID, l.Quantity, domain.MovementReservationRelease, reference); err != nil {
+			held = append(held, l)
+			errs = append(errs, fmt.Errorf("put back %d of product %s: %w", l.Quantity, l.ProductID, err))
+		}
+	}
+	if len(held) == 0 {
+		err := uc.reservations.Delete(ctx, id)
+		if err == nil {
+			return nil
+		}
+		// Releasing would put the units back a second time; mark it
+		// released instead so that it cannot be.
+		if _, rerr := uc.reservations.Release(ctx, id, time.Now().UTC()); rerr != nil {
+			return fmt.Errorf("delete reservation: %w; mark it released: %v", err, rerr)
+		}
+		return nil
+	}
+	if err := uc.reservations.SetLines(ctx, id, held); err != nil {
+		errs = append(errs, fmt.Errorf("record the units still held: %w", err))
+	} else {
+		errs = append(errs, errors.New("release the reservation to return the units still held"))
+	}
+	return errors.Join(errs...)
+}
+
+// availability counts the complete bundles in stock. Archived components
+// are refused unless allowArchived is set, when they count as out of stock.
+func (uc *BundleUseCase) availability(ctx context.Context, b *domain.Bundle, allowArchived bool) (int32, error) {
+	stock := make(map[string]int32, len(b.Components))
+	for _, c := range b.Components {
+		p, err := uc.products.GetByIDIncludingDeleted(ctx, c.ProductID)
+		if err != nil {
+			return 0, fmt.Errorf("component %s: %w", c.ProductID, err)
+		}
+		if !p.DeletedAt.IsZero() {
+			if !allowArchived {
+				return 0, fmt.Errorf("%w: component %s is archived", domain.ErrFailedPrecondition, c.ProductID)
+			}
+			stock[c.ProductID] = 0
+			continue
+		}
+		// Reservations move product stock only, which would leave the lots
+		// or serial units of a tracked component out of step.
+		if p.LotTracked || p.Serialized || p.HasVariants() {
+			return 0, fmt.Errorf("%w: component %s is lot-tracked, serialized or stocked per variant and cannot be bundled", domain.ErrFailedPrecondition, c.ProductID)
+		}
+		stock[c.ProductID] = p.Stock
+	}
+	return b.Availability(stock), nil
+}
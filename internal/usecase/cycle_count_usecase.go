@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/audit"
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type CycleCountUseCase struct {
+	counts   repository.CycleCountRepository
+	products repository.ProductRepository
+	ledger   *StockLedger
+}
+
+func NewCycleCountUseCase(c repository.CycleCountRepository, p repository.ProductRepository, sl *StockLedger) *CycleCountUseCase {
+	return &CycleCountUseCase{counts: c, products: p, ledger: sl}
+}
+
+func (uc *CycleCountUseCase) OpenCycleCount(ctx context.Context, productIDs []string) (*domain.CycleCount, error) {
+	ctx, span := tracer.Start(ctx, "CycleCountUseCase.OpenCycleCount",
+		trace.WithAttributes(attribute.Int("cycle_count.products", len(productIDs))))
+	defer span.End()
+
+	now := time.Now().UTC()
+	c := &domain.CycleCount{
+		Status:    domain.CycleCountOpen,
+		CreatedAt: now,
+		CreatedBy: audit.CallerFrom(ctx).Attribution(),
+		UpdatedAt: now,
+	}
+	for _, id := range productIDs {
+		c.Lines = append(c.Lines, domain.CycleCountLine{ProductID: id})
+	}
+	if err := c.Validate(); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	for _, id := range productIDs {
+		p, err := uc.products.GetByID(ctx, id)
+		if err != nil {
+			err = fmt.Errorf("product %s: %w", id, err)
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		if err := checkAdjustable(p); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+	}
+
+	var err error
+	if c.ID, err = uc.counts.Create(ctx, c); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return c, nil
+}
+
+func (uc *CycleCountUseCase) GetCycleCount(ctx context.Context, id string) (*domain.CycleCount, error) {
+	ctx, span := tracer.Start(ctx, "CycleCountUseCase.GetCycleCount",
+		trace.WithAttributes(attribute.String("cycle_count.id", id)))
+	defer span.End()
+
+	c, err := uc.counts.GetByID(ctx, id)
+	tracing.RecordError(span, err)
+	return c, err
+}
+
+// SubmitCounts records counted quantities, each against the system stock at
+// the moment it is submitted.
+func (uc *CycleCountUseCase) SubmitCounts(ctx context.Context, id string, entries []domain.CountEntry) (*domain.CycleCount, error) {
+	ctx, span := tracer.Start(ctx, "CycleCountUseCase.SubmitCounts",
+		trace.WithAttributes(attribute.String("cycle_count.id", id)))
+	defer span.End()
+
+	if len(entries) == 0 {
+		err := fmt.Errorf("%w: at least one count is required", domain.ErrInvalidArgument)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	c, err := uc.counts.GetByID(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	now := time.Now().UTC()
+	for _, e := range entries {
+		p, err := uc.products.GetByID(ctx, e.ProductID)
+		if err != nil {
+			err = fmt.Errorf("product %s: %w", e.ProductID, err)
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		if err := c.Record(e, p.Stock, now); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+	}
+	c.UpdatedAt = now
+	if err := uc.counts.Update(ctx, c); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return c, nil
+}
+
+// PreviewCycleCount returns the session together with the current stock of
+// its products, showing what approving it would change.
+func (uc *CycleCountUseCase) PreviewCycleCount(ctx context.Context, id string) (*domain.CycleCount, map[string]int32, error) {
+	ctx, span := tracer.Start(ctx, "CycleCountUseCase.PreviewCycleCount",
+		trace.WithAttributes(attribute.String("cycle_count.id", id)))
+	defer span.End()
+
+	c, err := uc.counts.GetByID(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	ids := make([]string, 0, len(c.Lines))
+	for _, l := range c.Lines {
+		ids = append(ids, l.ProductID)
+	}
+	products, err := uc.products.GetMany(ctx, ids, nil)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	current := make(map[string]int32, len(products))
+	for _, p := range products {
+		current[p.ID] = p.Stock
+	}
+	return c, current, nil
+}
+
+// ApproveCycleCount books the variance of every counted product as a stock
+// movement under the line's reason code. The session is saved as approved
+// first so that it cannot be applied twice; lines whose movement fails are
+// reopened and applied by approving again.
+func (uc *CycleCountUseCase) ApproveCycleCount(ctx context.Context, id string) (*domain.CycleCount, error) {
+	ctx, span := tracer.Start(ctx, "CycleCountUseCase.ApproveCycleCount",
+		trace.WithAttributes(attribute.String("cycle_count.id", id)))
+	defer span.End()
+
+	c, err := uc.counts.GetByID(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	var pending []domain.CycleCountLine
+	for _, l := range c.Lines {
+		if !l.Applied {
+			pending = append(pending, l)
+		}
+	}
+	now := time.Now().UTC()
+	if err := c.Approve(audit.CallerFrom(ctx).Attribution(), now); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	c.UpdatedAt = now
+	if err := uc.counts.Update(ctx, c); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	reference := "cycle_count:" + c.ID
+	for i, l := range pending {
+		if l.Variance() == 0 {
+			continue
+		}
+		if _, err := uc.ledger.Apply(ctx, l.ProductID, l.Variance(), l.Reason, reference); err != nil {
+			err = fmt.Errorf("adjust product %s: %w", l.ProductID, err)
+			unapplied := make([]string, 0, len(pending)-i)
+			for _, rest := range pending[i:] {
+				unapplied = append(unapplied, rest.ProductID)
+			}
+			c.Unapply(unapplied)
+			c.UpdatedAt = time.Now().UTC()
+			if uerr := uc.counts.Update(ctx, c); uerr != nil {
+				err = fmt.Errorf("%w; reopening cycle count %s: %v", err, c.ID, uerr)
+			}
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (uc *CycleCountUseCase) CancelCycleCount(ctx context.Context, id string) (*domain.CycleCount, error) {
+	ctx, span := tracer.Start(ctx, "CycleCountUseCase.CancelCycleCount",
+		trace.WithAttributes(attribute.String("cycle_count.id", id)))
+	defer span.End()
+
+	c, err := uc.counts.GetByID(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if err := c.Cancel(); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	c.UpdatedAt = time.Now().UTC()
+	if err := uc.counts.Update(ctx, c); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return c, nil
+}
+
+// checkAdjustable rejects products whose stock is made up of lots, serial
+// units or variants, which a plain stock adjustment would leave out of step.
+func checkAdjustable(p *domain.Product) error {
+	if p.LotTracked || p.Serialized {
+		return fmt.Errorf("%w: product %s is lot-tracked or serialized; adjust its lots or serial units instead", domain.ErrFailedPrecondition, p.ID)
+	}
+	if p.HasVariants() {
+		return fmt.Errorf("%w: product %s is stocked per variant; adjust its variants instead", domain.ErrFailedPrecondition, p.ID)
+	}
+	return nil
+}
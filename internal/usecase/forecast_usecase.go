@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/forecast"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// demandReasons are the movement reasons forecast.IsDemand looks at.
+var demandReasons = []domain.MovementReason{
+	domain.MovementSale, domain.MovementSaleReversal, domain.MovementSerialStatus,
+	domain.MovementReservation, domain.MovementReservationRelease, domain.MovementReturnRestock,
+}
+
+// ReorderSuggestion proposes an order for one product.
+type ReorderSuggestion struct {
+	Product  *domain.Product
+	Demand   forecast.Demand
+	Plan     forecast.Plan
+	OnOrder  int32
+	LeadTime float64
+	// SupplierID is the cheapest supplier of the product, if it has one.
+	SupplierID string
+}
+
+type ForecastUseCase struct {
+	products  repository.ProductRepository
+	movements repository.StockMovementRepository
+	orders    repository.PurchaseOrderRepository
+	suppliers repository.ProductSupplierRepository
+	defaults  forecast.Params
+}
+
+func NewForecastUseCase(p repository.ProductRepository, m repository.StockMovementRepository, o repository.PurchaseOrderRepository, s repository.ProductSupplierRepository, defaults forecast.Params) *ForecastUseCase {
+	return &ForecastUseCase{products: p, movements: m, orders: o, suppliers: s, defaults: defaults}
+}
+
+// ForecastDemand estimates the daily demand of a product from its sales
+// history.
+func (uc *ForecastUseCase) ForecastDemand(ctx context.Context, productID string, params forecast.Params) (forecast.Demand, forecast.Params, error) {
+	ctx, span := tracer.Start(ctx, "ForecastUseCase.ForecastDemand",
+		trace.WithAttributes(attribute.String("product.id", productID)))
+	defer span.End()
+
+	params = params.Or(uc.defaults)
+	if err := params.Validate(); err != nil {
+		tracing.RecordError(span, err)
+		return forecast.Demand{}, params, err
+	}
+	if _, err := uc.products.GetByID(ctx, productID); err != nil {
+		tracing.RecordError(span, err)
+		return forecast.Demand{}, params, err
+	}
+	demand, err := uc.demand(ctx, []string{productID}, params)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return forecast.Demand{}, params, err
+	}
+	return demand[productID], params, nil
+}
+
+// SuggestReorders plans replenishment for the given products or, when none
+// are given, for every product, and returns the products that need ordering.
+// Stock on order counts towards the stock position. The lead time is the one
+// requested, else that of the product's cheapest supplier, else the default.
+func (uc *ForecastUseCase) SuggestReorders(ctx context.Context, productIDs []string, params forecast.Params) ([]*ReorderSuggestion, error) {
+	ctx, span := tracer.Start(ctx, "ForecastUseCase.SuggestReorders",
+		trace.WithAttributes(attribute.Int("product.count", len(productIDs))))
+	defer span.End()
+
+	requestedLeadTime := params.LeadTimeDays
+	params = params.Or(uc.defaults)
+	if err := params.Validate(); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	z, _ := forecast.ServiceFactor(params.ServiceLevel)
+
+	products, err := uc.listProducts(ctx, productIDs)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	ids := make([]string, 0, len(products))
+	for _, p := range products {
+		ids = append(ids, p.ID)
+	}
+	demand, err := uc.demand(ctx, ids, params)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	onOrder, err := uc.onOrder(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	var suggestions []*ReorderSuggestion
+	for _, p := range products {
+		s := &ReorderSuggestion{
+			Product:  p,
+			Demand:   demand[p.ID],
+			OnOrder:  onOrder[p.ID],
+			LeadTime: requestedLeadTime,
+		}
+		var minOrder int32
+		link, err := uc.suppliers.Cheapest(ctx, p.ID, 0)
+		switch {
+		case err == nil:
+			s.SupplierID, minOrder = link.SupplierID, link.MinOrderQuantity
+			if s.LeadTime == 0 && link.LeadTimeDays > 0 {
+				s.LeadTime = float64(link.LeadTimeDays)
+			}
+		case !errors.Is(err, domain.ErrNotFound):
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		if s.LeadTime == 0 {
+			s.LeadTime = params.LeadTimeDays
+		}
+		s.Plan = forecast.Replenish(s.Demand, s.LeadTime, params.ReviewDays, z, p.Stock+s.OnOrder, minOrder)
+		if s.Plan.Quantity > 0 {
+			suggestions = append(suggestions, s)
+		}
+	}
+	span.SetAttributes(attribute.Int("reorder.suggestions", len(suggestions)))
+	return suggestions, nil
+}
+
+// listProducts returns the given products, or every product when none are
+// given, leaving out parents whose stock is held by their variants.
+func (uc *ForecastUseCase) listProducts(ctx context.Context, ids []string) ([]*domain.Product, error) {
+	var products []*domain.Product
+	if len(ids) == 0 {
+		all, err := uc.products.List(ctx, false)
+		if err != nil {
+			return nil, err
+		}
+		products = all
+	} else {
+		for _, id := range dedupe(ids) {
+			p, err := uc.products.GetByID(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			products = append(products, p)
+		}
+	}
+	stocked := products[:0]
+	for _, p := range products {
+		if !p.HasVariants() {
+			stocked = append(stocked, p)
+		}
+	}
+	return stocked, nil
+}
+
+func (uc *ForecastUseCase) demand(ctx context.Context, productIDs []string, params forecast.Params) (map[string]forecast.Demand, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	from := today.AddDate(0, 0, -params.HistoryDays)
+	movements, err := uc.movements.ListByReasons(ctx, productIDs, demandReasons, from)
+	if err != nil {
+		return nil, err
+	}
+	byProduct := make(map[string][]*domain.StockMovement, len(productIDs))
+	for _, m := range movements {
+		byProduct[m.ProductID] = append(byProduct[m.ProductID], m)
+	}
+	demand := make(map[string]forecast.Demand, len(productIDs))
+	for _, id := range productIDs {
+		// Today is still in progress, so the series ends with yesterday.
+		series := forecast.DailySeries(byProduct[id], from, params.HistoryDays)
+		if demand[id], err = forecast.Estimate(series, params.Method, params.WindowDays, params.Alpha); err != nil {
+			return nil, err
+		}
+	}
+	return demand, nil
+}
+
+// onOrder sums, per product, what open purchase orders have yet to deliver.
+func (uc *ForecastUseCase) onOrder(ctx context.Context) (map[string]int32, error) {
+	orders, err := uc.orders.ListByStatus(ctx,
+		domain.PurchaseOrderDraft, domain.PurchaseOrderSent, domain.PurchaseOrderPartiallyReceived)
+	if err != nil {
+		return nil, err
+	}
+	onOrder := make(map[string]int32)
+	for _, po := range orders {
+		for _, l := range po.Lines {
+			onOrder[l.ProductID] += l.Outstanding()
+		}
+	}
+	return onOrder, nil
+}
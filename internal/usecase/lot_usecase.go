@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// allocationAttempts bounds how often FEFO allocation re-reads the lots after
+// losing a race for one of them.
+const allocationAttempts = 3
+
+type LotUseCase struct {
+	lots     repository.LotRepository
+	products repository.ProductRepository
+	ledger   *StockLedger
+}
+
+func NewLotUseCase(l repository.LotRepository, p repository.ProductRepository, sl *StockLedger) *LotUseCase {
+	return &LotUseCase{lots: l, products: p, ledger: sl}
+}
+
+// ReceiveLot records a new lot of a lot-tracked product and adds its quantity
+// to the product's stock. The lot is saved as booked first; if its stock
+// cannot be added it is marked unbooked, and receiving the same lot again
+// adds it.
+func (uc *LotUseCase) ReceiveLot(ctx context.Context, l *domain.Lot) (*domain.Lot, error) {
+	ctx, span := tracer.Start(ctx, "LotUseCase.ReceiveLot",
+		trace.WithAttributes(attribute.String("product.id", l.ProductID), attribute.String("lot.number", l.LotNumber)))
+	defer span.End()
+
+	now := time.Now().UTC()
+	if err := l.Validate(now); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	p, err := uc.products.GetByID(ctx, l.ProductID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if !p.LotTracked {
+		err = fmt.Errorf("%w: product %s is not lot-tracked", domain.ErrFailedPrecondition, p.ID)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	existing, err := uc.lots.GetByNumber(ctx, l.ProductID, l.LotNumber)
+	switch {
+	case err == nil:
+		// Only a lot whose receipt did not reach stock may be received
+		// again, and only as it was received the first time.
+		retry := existing.Status == domain.LotAvailable && existing.Quantity == l.Quantity && existing.UnitCost == l.UnitCost
+		if retry {
+			if retry, err = uc.lots.SetBooked(ctx, existing.ID, true); err != nil {
+				tracing.RecordError(span, err)
+				return nil, err
+			}
+		}
+		if !retry {
+			err = fmt.Errorf("%w: lot %s of product %s already exists", domain.ErrInvalidArgument, l.LotNumber, l.ProductID)
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		l = existing
+		l.Booked = true
+	case errors.Is(err, domain.ErrNotFound):
+		l.Status = domain.LotAvailable
+		l.ReceivedAt = now
+		l.Booked = true
+		if l.ID, err = uc.lots.Create(ctx, l); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+	default:
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	if _, err := uc.ledger.Receive(ctx, l.ProductID, l.Quantity, l.UnitCost, domain.MovementLotReceipt, "lot:"+l.ID); err != nil {
+		if _, uerr := uc.lots.SetBooked(context.WithoutCancel(ctx), l.ID, false); uerr != nil {
+			err = fmt.Errorf("%w; marking lot %s unbooked: %v", err, l.ID, uerr)
+		}
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return l, nil
+}
+
+func (uc *LotUseCase) ListLots(ctx context.Context, productID string) ([]*domain.Lot, error) {
+	ctx, span := tracer.Start(ctx, "LotUseCase.ListLots",
+		trace.WithAttributes(attribute.String("product.id", productID)))
+	defer span.End()
+
+	lots, err := uc.lots.ListByProduct(ctx, productID)
+	tracing.RecordError(span, err)
+	return lots, err
+}
+
+func (uc *LotUseCase) ListExpiringLots(ctx context.Context, from, to time.Time) ([]*domain.Lot, error) {
+	ctx, span := tracer.Start(ctx, "LotUseCase.ListExpiringLots")
+	defer span.End()
+
+	if !from.Before(to) {
+		err := fmt.Errorf("%w: window start must be before its end", domain.ErrInvalidArgument)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	lots, err := uc.lots.ListExpiring(ctx, from, to)
+	tracing.RecordError(span, err)
+	return lots, err
+}
+
+// QuarantineExpired moves every expired lot that still holds stock to
+// quarantine and removes its remaining quantity from available stock. Lots
+// whose quantity could not be removed stay booked and are retried by the
+// next call.
+func (uc *LotUseCase) QuarantineExpired(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "LotUseCase.QuarantineExpired")
+	defer span.End()
+
+	expired, err := uc.lots.ListExpiring(ctx, time.Time{}, time.Now().UTC())
+	if err != nil {
+		tracing.RecordError(span, err)
+		return 0, err
+	}
+	quarantined := 0
+	for _, candidate := range expired {
+		_, ok, err := uc.lots.Quarantine(ctx, candidate.ID)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return quarantined, err
+		}
+		if ok {
+			quarantined++
+		}
+	}
+	span.SetAttributes(attribute.Int("lots.quarantined", quarantined))
+
+	pending, err := uc.lots.ListQuarantinedBooked(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return quarantined, err
+	}
+	for _, l := range pending {
+		if err := uc.debit(ctx, l); err != nil {
+			slog.ErrorContext(ctx, "lots: remove quarantined lot from stock", "lot_id", l.ID, "quantity", l.Quantity, "err", err)
+		}
+	}
+	return quarantined, nil
+}
+
+// debit takes the quantity of a quarantined lot out of stock. The lot is
+// marked unbooked first so that concurrent sweeps do not both take it, and
+// marked booked again if the stock cannot be taken.
+func (uc *LotUseCase) debit(ctx context.Context, l *domain.Lot) error {
+	ok, err := uc.lots.SetBooked(ctx, l.ID, false)
+	if err != nil || !ok || l.Quantity == 0 {
+		return err
+	}
+	if _, err := uc.ledger.Apply(ctx, l.ProductID, -l.Quantity, domain.MovementLotQuarantine, "lot:"+l.ID); err != nil {
+		if _, uerr := uc.lots.SetBooked(context.WithoutCancel(ctx), l.ID, true); uerr != nil {
+			err = fmt.Errorf("%w; marking lot %s booked: %v", err, l.ID, uerr)
+		}
+		return err
+	}
+	return nil
+}
+
+// RunExpiryChecks quarantines expired lots now and then on every interval
+// until ctx is done.
+func (uc *LotUseCase) RunExpiryChecks(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := uc.QuarantineExpired(ctx); err != nil {
+			slog.ErrorContext(ctx, "lots: quarantine expired lots", "err", err)
+		} else if n > 0 {
+			slog.InfoContext(ctx, "lots: quarantined expired lots", "count", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// allocate takes quantity units of a product from its lots, earliest expiry
+// first. On failure everything taken so far is returned to the lots.
+func (uc *LotUseCase) allocate(ctx context.Context, productID string, quantity int32) ([]domain.LotAllocation, error) {
+	var allocations []domain.LotAllocation
+	remaining := quantity
+
+	for attempt := 0; attempt < allocationAttempts && remaining > 0; attempt++ {
+		lots, err := uc.lots.ListAllocatable(ctx, productID, time.Now().UTC())
+		if err != nil {
+			uc.release(ctx, allocations)
+			return nil, err
+		}
+		for _, l := range lots {
+			if remaining == 0 {
+				break
+			}
+			take := l.Quantity
+			if take > remaining {
+				take = remaining
+			}
+			ok, err := uc.lots.Take(ctx, l.ID, take)
+			if err != nil {
+				uc.release(ctx, allocations)
+				return nil, err
+			}
+			if !ok {
+				// Someone else took from this lot; re-read and try again.
+				break
+			}
+			allocations = append(allocations, domain.LotAllocation{
+				LotID:     l.ID,
+				LotNumber: l.LotNumber,
+				ExpiresAt: l.ExpiresAt,
+				Quantity:  take,
+			})
+			remaining -= take
+		}
+	}
+	if remaining > 0 {
+		uc.release(ctx, allocations)
+		return nil, fmt.Errorf("%w: lots of product %s are short by %d", domain.ErrInsufficientStock, productID, remaining)
+	}
+	return allocations, nil
+}
+
+func (uc *LotUseCase) release(ctx context.Context, allocations []domain.LotAllocation) {
+	for _, a := range allocations {
+		if err := uc.lots.Return(ctx, a.LotID, a.Quantity); err != nil {
+			slog.ErrorContext(ctx, "lots: return units to lot", "lot_id", a.LotID, "quantity", a.Quantity, "err", err)
+		}
+	}
+}
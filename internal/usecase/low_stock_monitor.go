@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/event"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+)
+
+// StockObserver is notified after the stock of a product has changed.
+type StockObserver interface {
+	StockChanged(productID string)
+}
+
+type LowStockAlert struct {
+	ProductID       string `json:"product_id"`
+	Name            string `json:"name"`
+	Stock           int32  `json:"stock"`
+	ReorderPoint    int32  `json:"reorder_point"`
+	ReorderQuantity int32  `json:"reorder_quantity"`
+}
+
+// LowStockMonitor evaluates products in the background after stock changes
+// and publishes one alert each time a product drops to its reorder point.
+// The alert is re-armed once stock rises above the reorder point again.
+type LowStockMonitor struct {
+	repo      repository.ProductRepository
+	publisher event.Publisher
+	queue     chan string
+}
+
+func NewLowStockMonitor(r repository.ProductRepository, p event.Publisher, queueSize int) *LowStockMonitor {
+	return &LowStockMonitor{repo: r, publisher: p, queue: make(chan string, queueSize)}
+}
+
+// StockChanged never blocks the caller; if the queue is full the change is
+// dropped and picked up by the sweep on the next start.
+func (m *LowStockMonitor) StockChanged(productID string) {
+	select {
+	case m.queue <- productID:
+	default:
+		slog.Warn("low stock monitor: queue full, dropping evaluation", "product_id", productID)
+	}
+}
+
+func (m *LowStockMonitor) Run(ctx context.Context) {
+	m.sweep(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case id := <-m.queue:
+			p, err := m.repo.GetByID(ctx, id)
+			if err != nil {
+				slog.ErrorContext(ctx, "low stock monitor: get product", "product_id", id, "err", err)
+				continue
+			}
+			m.evaluate(ctx, p)
+		}
+	}
+}
+
+// sweep catches products that went low while the service was not running.
+func (m *LowStockMonitor) sweep(ctx context.Context) {
+	products, err := m.repo.ListLowStock(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "low stock monitor: initial sweep", "err", err)
+		return
+	}
+	for _, p := range products {
+		m.evaluate(ctx, p)
+	}
+}
+
+func (m *LowStockMonitor) evaluate(ctx context.Context, p *domain.Product) {
+	low := p.IsLowStock()
+	changed, err := m.repo.SetLowStockAlerted(ctx, p.ID, low)
+	if err != nil {
+		slog.ErrorContext(ctx, "low stock monitor: update product", "product_id", p.ID, "err", err)
+		return
+	}
+	if !low || !changed {
+		return
+	}
+
+	err = m.publisher.Publish(ctx, event.New(event.TypeLowStock, LowStockAlert{
+		ProductID:       p.ID,
+		Name:            p.Name,
+		Stock:           p.Stock,
+		ReorderPoint:    p.ReorderPoint,
+		ReorderQuantity: p.ReorderQuantity,
+	}))
+	if err != nil {
+		slog.ErrorContext(ctx, "low stock monitor: publish alert", "product_id", p.ID, "err", err)
+		// Re-arm so that the next stock change retries the alert.
+		if _, err := m.repo.SetLowStockAlerted(ctx, p.ID, false); err != nil {
+			slog.ErrorContext(ctx, "low stock monitor: re-arm product", "product_id", p.ID, "err", err)
+		}
+	}
+}
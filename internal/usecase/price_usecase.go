@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/audit"
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PriceUseCase keeps the price history of products and applies scheduled
+// price changes.
+type PriceUseCase struct {
+	products  repository.ProductRepository
+	history   repository.PriceHistoryRepository
+	schedules repository.ScheduledPriceRepository
+}
+
+func NewPriceUseCase(p repository.ProductRepository, h repository.PriceHistoryRepository, s repository.ScheduledPriceRepository) *PriceUseCase {
+	return &PriceUseCase{products: p, history: h, schedules: s}
+}
+
+// SchedulePriceChange plans a price change for a product. Windows of pending
+// and active changes of the same product may not overlap.
+func (uc *PriceUseCase) SchedulePriceChange(ctx context.Context, s *domain.ScheduledPrice) (*domain.ScheduledPrice, error) {
+	ctx, span := tracer.Start(ctx, "PriceUseCase.SchedulePriceChange",
+		trace.WithAttributes(attribute.String("product.id", s.ProductID)))
+	defer span.End()
+
+	now := time.Now().UTC()
+	if err := s.Validate(now); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if _, err := uc.products.GetByID(ctx, s.ProductID); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	planned, err := uc.schedules.ListByProduct(ctx, s.ProductID, domain.ScheduledPricePending, domain.ScheduledPriceActive)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	for _, other := range planned {
+		if s.Overlaps(other) {
+			err = fmt.Errorf("%w: overlaps scheduled price change %s", domain.ErrInvalidArgument, other.ID)
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+	}
+
+	s.Status = domain.ScheduledPricePending
+	s.CreatedAt = now
+	s.CreatedBy = audit.CallerFrom(ctx).Attribution()
+	if s.ID, err = uc.schedules.Create(ctx, s); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return s, nil
+}
+
+// CancelScheduledPriceChange cancels a change that has not been applied yet.
+func (uc *PriceUseCase) CancelScheduledPriceChange(ctx context.Context, id string) (*domain.ScheduledPrice, error) {
+	ctx, span := tracer.Start(ctx, "PriceUseCase.CancelScheduledPriceChange",
+		trace.WithAttributes(attribute.String("scheduled_price.id", id)))
+	defer span.End()
+
+	s, err := uc.schedules.GetByID(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	ok, err := uc.schedules.Transition(ctx, id, domain.ScheduledPricePending, domain.ScheduledPriceCancelled, s.PreviousPrice, false)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if !ok {
+		err = fmt.Errorf("%w: scheduled price change %s is no longer pending", domain.ErrFailedPrecondition, id)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	s.Status = domain.ScheduledPriceCancelled
+	return s, nil
+}
+
+func (uc *PriceUseCase) ListScheduledPriceChanges(ctx context.Context, productID string) ([]*domain.ScheduledPrice, error) {
+	ctx, span := tracer.Start(ctx, "PriceUseCase.ListScheduledPriceChanges",
+		trace.WithAttributes(attribute.String("product.id", productID)))
+	defer span.End()
+
+	changes, err := uc.schedules.ListByProduct(ctx, productID)
+	tracing.RecordError(span, err)
+	return changes, err
+}
+
+func (uc *PriceUseCase) GetPriceHistory(ctx context.Context, productID string) ([]*domain.PriceRecord, error) {
+	ctx, span := tracer.Start(ctx, "PriceUseCase.GetPriceHistory",
+		trace.WithAttributes(attribute.String("product.id", productID)))
+	defer span.End()
+
+	records, err := uc.history.ListByProduct(ctx, productID)
+	tracing.RecordError(span, err)
+	return records, err
+}
+
+// GetPriceAt returns the price record in effect at t. Products whose price
+// has never been recorded report their current price.
+func (uc *PriceUseCase) GetPriceAt(ctx context.Context, productID string, t time.Time) (*domain.PriceRecord, error) {
+	ctx, span := tracer.Start(ctx, "PriceUseCase.GetPriceAt",
+		trace.WithAttributes(attribute.String("product.id", productID)))
+	defer span.End()
+
+	if t.IsZero() {
+		err := fmt.Errorf("%w: time is required", domain.ErrInvalidArgument)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	r, err := uc.history.At(ctx, productID, t)
+	if !errors.Is(err, domain.ErrNotFound) {
+		tracing.RecordError(span, err)
+		return r, err
+	}
+	records, err := uc.history.ListByProduct(ctx, productID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if len(records) > 0 {
+		err = fmt.Errorf("%w: no price recorded for product %s at %s", domain.ErrNotFound, productID, t.Format(time.RFC3339))
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	p, err := uc.products.GetByIDIncludingDeleted(ctx, productID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return &domain.PriceRecord{ProductID: p.ID, Price: p.Price, Source: "current"}, nil
+}
+
+// ApplyDueChanges starts scheduled changes whose window has begun and ends
+// those whose window is over. A change whose price reached the product but
+// not the price history is recorded first, on every run until that works.
+func (uc *PriceUseCase) ApplyDueChanges(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "PriceUseCase.ApplyDueChanges")
+	defer span.End()
+
+	now := time.Now().UTC()
+	due, err := uc.schedules.ListDue(ctx, now)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return 0, err
+	}
+	applied := 0
+	for _, s := range due {
+		if err := uc.apply(ctx, s, now); err != nil {
+			slog.ErrorContext(ctx, "prices: apply scheduled price change", "scheduled_price_id", s.ID, "err", err)
+			continue
+		}
+		applied++
+	}
+	span.SetAttributes(attribute.Int("prices.applied", applied))
+	return applied, nil
+}
+
+// RunScheduler applies due price changes now and then on every interval
+// until ctx is done.
+func (uc *PriceUseCase) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := uc.ApplyDueChanges(ctx); err != nil {
+			slog.ErrorContext(ctx, "prices: apply scheduled price changes", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (uc *PriceUseCase) apply(ctx context.Context, s *domain.ScheduledPrice, now time.Time) error {
+	if s.HistoryPending {
+		if err := uc.recordChange(ctx, s, now); err != nil {
+			return err
+		}
+	}
+	switch {
+	case s.Status == domain.ScheduledPricePending && !now.Before(s.EffectiveFrom):
+		return uc.start(ctx, s, now)
+	case s.Status == domain.ScheduledPriceActive && !s.EffectiveTo.IsZero() && !now.Before(s.EffectiveTo):
+		return uc.end(ctx, s, now)
+	}
+	return nil
+}
+
+func (uc *PriceUseCase) start(ctx context.Context, s *domain.ScheduledPrice, now time.Time) error {
+	if !s.EffectiveTo.IsZero() && !now.Before(s.EffectiveTo) {
+		// The whole window passed while the scheduler was not running.
+		_, err := uc.schedules.Transition(ctx, s.ID, domain.ScheduledPricePending, domain.ScheduledPriceCompleted, 0, false)
+		return err
+	}
+	p, err := uc.products.GetByID(ctx, s.ProductID)
+	if errors.Is(err, domain.ErrNotFound) {
+		_, err = uc.schedules.Transition(ctx, s.ID, domain.ScheduledPricePending, domain.ScheduledPriceCancelled, 0, false)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	next := domain.ScheduledPriceActive
+	if s.EffectiveTo.IsZero() {
+		next = domain.ScheduledPriceCompleted
+	}
+	// The change is marked as owing a history record along with the claim,
+	// so a record that cannot be written is retried by the next run.
+	ok, err := uc.schedules.Transition(ctx, s.ID, domain.ScheduledPricePending, next, p.Price, true)
+	if err != nil || !ok {
+		return err
+	}
+	if _, _, err := uc.products.Update(ctx, s.ProductID, &domain.ProductUpdate{Price: &s.Price}); err != nil {
+		if _, rerr := uc.schedules.Transition(context.WithoutCancel(ctx), s.ID, next, domain.ScheduledPricePending, 0, false); rerr != nil {
+			slog.ErrorContext(ctx, "prices: return scheduled price change to pending", "scheduled_price_id", s.ID, "err", rerr)
+		}
+		return err
+	}
+	s.Status, s.PreviousPrice = next, p.Price
+	// History records when the price actually changed, which is later than
+	// EffectiveFrom when the scheduler runs late.
+	return uc.recordChange(ctx, s, now)
+}
+
+// end puts back the price a change replaced, unless the price has been
+// changed again since the change was applied. Like start, it moves the
+// change back when the price cannot be restored, so the next run retries.
+func (uc *PriceUseCase) end(ctx context.Context, s *domain.ScheduledPrice, now time.Time) error {
+	ok, err := uc.schedules.Transition(ctx, s.ID, domain.ScheduledPriceActive, domain.ScheduledPriceCompleted, s.PreviousPrice, true)
+	if err != nil || !ok {
+		return err
+	}
+	p, err := uc.products.GetByID(ctx, s.ProductID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err == nil && p.Price == s.Price {
+		_, _, err = uc.products.Update(ctx, s.ProductID, &domain.ProductUpdate{Price: &s.PreviousPrice})
+	}
+	if err != nil {
+		if _, rerr := uc.schedules.Transition(context.WithoutCancel(ctx), s.ID, domain.ScheduledPriceCompleted, domain.ScheduledPriceActive, s.PreviousPrice, false); rerr != nil {
+			slog.ErrorContext(ctx, "prices: return scheduled price change to active", "scheduled_price_id", s.ID, "err", rerr)
+		}
+		return err
+	}
+	s.Status = domain.ScheduledPriceCompleted
+	return uc.recordChange(ctx, s, now)
+}
+
+// recordChange writes the price a change last set to the history and clears
+// its pending record. Nothing is written when the history already has it or
+// when the product's price has been changed again since, which recorded the
+// newer price itself.
+func (uc *PriceUseCase) recordChange(ctx context.Context, s *domain.ScheduledPrice, now time.Time) error {
+	price, source := s.Price, "scheduled:"+s.ID
+	if s.Status == domain.ScheduledPriceCompleted && !s.EffectiveTo.IsZero() {
+		price, source = s.PreviousPrice, source+":end"
+	}
+	cur, err := uc.history.At(ctx, s.ProductID, now)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	if err != nil || cur.Source != source {
+		p, err := uc.products.GetByID(ctx, s.ProductID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+		if err == nil && p.Price == price {
+			if err := recordPrice(ctx, uc.history, s.ProductID, price, now, source); err != nil {
+				return err
+			}
+		}
+	}
+	if err := uc.schedules.ClearHistoryPending(ctx, s.ID); err != nil {
+		return err
+	}
+	s.HistoryPending = false
+	return nil
+}
+
+// recordPrice starts a new price history record for a product.
+func recordPrice(ctx context.Context, history repository.PriceHistoryRepository, productID string, price float64, at time.Time, source string) error {
+	return history.Record(ctx, &domain.PriceRecord{
+		ProductID:     productID,
+		Price:         price,
+		EffectiveFrom: at,
+		Source:        source,
+		Actor:         audit.CallerFrom(ctx).Attribution(),
+	})
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+)
+
+// ProductReferences finds what still refers to a product, so that purging
+// archived products does not leave variants, lots, serial units, supplier
+// links, bundle components or reservations pointing at nothing.
+type ProductReferences struct {
+	variants     repository.VariantRepository
+	lots         repository.LotRepository
+	serials      repository.SerialRepository
+	links        repository.ProductSupplierRepository
+	bundles      repository.BundleRepository
+	reservations repository.ReservationRepository
+}
+
+func NewProductReferences(v repository.VariantRepository, l repository.LotRepository, s repository.SerialRepository,
+	ps repository.ProductSupplierRepository, b repository.BundleRepository, r repository.ReservationRepository) *ProductReferences {
+	return &ProductReferences{variants: v, lots: l, serials: s, links: ps, bundles: b, reservations: r}
+}
+
+// Of names the kinds of records that refer to a product; it is empty when
+// nothing does.
+func (r *ProductReferences) Of(ctx context.Context, productID string) ([]string, error) {
+	var kinds []string
+	variants, err := r.variants.ListByProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(variants) > 0 {
+		kinds = append(kinds, "variants")
+	}
+	lots, err := r.lots.ListByProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(lots) > 0 {
+		kinds = append(kinds, "lots")
+	}
+	units, err := r.serials.ListByProduct(ctx, productID, "", 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(units) > 0 {
+		kinds = append(kinds, "serial units")
+	}
+	links, err := r.links.ListByProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(links) > 0 {
+		kinds = append(kinds, "supplier links")
+	}
+	bundles, err := r.bundles.ListByComponent(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(bundles) > 0 {
+		kinds = append(kinds, "bundles")
+	}
+	reservations, err := r.reservations.List(ctx, domain.ReservationActive, productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(reservations) > 0 {
+		kinds = append(kinds, "active reservations")
+	}
+	return kinds, nil
+}
@@ -1,22 +1,430 @@
 package usecase
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/audit"
 	"github.com/facelessEmptiness/inventory_service/internal/domain"
 	"github.com/facelessEmptiness/inventory_service/internal/repository"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/facelessEmptiness/inventory_service/internal/usecase")
+
 type ProductUseCase struct {
-	repo repository.ProductRepository
+	repo     repository.ProductRepository
+	variants repository.VariantRepository
+	prices   repository.PriceHistoryRepository
+	refs     *ProductReferences
+	observer StockObserver
+	// maxBatch caps the number of ids and SKUs GetProducts accepts.
+	maxBatch int
+	// admins are the verified callers that may read archived products.
+	admins map[string]bool
+}
+
+func NewProductUseCase(r repository.ProductRepository, v repository.VariantRepository, ph repository.PriceHistoryRepository, refs *ProductReferences, o StockObserver, maxBatch int, admins []string) *ProductUseCase {
+	uc := &ProductUseCase{repo: r, variants: v, prices: ph, refs: refs, observer: o, maxBatch: maxBatch, admins: make(map[string]bool, len(admins))}
+	for _, a := range admins {
+		uc.admins[a] = true
+	}
+	return uc
+}
+
+func (uc *ProductUseCase) AddProduct(ctx context.Context, p *domain.Product) (string, error) {
+	ctx, span := tracer.Start(ctx, "ProductUseCase.AddProduct")
+	defer span.End()
+
+	if err := p.Validate(); err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	seen := make(map[string]bool, len(p.VariantAttributes))
+	for _, name := range p.VariantAttributes {
+		if name == "" || seen[name] {
+			err := fmt.Errorf("%w: variant attribute names must be non-empty and unique", domain.ErrInvalidArgument)
+			tracing.RecordError(span, err)
+			return "", err
+		}
+		seen[name] = true
+	}
+	if p.LotTracked && p.Stock != 0 {
+		err := fmt.Errorf("%w: lot-tracked products start without stock and are stocked by receiving lots", domain.ErrInvalidArgument)
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	if p.Serialized && (p.LotTracked || p.Stock != 0) {
+		err := fmt.Errorf("%w: serialized products cannot be lot-tracked and are stocked by receiving serial numbers", domain.ErrInvalidArgument)
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	if p.HasVariants() && (p.Stock != 0 || p.LotTracked || p.Serialized) {
+		err := fmt.Errorf("%w: products with variants are stocked per variant and cannot have stock of their own, lots or serial numbers", domain.ErrInvalidArgument)
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	if p.SKU != "" {
+		if err := uc.checkSKUFree(ctx, p.SKU); err != nil {
+			tracing.RecordError(span, err)
+			return "", err
+		}
+	}
+
+	id, err := uc.repo.Create(ctx, p)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	span.SetAttributes(attribute.String("product.id", id))
+	if err := recordPrice(ctx, uc.prices, id, p.Price, time.Now().UTC(), "create"); err != nil {
+		slog.ErrorContext(ctx, "products: record initial price", "product_id", id, "err", err)
+	}
+	uc.observer.StockChanged(id)
+	return id, nil
+}
+
+// GetProduct returns a product with its variants. Archived products are only
+// returned when includeDeleted is set, which is reserved for admins.
+func (uc *ProductUseCase) GetProduct(ctx context.Context, id string, includeDeleted bool) (*domain.Product, error) {
+	ctx, span := tracer.Start(ctx, "ProductUseCase.GetProduct",
+		trace.WithAttributes(attribute.String("product.id", id)))
+	defer span.End()
+
+	if includeDeleted {
+		if err := uc.checkAdmin(ctx); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+	}
+	get := uc.repo.GetByID
+	if includeDeleted {
+		get = uc.repo.GetByIDIncludingDeleted
+	}
+	p, err := get(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if p.HasVariants() {
+		if p.Variants, err = uc.variants.ListByProduct(ctx, p.ID); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// GetProducts looks up many products by id and SKU at once and reports the
+// ids and SKUs that matched no product. Variants are not loaded.
+func (uc *ProductUseCase) GetProducts(ctx context.Context, ids, skus []string) (products []*domain.Product, missingIDs, missingSKUs []string, err error) {
+	ctx, span := tracer.Start(ctx, "ProductUseCase.GetProducts",
+		trace.WithAttributes(attribute.Int("product.ids", len(ids)), attribute.Int("product.skus", len(skus))))
+	defer span.End()
+
+	ids, skus = dedupe(ids), dedupe(skus)
+	if n := len(ids) + len(skus); n == 0 || n > uc.maxBatch {
+		err = fmt.Errorf("%w: between 1 and %d ids and SKUs are accepted, got %d", domain.ErrInvalidArgument, uc.maxBatch, n)
+		tracing.RecordError(span, err)
+		return nil, nil, nil, err
+	}
+	if products, err = uc.repo.GetMany(ctx, ids, skus); err != nil {
+		tracing.RecordError(span, err)
+		return nil, nil, nil, err
+	}
+
+	foundIDs := make(map[string]bool, len(products))
+	foundSKUs := make(map[string]bool, len(products))
+	for _, p := range products {
+		foundIDs[p.ID] = true
+		foundSKUs[p.SKU] = true
+	}
+	for _, id := range ids {
+		if !foundIDs[id] {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+	for _, sku := range skus {
+		if !foundSKUs[sku] {
+			missingSKUs = append(missingSKUs, sku)
+		}
+	}
+	return products, missingIDs, missingSKUs, nil
+}
+
+// ListProducts returns the live products, and the archived ones too when
+// includeDeleted is set, which is reserved for admins.
+func (uc *ProductUseCase) ListProducts(ctx context.Context, includeDeleted bool) ([]*domain.Product, error) {
+	ctx, span := tracer.Start(ctx, "ProductUseCase.ListProducts")
+	defer span.End()
+
+	if includeDeleted {
+		if err := uc.checkAdmin(ctx); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+	}
+	products, err := uc.repo.List(ctx, includeDeleted)
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+// SearchProducts returns the products matching every word of query, at most
+// limit of them; a limit of 0 or above the batch size means the batch size.
+func (uc *ProductUseCase) SearchProducts(ctx context.Context, query string, limit int) ([]*domain.Product, error) {
+	ctx, span := tracer.Start(ctx, "ProductUseCase.SearchProducts",
+		trace.WithAttributes(attribute.String("search.query", query)))
+	defer span.End()
+
+	if strings.TrimSpace(query) == "" {
+		err := fmt.Errorf("%w: search query is required", domain.ErrInvalidArgument)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if limit < 0 {
+		err := fmt.Errorf("%w: limit must not be negative", domain.ErrInvalidArgument)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if limit == 0 || limit > uc.maxBatch {
+		limit = uc.maxBatch
+	}
+	products, err := uc.repo.Search(ctx, query, limit)
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+func (uc *ProductUseCase) UpdateProduct(ctx context.Context, id string, u *domain.ProductUpdate) (*domain.Product, error) {
+	ctx, span := tracer.Start(ctx, "ProductUseCase.UpdateProduct",
+		trace.WithAttributes(attribute.String("product.id", id)))
+	defer span.End()
+
+	if err := u.Validate(); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if u.IsEmpty() {
+		err := fmt.Errorf("%w: no fields to update", domain.ErrInvalidArgument)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	now := time.Now().UTC()
+	_, p, err := uc.repo.Update(ctx, id, u)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if u.ReorderPoint != nil {
+		uc.observer.StockChanged(id)
+	}
+	// The price is recorded whenever the history disagrees with it, so an
+	// update whose record failed is completed by sending it again.
+	if u.Price != nil {
+		if cur, err := uc.prices.At(ctx, id, now); err != nil || cur.Price != p.Price {
+			if err := recordPrice(ctx, uc.prices, id, p.Price, now, "update"); err != nil {
+				err = fmt.Errorf("record price change of product %s: %w", id, err)
+				tracing.RecordError(span, err)
+				return nil, err
+			}
+		}
+	}
+	return p, nil
+}
+
+// DeleteProduct archives a product on behalf of the calling actor. Archived
+// products keep their history and can be restored until they are purged.
+func (uc *ProductUseCase) DeleteProduct(ctx context.Context, id string) (*domain.Product, error) {
+	ctx, span := tracer.Start(ctx, "ProductUseCase.DeleteProduct",
+		trace.WithAttributes(attribute.String("product.id", id)))
+	defer span.End()
+
+	p, err := uc.repo.SoftDelete(ctx, id, audit.CallerFrom(ctx).Attribution(), time.Now().UTC())
+	tracing.RecordError(span, err)
+	return p, err
+}
+
+func (uc *ProductUseCase) RestoreProduct(ctx context.Context, id string) (*domain.Product, error) {
+	ctx, span := tracer.Start(ctx, "ProductUseCase.RestoreProduct",
+		trace.WithAttributes(attribute.String("product.id", id)))
+	defer span.End()
+
+	p, err := uc.repo.Restore(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	uc.observer.StockChanged(id)
+	return p, nil
+}
+
+// PurgeArchived permanently removes products archived longer than retention.
+// Products that other records still refer to are kept, and logged, until
+// those references are removed.
+func (uc *ProductUseCase) PurgeArchived(ctx context.Context, retention time.Duration) (int64, error) {
+	ctx, span := tracer.Start(ctx, "ProductUseCase.PurgeArchived")
+	defer span.End()
+
+	before := time.Now().UTC().Add(-retention)
+	archived, err := uc.repo.ListDeleted(ctx, before)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return 0, err
+	}
+	ids := make([]string, 0, len(archived))
+	for _, p := range archived {
+		kinds, err := uc.refs.Of(ctx, p.ID)
+		if err != nil {
+			err = fmt.Errorf("references to product %s: %w", p.ID, err)
+			tracing.RecordError(span, err)
+			return 0, err
+		}
+		if len(kinds) > 0 {
+			slog.InfoContext(ctx, "products: keeping archived product still referenced", "product_id", p.ID, "references", kinds)
+			continue
+		}
+		ids = append(ids, p.ID)
+	}
+	purged, err := uc.repo.PurgeDeleted(ctx, ids, before)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return int64(len(purged)), err
+	}
+	span.SetAttributes(attribute.Int("products.purged", len(purged)), attribute.Int("products.kept", len(archived)-len(ids)))
+	return int64(len(purged)), nil
+}
+
+// RunPurge purges archived products now and then on every interval until
+// ctx is done.
+func (uc *ProductUseCase) RunPurge(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := uc.PurgeArchived(ctx, retention); err != nil {
+			slog.ErrorContext(ctx, "products: purge archived products", "err", err)
+		} else if n > 0 {
+			slog.InfoContext(ctx, "products: purged archived products", "count", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (uc *ProductUseCase) ListLowStockProducts(ctx context.Context) ([]*domain.Product, error) {
+	ctx, span := tracer.Start(ctx, "ProductUseCase.ListLowStockProducts")
+	defer span.End()
+
+	products, err := uc.repo.ListLowStock(ctx)
+	tracing.RecordError(span, err)
+	return products, err
+}
+
+func (uc *ProductUseCase) AddVariant(ctx context.Context, v *domain.Variant) (*domain.Variant, *domain.Product, error) {
+	ctx, span := tracer.Start(ctx, "ProductUseCase.AddVariant",
+		trace.WithAttributes(attribute.String("product.id", v.ProductID)))
+	defer span.End()
+
+	parent, err := uc.repo.GetByID(ctx, v.ProductID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	if err := v.ValidateFor(parent); err != nil {
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+
+	if err := uc.checkSKUFree(ctx, v.SKU); err != nil {
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	siblings, err := uc.variants.ListByProduct(ctx, parent.ID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	for _, s := range siblings {
+		if s.SameAttributes(v) {
+			err = fmt.Errorf("%w: variant %s already has attributes %v", domain.ErrInvalidArgument, s.SKU, v.Attributes)
+			tracing.RecordError(span, err)
+			return nil, nil, err
+		}
+	}
+
+	v.CreatedAt = time.Now().UTC()
+	id, err := uc.variants.Create(ctx, v)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	v.ID = id
+	span.SetAttributes(attribute.String("variant.id", id))
+	return v, parent, nil
+}
+
+func (uc *ProductUseCase) ListVariants(ctx context.Context, productID string) (*domain.Product, error) {
+	ctx, span := tracer.Start(ctx, "ProductUseCase.ListVariants",
+		trace.WithAttributes(attribute.String("product.id", productID)))
+	defer span.End()
+
+	parent, err := uc.repo.GetByID(ctx, productID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if parent.Variants, err = uc.variants.ListByProduct(ctx, productID); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return parent, nil
 }
 
-func NewProductUseCase(r repository.ProductRepository) *ProductUseCase {
-	return &ProductUseCase{repo: r}
+// checkSKUFree fails when a product or variant already uses the SKU, so that
+// a SKU names exactly one sellable item.
+func (uc *ProductUseCase) checkSKUFree(ctx context.Context, sku string) error {
+	if _, err := uc.variants.GetBySKU(ctx, sku); err == nil {
+		return fmt.Errorf("%w: SKU %s is already used by a variant", domain.ErrInvalidArgument, sku)
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	products, err := uc.repo.GetMany(ctx, nil, []string{sku})
+	if err != nil {
+		return err
+	}
+	if len(products) > 0 {
+		return fmt.Errorf("%w: SKU %s is already used by product %s", domain.ErrInvalidArgument, sku, products[0].ID)
+	}
+	return nil
 }
 
-func (uc *ProductUseCase) AddProduct(p *domain.Product) (string, error) {
-	return uc.repo.Create(p)
+// checkAdmin lets through callers whose verified certificate subject is one
+// of the admins; a claimed x-actor never counts.
+func (uc *ProductUseCase) checkAdmin(ctx context.Context) error {
+	if c := audit.CallerFrom(ctx); c.Verified && uc.admins[c.Actor] {
+		return nil
+	}
+	return fmt.Errorf("%w: archived products are only visible to admins", domain.ErrPermissionDenied)
 }
 
-func (uc *ProductUseCase) GetProduct(id string) (*domain.Product, error) {
-	return uc.repo.GetByID(id)
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := values[:0:0]
+	for _, v := range values {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
 }
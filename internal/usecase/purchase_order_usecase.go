@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type PurchaseOrderUseCase struct {
+	orders    repository.PurchaseOrderRepository
+	products  repository.ProductRepository
+	suppliers repository.SupplierRepository
+	ledger    *StockLedger
+	// overDeliveryTolerance is the fraction of an ordered quantity that may
+	// be received on top of it.
+	overDeliveryTolerance float64
+}
+
+func NewPurchaseOrderUseCase(o repository.PurchaseOrderRepository, p repository.ProductRepository, s repository.SupplierRepository, l *StockLedger, overDeliveryTolerance float64) *PurchaseOrderUseCase {
+	return &PurchaseOrderUseCase{orders: o, products: p, suppliers: s, ledger: l, overDeliveryTolerance: overDeliveryTolerance}
+}
+
+func (uc *PurchaseOrderUseCase) CreatePurchaseOrder(ctx context.Context, po *domain.PurchaseOrder) (*domain.PurchaseOrder, error) {
+	ctx, span := tracer.Start(ctx, "PurchaseOrderUseCase.CreatePurchaseOrder")
+	defer span.End()
+
+	if err := po.Validate(); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if _, err := uc.suppliers.GetByID(ctx, po.SupplierID); err != nil {
+		err = fmt.Errorf("supplier %s: %w", po.SupplierID, err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	for i := range po.Lines {
+		if err := uc.checkReceivable(ctx, po.Lines[i].ProductID); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		po.Lines[i].ReceivedQuantity = 0
+	}
+
+	now := time.Now().UTC()
+	po.Status = domain.PurchaseOrderDraft
+	po.CreatedAt, po.UpdatedAt = now, now
+	po.Version = 0
+
+	id, err := uc.orders.Create(ctx, po)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	po.ID = id
+	span.SetAttributes(attribute.String("purchase_order.id", id))
+	return po, nil
+}
+
+func (uc *PurchaseOrderUseCase) GetPurchaseOrder(ctx context.Context, id string) (*domain.PurchaseOrder, error) {
+	ctx, span := tracer.Start(ctx, "PurchaseOrderUseCase.GetPurchaseOrder",
+		trace.WithAttributes(attribute.String("purchase_order.id", id)))
+	defer span.End()
+
+	po, err := uc.orders.GetByID(ctx, id)
+	tracing.RecordError(span, err)
+	return po, err
+}
+
+func (uc *PurchaseOrderUseCase) SendPurchaseOrder(ctx context.Context, id string) (*domain.PurchaseOrder, error) {
+	ctx, span := tracer.Start(ctx, "PurchaseOrderUseCase.SendPurchaseOrder",
+		trace.WithAttributes(attribute.String("purchase_order.id", id)))
+	defer span.End()
+
+	po, err := uc.transition(ctx, id, (*domain.PurchaseOrder).Send)
+	tracing.RecordError(span, err)
+	return po, err
+}
+
+func (uc *PurchaseOrderUseCase) CancelPurchaseOrder(ctx context.Context, id string) (*domain.PurchaseOrder, error) {
+	ctx, span := tracer.Start(ctx, "PurchaseOrderUseCase.CancelPurchaseOrder",
+		trace.WithAttributes(attribute.String("purchase_order.id", id)))
+	defer span.End()
+
+	po, err := uc.transition(ctx, id, (*domain.PurchaseOrder).Cancel)
+	tracing.RecordError(span, err)
+	return po, err
+}
+
+// ReceiveGoods books a delivery against the purchase order and then adds the
+// received quantities to stock. The order is saved first so that a concurrent
+// receipt of the same delivery fails with ErrConflict instead of adding the
+// stock twice; receipt lines that cannot be added are marked unbooked and
+// added by the next call, which may name no lines to do only that.
+func (uc *PurchaseOrderUseCase) ReceiveGoods(ctx context.Context, id string, lines []domain.ReceiptLine, closeShort bool) (*domain.PurchaseOrder, error) {
+	ctx, span := tracer.Start(ctx, "PurchaseOrderUseCase.ReceiveGoods",
+		trace.WithAttributes(attribute.String("purchase_order.id", id)))
+	defer span.End()
+
+	for _, l := range lines {
+		if err := uc.checkReceivable(ctx, l.ProductID); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+	}
+	var booking []domain.ReceiptLineRef
+	po, err := uc.transition(ctx, id, func(po *domain.PurchaseOrder) error {
+		var err error
+		booking, err = po.Receive(lines, uc.overDeliveryTolerance, closeShort, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	reference := "purchase_order:" + po.ID
+	for i, ref := range booking {
+		l := po.ReceiptLine(ref)
+		if _, err := uc.ledger.Receive(ctx, l.ProductID, l.Quantity, l.UnitCost, domain.MovementPurchaseReceipt, reference); err != nil {
+			err = fmt.Errorf("receive product %s: %w", l.ProductID, err)
+			po.Unbook(booking[i:])
+			po.UpdatedAt = time.Now().UTC()
+			if uerr := uc.orders.Update(context.WithoutCancel(ctx), po); uerr != nil {
+				err = fmt.Errorf("%w; marking receipt lines of purchase order %s unbooked: %v", err, po.ID, uerr)
+			}
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+	}
+	return po, nil
+}
+
+// checkReceivable rejects missing products and those whose stock is made up
+// of lots, serial units or variants, which a plain receipt would leave out
+// of step.
+func (uc *PurchaseOrderUseCase) checkReceivable(ctx context.Context, productID string) error {
+	p, err := uc.products.GetByID(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("product %s: %w", productID, err)
+	}
+	if p.LotTracked {
+		return fmt.Errorf("%w: product %s is lot-tracked; receive it as lots instead", domain.ErrFailedPrecondition, p.ID)
+	}
+	if p.Serialized {
+		return fmt.Errorf("%w: product %s is serialized; receive its serial units instead", domain.ErrFailedPrecondition, p.ID)
+	}
+	if p.HasVariants() {
+		return fmt.Errorf("%w: product %s is stocked per variant, which purchase orders cannot receive", domain.ErrFailedPrecondition, p.ID)
+	}
+	return nil
+}
+
+func (uc *PurchaseOrderUseCase) transition(ctx context.Context, id string, apply func(*domain.PurchaseOrder) error) (*domain.PurchaseOrder, error) {
+	po, err := uc.orders.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := apply(po); err != nil {
+		return nil, err
+	}
+	po.UpdatedAt = time.Now().UTC()
+	if err := uc.orders.Update(ctx, po); err != nil {
+		return nil, err
+	}
+	return po, nil
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/audit"
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type ReturnUseCase struct {
+	returns  repository.ReturnRepository
+	products repository.ProductRepository
+	ledger   *StockLedger
+}
+
+func NewReturnUseCase(r repository.ReturnRepository, p repository.ProductRepository, sl *StockLedger) *ReturnUseCase {
+	return &ReturnUseCase{returns: r, products: p, ledger: sl}
+}
+
+func (uc *ReturnUseCase) CreateReturn(ctx context.Context, r *domain.Return) (*domain.Return, error) {
+	ctx, span := tracer.Start(ctx, "ReturnUseCase.CreateReturn",
+		trace.WithAttributes(attribute.String("order.id", r.OrderID)))
+	defer span.End()
+
+	if err := r.Validate(); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	for _, l := range r.Lines {
+		p, err := uc.products.GetByID(ctx, l.ProductID)
+		if err != nil {
+			err = fmt.Errorf("product %s: %w", l.ProductID, err)
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		// Returned lots and serial units have to be put back individually,
+		// which a plain stock movement cannot do.
+		if p.LotTracked || p.Serialized {
+			err = fmt.Errorf("%w: product %s is lot-tracked or serialized; return its lots or serial units instead", domain.ErrFailedPrecondition, p.ID)
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		if p.HasVariants() {
+			err = fmt.Errorf("%w: product %s is stocked per variant, which returns cannot restock", domain.ErrFailedPrecondition, p.ID)
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC()
+	r.Status = domain.ReturnOpen
+	r.CreatedAt, r.UpdatedAt = now, now
+	r.CreatedBy = audit.CallerFrom(ctx).Attribution()
+	for i := range r.Lines {
+		r.Lines[i].Restocked, r.Lines[i].Damaged, r.Lines[i].VendorReturn = 0, 0, 0
+	}
+	var err error
+	if r.ID, err = uc.returns.Create(ctx, r); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("return.id", r.ID))
+	return r, nil
+}
+
+func (uc *ReturnUseCase) GetReturn(ctx context.Context, id string) (*domain.Return, error) {
+	ctx, span := tracer.Start(ctx, "ReturnUseCase.GetReturn",
+		trace.WithAttributes(attribute.String("return.id", id)))
+	defer span.End()
+
+	r, err := uc.returns.GetByID(ctx, id)
+	tracing.RecordError(span, err)
+	return r, err
+}
+
+func (uc *ReturnUseCase) ListReturnsByOrder(ctx context.Context, orderID string) ([]*domain.Return, error) {
+	ctx, span := tracer.Start(ctx, "ReturnUseCase.ListReturnsByOrder",
+		trace.WithAttributes(attribute.String("order.id", orderID)))
+	defer span.End()
+
+	returns, err := uc.returns.ListByOrder(ctx, orderID)
+	tracing.RecordError(span, err)
+	return returns, err
+}
+
+// InspectReturn books the outcome of inspecting returned units. Restockable
+// units go back into available stock; damaged units and units held for the
+// vendor go into the product's damaged stock. The return is saved first so
+// that the same units cannot be booked twice; inspections whose units cannot
+// be moved are marked unbooked and moved by the next call, which may carry
+// no inspections to do only that.
+func (uc *ReturnUseCase) InspectReturn(ctx context.Context, id string, inspections []domain.Inspection) (*domain.Return, error) {
+	ctx, span := tracer.Start(ctx, "ReturnUseCase.InspectReturn",
+		trace.WithAttributes(attribute.String("return.id", id)))
+	defer span.End()
+
+	r, err := uc.returns.GetByID(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	booking, err := r.Inspect(inspections)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	r.UpdatedAt = time.Now().UTC()
+	if err := uc.returns.Update(ctx, r); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	reference := "return:" + r.ID
+	for i, idx := range booking {
+		in := r.Inspections[idx]
+		switch in.Disposition {
+		case domain.DispositionRestock:
+			_, err = uc.ledger.Apply(ctx, in.ProductID, in.Quantity, domain.MovementReturnRestock, reference)
+		case domain.DispositionDamaged:
+			_, err = uc.ledger.ApplyDamaged(ctx, in.ProductID, in.Quantity, domain.MovementReturnDamaged, reference)
+		case domain.DispositionVendorReturn:
+			_, err = uc.ledger.ApplyDamaged(ctx, in.ProductID, in.Quantity, domain.MovementReturnToVendor, reference)
+		}
+		if err != nil {
+			err = fmt.Errorf("book %d returned units of product %s as %s: %w", in.Quantity, in.ProductID, in.Disposition, err)
+			r.Unbook(booking[i:])
+			r.UpdatedAt = time.Now().UTC()
+			if uerr := uc.returns.Update(context.WithoutCancel(ctx), r); uerr != nil {
+				err = fmt.Errorf("%w; marking inspections of return %s unbooked: %v", err, r.ID, uerr)
+			}
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,364 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SerialUseCase manages the units of serialized products. The product's stock
+// always equals its number of available units: every transition into or out
+// of available goes through the ledger.
+type SerialUseCase struct {
+	serials  repository.SerialRepository
+	products repository.ProductRepository
+	ledger   *StockLedger
+}
+
+func NewSerialUseCase(s repository.SerialRepository, p repository.ProductRepository, sl *StockLedger) *SerialUseCase {
+	return &SerialUseCase{serials: s, products: p, ledger: sl}
+}
+
+// ReceiveSerials adds one available unit per serial number to a serialized
+// product. unitCost is what each unit cost, or zero when unknown. Units are
+// stored booked before their stock is received and unbooked again when that
+// fails, so receiving the same serials again finishes the receipt.
+func (uc *SerialUseCase) ReceiveSerials(ctx context.Context, productID string, serials []string, location, reference string, unitCost float64) ([]*domain.SerialUnit, error) {
+	ctx, span := tracer.Start(ctx, "SerialUseCase.ReceiveSerials", trace.WithAttributes(
+		attribute.String("product.id", productID), attribute.Int("serial.count", len(serials))))
+	defer span.End()
+
+	if err := domain.ValidateSerials(serials); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if unitCost < 0 {
+		err := fmt.Errorf("%w: unit cost must not be negative", domain.ErrInvalidArgument)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if _, err := uc.serializedProduct(ctx, productID); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	existing, err := uc.serials.FindExisting(ctx, serials)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	var units []*domain.SerialUnit
+	if len(existing) > 0 {
+		units, err = uc.claimUnbooked(ctx, productID, serials, existing)
+	} else {
+		units, err = uc.createBooked(ctx, productID, serials, location)
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if _, err := uc.ledger.Receive(ctx, productID, int32(len(units)), unitCost, domain.MovementSerialReceipt, reference); err != nil {
+		uctx := context.WithoutCancel(ctx)
+		for _, u := range units {
+			if _, uerr := uc.serials.SetBooked(uctx, u.Serial, false); uerr != nil {
+				err = fmt.Errorf("%w; marking serial %s unbooked: %v", err, u.Serial, uerr)
+			}
+		}
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return units, nil
+}
+
+func (uc *SerialUseCase) createBooked(ctx context.Context, productID string, serials []string, location string) ([]*domain.SerialUnit, error) {
+	now := time.Now().UTC()
+	units := make([]*domain.SerialUnit, 0, len(serials))
+	for _, s := range serials {
+		units = append(units, &domain.SerialUnit{
+			ProductID:  productID,
+			Serial:     s,
+			Status:     domain.SerialAvailable,
+			Location:   location,
+			Booked:     true,
+			ReceivedAt: now,
+			UpdatedAt:  now,
+		})
+	}
+	if err := uc.serials.CreateMany(ctx, units); err != nil {
+		return nil, err
+	}
+	return units, nil
+}
+
+// claimUnbooked books again the units of a receipt that did not reach stock.
+// Only a receipt of exactly those units may be retried; a unit claimed by a
+// concurrent retry releases the ones this call already claimed.
+func (uc *SerialUseCase) claimUnbooked(ctx context.Context, productID string, serials, existing []string) ([]*domain.SerialUnit, error) {
+	exists := fmt.Errorf("%w: serial numbers already exist: %s", domain.ErrInvalidArgument, strings.Join(existing, ", "))
+	if len(existing) != len(serials) {
+		return nil, exists
+	}
+	units := make([]*domain.SerialUnit, 0, len(serials))
+	for _, s := range serials {
+		u, err := uc.serials.GetBySerial(ctx, s)
+		if err != nil {
+			return nil, err
+		}
+		if u.ProductID != productID || u.Status != domain.SerialAvailable || u.Booked {
+			return nil, exists
+		}
+		units = append(units, u)
+	}
+	for i, u := range units {
+		ok, err := uc.serials.SetBooked(ctx, u.Serial, true)
+		if err == nil && !ok {
+			err = fmt.Errorf("%w: serial %s is being received concurrently", domain.ErrConflict, u.Serial)
+		}
+		if err != nil {
+			uctx := context.WithoutCancel(ctx)
+			for _, claimed := range units[:i] {
+				if _, uerr := uc.serials.SetBooked(uctx, claimed.Serial, false); uerr != nil {
+					err = fmt.Errorf("%w; marking serial %s unbooked: %v", err, claimed.Serial, uerr)
+				}
+			}
+			return nil, err
+		}
+		u.Booked = true
+	}
+	return units, nil
+}
+
+// AllocateSerials reserves units of a product for an order: the given serial
+// numbers, or when none are given, quantity of the oldest available units.
+func (uc *SerialUseCase) AllocateSerials(ctx context.Context, productID, orderID string, quantity int32, serials []string) ([]*domain.SerialUnit, error) {
+	ctx, span := tracer.Start(ctx, "SerialUseCase.AllocateSerials", trace.WithAttributes(
+		attribute.String("product.id", productID), attribute.String("order.id", orderID)))
+	defer span.End()
+
+	if orderID == "" {
+		err := fmt.Errorf("%w: order id is required", domain.ErrInvalidArgument)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if len(serials) > 0 {
+		if err := domain.ValidateSerials(serials); err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		quantity = int32(len(serials))
+	} else if quantity <= 0 {
+		err := fmt.Errorf("%w: quantity must be positive", domain.ErrInvalidArgument)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if _, err := uc.serializedProduct(ctx, productID); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	// As with plain sales, the product-level decrement guards against
+	// over-allocation; units are only reserved once it has succeeded.
+	reference := "order:" + orderID
+	if _, err := uc.ledger.Apply(ctx, productID, -quantity, domain.MovementSerialStatus, reference); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	var (
+		reserved []*domain.SerialUnit
+		err      error
+	)
+	if len(serials) > 0 {
+		reserved, err = uc.reserveSerials(ctx, productID, orderID, serials)
+	} else {
+		reserved, err = uc.reserveOldest(ctx, productID, orderID, quantity)
+	}
+	if err != nil {
+		uc.unreserve(ctx, reserved)
+		if _, rerr := uc.ledger.Apply(ctx, productID, quantity, domain.MovementSerialStatus, reference); rerr != nil {
+			slog.ErrorContext(ctx, "serials: reverse allocation", "product_id", productID, "order_id", orderID, "err", rerr)
+		}
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return reserved, nil
+}
+
+func (uc *SerialUseCase) GetSerial(ctx context.Context, serial string) (*domain.SerialUnit, error) {
+	ctx, span := tracer.Start(ctx, "SerialUseCase.GetSerial",
+		trace.WithAttributes(attribute.String("serial", serial)))
+	defer span.End()
+
+	u, err := uc.serials.GetBySerial(ctx, serial)
+	tracing.RecordError(span, err)
+	return u, err
+}
+
+func (uc *SerialUseCase) ListSerials(ctx context.Context, productID string, status domain.SerialStatus) ([]*domain.SerialUnit, error) {
+	ctx, span := tracer.Start(ctx, "SerialUseCase.ListSerials",
+		trace.WithAttributes(attribute.String("product.id", productID)))
+	defer span.End()
+
+	units, err := uc.serials.ListByProduct(ctx, productID, status, 0)
+	tracing.RecordError(span, err)
+	return units, err
+}
+
+// UpdateSerialStatus moves a unit to a new status, e.g. marking a reserved
+// unit sold or a returned unit defective. A non-empty location also moves it.
+func (uc *SerialUseCase) UpdateSerialStatus(ctx context.Context, serial string, status domain.SerialStatus, location string) (*domain.SerialUnit, error) {
+	ctx, span := tracer.Start(ctx, "SerialUseCase.UpdateSerialStatus", trace.WithAttributes(
+		attribute.String("serial", serial), attribute.String("serial.status", string(status))))
+	defer span.End()
+
+	u, err := uc.serials.GetBySerial(ctx, serial)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if !u.Booked {
+		err = fmt.Errorf("%w: serial %s has not reached stock; receive it again first", domain.ErrFailedPrecondition, serial)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if !u.Status.CanTransitionTo(status) {
+		err = fmt.Errorf("%w: serial %s cannot move from %s to %s", domain.ErrFailedPrecondition, serial, u.Status, status)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	from := u.Status
+	prev := *u
+	u.Status = status
+	u.UpdatedAt = time.Now().UTC()
+	if location != "" {
+		u.Location = location
+	}
+	if status == domain.SerialAvailable {
+		u.OrderID = ""
+	}
+	ok, err := uc.serials.Transition(ctx, u, from)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if !ok {
+		err = fmt.Errorf("%w: serial %s changed concurrently", domain.ErrConflict, serial)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if delta := from.StockDelta(status); delta != 0 {
+		if _, err := uc.ledger.Apply(ctx, u.ProductID, delta, domain.MovementSerialStatus, "serial:"+serial); err != nil {
+			prev.UpdatedAt = u.UpdatedAt
+			if _, rerr := uc.serials.Transition(ctx, &prev, status); rerr != nil {
+				slog.ErrorContext(ctx, "serials: revert status", "serial", serial, "status", from, "err", rerr)
+			}
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+// CountSerials returns the number of units of a product in each status.
+func (uc *SerialUseCase) CountSerials(ctx context.Context, productID string) (map[domain.SerialStatus]int64, error) {
+	ctx, span := tracer.Start(ctx, "SerialUseCase.CountSerials",
+		trace.WithAttributes(attribute.String("product.id", productID)))
+	defer span.End()
+
+	if _, err := uc.serializedProduct(ctx, productID); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	counts, err := uc.serials.CountByStatus(ctx, productID)
+	tracing.RecordError(span, err)
+	return counts, err
+}
+
+func (uc *SerialUseCase) serializedProduct(ctx context.Context, productID string) (*domain.Product, error) {
+	p, err := uc.products.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Serialized {
+		return nil, fmt.Errorf("%w: product %s is not serialized", domain.ErrFailedPrecondition, productID)
+	}
+	return p, nil
+}
+
+func (uc *SerialUseCase) reserveSerials(ctx context.Context, productID, orderID string, serials []string) ([]*domain.SerialUnit, error) {
+	reserved := make([]*domain.SerialUnit, 0, len(serials))
+	for _, s := range serials {
+		u, err := uc.serials.GetBySerial(ctx, s)
+		if err != nil {
+			return reserved, fmt.Errorf("serial %s: %w", s, err)
+		}
+		if u.ProductID != productID {
+			return reserved, fmt.Errorf("%w: serial %s belongs to product %s", domain.ErrInvalidArgument, s, u.ProductID)
+		}
+		ok, err := uc.reserve(ctx, u, orderID)
+		if err != nil {
+			return reserved, err
+		}
+		if !ok {
+			return reserved, fmt.Errorf("%w: serial %s is not available", domain.ErrFailedPrecondition, s)
+		}
+		reserved = append(reserved, u)
+	}
+	return reserved, nil
+}
+
+func (uc *SerialUseCase) reserveOldest(ctx context.Context, productID, orderID string, quantity int32) ([]*domain.SerialUnit, error) {
+	reserved := make([]*domain.SerialUnit, 0, quantity)
+	for attempt := 0; attempt < allocationAttempts && int32(len(reserved)) < quantity; attempt++ {
+		units, err := uc.serials.ListAllocatable(ctx, productID, int64(quantity)-int64(len(reserved)))
+		if err != nil {
+			return reserved, err
+		}
+		for _, u := range units {
+			ok, err := uc.reserve(ctx, u, orderID)
+			if err != nil {
+				return reserved, err
+			}
+			if ok {
+				reserved = append(reserved, u)
+			}
+		}
+	}
+	if short := quantity - int32(len(reserved)); short > 0 {
+		return reserved, fmt.Errorf("%w: available serial units of product %s are short by %d", domain.ErrInsufficientStock, productID, short)
+	}
+	return reserved, nil
+}
+
+func (uc *SerialUseCase) reserve(ctx context.Context, u *domain.SerialUnit, orderID string) (bool, error) {
+	reserved := *u
+	reserved.Status = domain.SerialReserved
+	reserved.OrderID = orderID
+	reserved.UpdatedAt = time.Now().UTC()
+	ok, err := uc.serials.Transition(ctx, &reserved, domain.SerialAvailable)
+	if ok {
+		*u = reserved
+	}
+	return ok, err
+}
+
+// unreserve puts units reserved by a failed allocation back to available.
+func (uc *SerialUseCase) unreserve(ctx context.Context, units []*domain.SerialUnit) {
+	for _, u := range units {
+		u.Status = domain.SerialAvailable
+		u.OrderID = ""
+		u.UpdatedAt = time.Now().UTC()
+		if _, err := uc.serials.Transition(ctx, u, domain.SerialReserved); err != nil {
+			slog.ErrorContext(ctx, "serials: release serial", "serial", u.Serial, "err", err)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+)
+
+// StockLedger is the single path through which stock levels change: every
+// adjustment is applied atomically on the product, recorded as a movement and
+// reported to the stock observer. An adjustment whose movement cannot be
+// recorded is taken back, so that the ledger always adds up to the stock held
+// and valuation can work opening stock back from it.
+type StockLedger struct {
+	products  repository.ProductRepository
+	variants  repository.VariantRepository
+	movements repository.StockMovementRepository
+	observer  StockObserver
+}
+
+func NewStockLedger(p repository.ProductRepository, v repository.VariantRepository, m repository.StockMovementRepository, o StockObserver) *StockLedger {
+	return &StockLedger{products: p, variants: v, movements: m, observer: o}
+}
+
+func (l *StockLedger) Apply(ctx context.Context, productID string, delta int32, reason domain.MovementReason, reference string) (*domain.Product, error) {
+	return l.apply(ctx, &domain.StockMovement{
+		ProductID: productID,
+		Quantity:  delta,
+		Reason:    reason,
+		Bucket:    domain.BucketAvailable,
+		Reference: reference,
+	})
+}
+
+// Receive adds received units to stock, recording what each of them cost so
+// that the stock can be valued. A zero unitCost means the cost is unknown.
+func (l *StockLedger) Receive(ctx context.Context, productID string, quantity int32, unitCost float64, reason domain.MovementReason, reference string) (*domain.Product, error) {
+	return l.apply(ctx, &domain.StockMovement{
+		ProductID: productID,
+		Quantity:  quantity,
+		Reason:    reason,
+		Bucket:    domain.BucketAvailable,
+		UnitCost:  unitCost,
+		Reference: reference,
+	})
+}
+
+func (l *StockLedger) apply(ctx context.Context, m *domain.StockMovement) (*domain.Product, error) {
+	p, err := l.products.AdjustStock(ctx, m.ProductID, m.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	err = l.record(ctx, m, "stock of product "+m.ProductID, func(ctx context.Context) error {
+		_, err := l.products.AdjustStock(ctx, m.ProductID, -m.Quantity)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	l.observer.StockChanged(m.ProductID)
+	return p, nil
+}
+
+// ApplyVariant changes the stock of one variant, recording the movement
+// against its parent product. Variants have no reorder point, so the stock
+// observer is not told.
+func (l *StockLedger) ApplyVariant(ctx context.Context, v *domain.Variant, delta int32, reason domain.MovementReason, reference string) (*domain.Variant, error) {
+	updated, err := l.variants.AdjustStock(ctx, v.ID, delta)
+	if err != nil {
+		return nil, err
+	}
+	m := &domain.StockMovement{
+		ProductID: v.ProductID,
+		VariantID: v.ID,
+		Quantity:  delta,
+		Reason:    reason,
+		Bucket:    domain.BucketAvailable,
+		Reference: reference,
+	}
+	err = l.record(ctx, m, "stock of variant "+v.ID, func(ctx context.Context) error {
+		_, err := l.variants.AdjustStock(ctx, v.ID, -delta)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// ApplyDamaged changes the damaged stock of a product, which is kept apart
+// from the stock available for sale.
+func (l *StockLedger) ApplyDamaged(ctx context.Context, productID string, delta int32, reason domain.MovementReason, reference string) (*domain.Product, error) {
+	p, err := l.products.AdjustDamagedStock(ctx, productID, delta)
+	if err != nil {
+		return nil, err
+	}
+	m := &domain.StockMovement{
+		ProductID: productID,
+		Quantity:  delta,
+		Reason:    reason,
+		Bucket:    domain.BucketDamaged,
+		Reference: reference,
+	}
+	err = l.record(ctx, m, "damaged stock of product "+productID, func(ctx context.Context) error {
+		_, err := l.products.AdjustDamagedStock(ctx, productID, -delta)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// record writes the movement of a change already made to what. When the
+// movement cannot be written the change is taken back with undo, even if ctx
+// has ended; a change that cannot be taken back either is logged, as the
+// ledger no longer adds up to the stock.
+func (l *StockLedger) record(ctx context.Context, m *domain.StockMovement, what string, undo func(context.Context) error) error {
+	err := l.movements.Record(ctx, m)
+	if err == nil {
+		return nil
+	}
+	if uerr := undo(context.WithoutCancel(ctx)); uerr != nil {
+		slog.ErrorContext(ctx, "ledger: stock changed without a movement", "product_id", m.ProductID,
+			"variant_id", m.VariantID, "bucket", m.Bucket, "quantity", m.Quantity, "err", uerr)
+		return fmt.Errorf("%s changed by %d but the ledger entry was not recorded: %w; taking the change back: %v", what, m.Quantity, err, uerr)
+	}
+	return fmt.Errorf("record the ledger entry for %s: %w", what, err)
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StockUseCase handles direct stock operations on products and variants.
+type StockUseCase struct {
+	products repository.ProductRepository
+	variants repository.VariantRepository
+	ledger   *StockLedger
+	lots     *LotUseCase
+}
+
+func NewStockUseCase(p repository.ProductRepository, v repository.VariantRepository, l *StockLedger, lots *LotUseCase) *StockUseCase {
+	return &StockUseCase{products: p, variants: v, ledger: l, lots: lots}
+}
+
+// DecrementStock removes sold units from stock. Products with variants are
+// stocked per variant, so variantID names the one sold. For lot-tracked
+// products the units are allocated from lots first-expired-first-out and the
+// allocations are returned.
+func (uc *StockUseCase) DecrementStock(ctx context.Context, productID, variantID string, quantity int32, reference string) (*domain.Product, []domain.LotAllocation, error) {
+	ctx, span := tracer.Start(ctx, "StockUseCase.DecrementStock", trace.WithAttributes(
+		attribute.String("product.id", productID), attribute.String("variant.id", variantID),
+		attribute.Int("stock.quantity", int(quantity))))
+	defer span.End()
+
+	if quantity <= 0 {
+		err := fmt.Errorf("%w: quantity must be positive", domain.ErrInvalidArgument)
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	p, err := uc.products.GetByID(ctx, productID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	if p.Serialized {
+		err = fmt.Errorf("%w: product %s is serialized; allocate serial numbers instead", domain.ErrFailedPrecondition, productID)
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	if p.HasVariants() || variantID != "" {
+		p, err = uc.applyVariant(ctx, p, variantID, -quantity, domain.MovementSale, reference)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return nil, nil, err
+		}
+		return p, nil, nil
+	}
+
+	// The product-level decrement is the atomic guard against overselling;
+	// lots are only allocated once it has succeeded.
+	p, err = uc.ledger.Apply(ctx, productID, -quantity, domain.MovementSale, reference)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	if !p.LotTracked {
+		return p, nil, nil
+	}
+
+	allocations, err := uc.lots.allocate(ctx, productID, quantity)
+	if err != nil {
+		if _, rerr := uc.ledger.Apply(ctx, productID, quantity, domain.MovementSaleReversal, reference); rerr != nil {
+			slog.ErrorContext(ctx, "stock: reverse decrement after failed lot allocation", "product_id", productID, "err", rerr)
+		}
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	return p, allocations, nil
+}
+
+// AdjustStock corrects the stock of a product, or of the variant variantID
+// names, outside the regular flows, e.g. for damage or loss, booked under an
+// adjustment reason code.
+func (uc *StockUseCase) AdjustStock(ctx context.Context, productID, variantID string, delta int32, reason domain.MovementReason, reference string) (*domain.Product, error) {
+	ctx, span := tracer.Start(ctx, "StockUseCase.AdjustStock", trace.WithAttributes(
+		attribute.String("product.id", productID), attribute.String("variant.id", variantID),
+		attribute.Int("stock.delta", int(delta))))
+	defer span.End()
+
+	if delta == 0 {
+		err := fmt.Errorf("%w: delta must not be zero", domain.ErrInvalidArgument)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if !reason.IsAdjustment() {
+		err := fmt.Errorf("%w: %q is not an adjustment reason", domain.ErrInvalidArgument, reason)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	p, err := uc.products.GetByID(ctx, productID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if p.HasVariants() || variantID != "" {
+		p, err = uc.applyVariant(ctx, p, variantID, delta, reason, reference)
+		tracing.RecordError(span, err)
+		return p, err
+	}
+	if err := checkAdjustable(p); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	p, err = uc.ledger.Apply(ctx, productID, delta, reason, reference)
+	tracing.RecordError(span, err)
+	return p, err
+}
+
+// applyVariant books a stock change on one variant of p and returns p with
+// its variants loaded, so that its available stock shows the change.
+func (uc *StockUseCase) applyVariant(ctx context.Context, p *domain.Product, variantID string, delta int32, reason domain.MovementReason, reference string) (*domain.Product, error) {
+	if !p.HasVariants() {
+		return nil, fmt.Errorf("%w: product %s has no variants", domain.ErrInvalidArgument, p.ID)
+	}
+	if variantID == "" {
+		return nil, fmt.Errorf("%w: product %s is stocked per variant; name the variant", domain.ErrInvalidArgument, p.ID)
+	}
+	v, err := uc.variants.GetByID(ctx, variantID)
+	if err == nil && v.ProductID != p.ID {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("variant %s: %w", variantID, err)
+	}
+	if _, err := uc.ledger.ApplyVariant(ctx, v, delta, reason, reference); err != nil {
+		return nil, err
+	}
+	if p.Variants, err = uc.variants.ListByProduct(ctx, p.ID); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
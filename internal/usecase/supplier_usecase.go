@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type SupplierUseCase struct {
+	suppliers repository.SupplierRepository
+	links     repository.ProductSupplierRepository
+	products  repository.ProductRepository
+}
+
+func NewSupplierUseCase(s repository.SupplierRepository, l repository.ProductSupplierRepository, p repository.ProductRepository) *SupplierUseCase {
+	return &SupplierUseCase{suppliers: s, links: l, products: p}
+}
+
+// SupplierProduct is a product together with the terms of one supplier.
+type SupplierProduct struct {
+	Product *domain.Product
+	Terms   *domain.ProductSupplier
+}
+
+func (uc *SupplierUseCase) CreateSupplier(ctx context.Context, s *domain.Supplier) (*domain.Supplier, error) {
+	ctx, span := tracer.Start(ctx, "SupplierUseCase.CreateSupplier")
+	defer span.End()
+
+	if err := s.Validate(); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	s.CreatedAt = time.Now().UTC()
+	id, err := uc.suppliers.Create(ctx, s)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	s.ID = id
+	span.SetAttributes(attribute.String("supplier.id", id))
+	return s, nil
+}
+
+func (uc *SupplierUseCase) GetSupplier(ctx context.Context, id string) (*domain.Supplier, error) {
+	ctx, span := tracer.Start(ctx, "SupplierUseCase.GetSupplier",
+		trace.WithAttributes(attribute.String("supplier.id", id)))
+	defer span.End()
+
+	s, err := uc.suppliers.GetByID(ctx, id)
+	tracing.RecordError(span, err)
+	return s, err
+}
+
+func (uc *SupplierUseCase) ListSuppliers(ctx context.Context) ([]*domain.Supplier, error) {
+	ctx, span := tracer.Start(ctx, "SupplierUseCase.ListSuppliers")
+	defer span.End()
+
+	suppliers, err := uc.suppliers.List(ctx)
+	tracing.RecordError(span, err)
+	return suppliers, err
+}
+
+func (uc *SupplierUseCase) LinkProductSupplier(ctx context.Context, ps *domain.ProductSupplier) (*domain.ProductSupplier, error) {
+	ctx, span := tracer.Start(ctx, "SupplierUseCase.LinkProductSupplier", trace.WithAttributes(
+		attribute.String("product.id", ps.ProductID), attribute.String("supplier.id", ps.SupplierID)))
+	defer span.End()
+
+	if err := ps.Validate(); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if _, err := uc.suppliers.GetByID(ctx, ps.SupplierID); err != nil {
+		err = fmt.Errorf("supplier %s: %w", ps.SupplierID, err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if _, err := uc.products.GetByID(ctx, ps.ProductID); err != nil {
+		err = fmt.Errorf("product %s: %w", ps.ProductID, err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if err := uc.links.Upsert(ctx, ps); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return ps, nil
+}
+
+func (uc *SupplierUseCase) UnlinkProductSupplier(ctx context.Context, productID, supplierID string) error {
+	ctx, span := tracer.Start(ctx, "SupplierUseCase.UnlinkProductSupplier", trace.WithAttributes(
+		attribute.String("product.id", productID), attribute.String("supplier.id", supplierID)))
+	defer span.End()
+
+	err := uc.links.Delete(ctx, productID, supplierID)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (uc *SupplierUseCase) ListSupplierProducts(ctx context.Context, supplierID string) ([]SupplierProduct, error) {
+	ctx, span := tracer.Start(ctx, "SupplierUseCase.ListSupplierProducts",
+		trace.WithAttributes(attribute.String("supplier.id", supplierID)))
+	defer span.End()
+
+	if _, err := uc.suppliers.GetByID(ctx, supplierID); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	links, err := uc.links.ListBySupplier(ctx, supplierID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	result := make([]SupplierProduct, 0, len(links))
+	for _, l := range links {
+		// Archived products are listed too, flagged by their DeletedAt, as
+		// their links remain until someone removes them.
+		p, err := uc.products.GetByIDIncludingDeleted(ctx, l.ProductID)
+		if err != nil {
+			err = fmt.Errorf("product %s: %w", l.ProductID, err)
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		result = append(result, SupplierProduct{Product: p, Terms: l})
+	}
+	return result, nil
+}
+
+func (uc *SupplierUseCase) ListProductSuppliers(ctx context.Context, productID string) ([]*domain.ProductSupplier, error) {
+	ctx, span := tracer.Start(ctx, "SupplierUseCase.ListProductSuppliers",
+		trace.WithAttributes(attribute.String("product.id", productID)))
+	defer span.End()
+
+	links, err := uc.links.ListByProduct(ctx, productID)
+	tracing.RecordError(span, err)
+	return links, err
+}
+
+func (uc *SupplierUseCase) GetCheapestSupplier(ctx context.Context, productID string, quantity int32) (*domain.ProductSupplier, error) {
+	ctx, span := tracer.Start(ctx, "SupplierUseCase.GetCheapestSupplier",
+		trace.WithAttributes(attribute.String("product.id", productID)))
+	defer span.End()
+
+	ps, err := uc.links.Cheapest(ctx, productID, quantity)
+	tracing.RecordError(span, err)
+	return ps, err
+}
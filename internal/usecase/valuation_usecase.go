@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/facelessEmptiness/inventory_service/internal/domain"
+	"github.com/facelessEmptiness/inventory_service/internal/repository"
+	"github.com/facelessEmptiness/inventory_service/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// valuationBatch is the number of products whose movements are loaded and
+// replayed at a time.
+const valuationBatch = 200
+
+// ValuationUseCase values stock on hand from the costs recorded on the stock
+// ledger.
+type ValuationUseCase struct {
+	products  repository.ProductRepository
+	movements repository.StockMovementRepository
+	serials   repository.SerialRepository
+}
+
+func NewValuationUseCase(p repository.ProductRepository, m repository.StockMovementRepository, s repository.SerialRepository) *ValuationUseCase {
+	return &ValuationUseCase{products: p, movements: m, serials: s}
+}
+
+// Valuate values the stock held at asOf, or now when asOf is zero, of the
+// given products or, when none are given, of every product; categoryID
+// narrows the report to one category. Products archived by then are left out.
+// Movements are loaded for a batch of products at a time, so that a report
+// on the whole catalogue never holds the full ledger in memory.
+//
+// A report on the present is also broken down per warehouse by the location
+// of each serial unit. Units keep no history of where they were, so a report
+// as of an earlier moment has no such breakdown.
+func (uc *ValuationUseCase) Valuate(ctx context.Context, method domain.ValuationMethod, asOf time.Time, productIDs []string, categoryID string) (*domain.ValuationReport, error) {
+	ctx, span := tracer.Start(ctx, "ValuationUseCase.Valuate", trace.WithAttributes(
+		attribute.String("valuation.method", string(method)), attribute.String("category.id", categoryID)))
+	defer span.End()
+
+	if err := method.Validate(); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	current := asOf.IsZero()
+	if current {
+		asOf = time.Now().UTC()
+	}
+	products, err := uc.listProducts(ctx, productIDs)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	selected := products[:0]
+	for _, p := range products {
+		if p.IsDeleted() && !p.DeletedAt.After(asOf) {
+			continue
+		}
+		if categoryID != "" && p.CategoryID != categoryID {
+			continue
+		}
+		selected = append(selected, p)
+	}
+
+	report := &domain.ValuationReport{Method: method, AsOf: asOf}
+	categories := make(map[string]*domain.CategoryValuation)
+	warehouses := make(map[string]*domain.WarehouseValuation)
+	var byProduct map[string][]*domain.StockMovement
+	var locations map[string]map[string]int32
+	for i, p := range selected {
+		if i%valuationBatch == 0 {
+			batch := selected[i:min(i+valuationBatch, len(selected))]
+			byProduct, err = uc.movementsOf(ctx, batch)
+			if err == nil && current {
+				locations, err = uc.locationsOf(ctx, batch)
+			}
+			if err != nil {
+				tracing.RecordError(span, err)
+				return nil, err
+			}
+		}
+		book := domain.ReplayCostBook(p, byProduct[p.ID], method, asOf)
+		v := domain.ProductValuation{
+			ProductID:        p.ID,
+			SKU:              p.SKU,
+			Name:             p.Name,
+			CategoryID:       p.CategoryID,
+			Quantity:         book.Quantity(),
+			UncostedQuantity: book.Uncosted(),
+			Value:            book.Value(),
+		}
+		report.Products = append(report.Products, v)
+		report.Quantity += v.Quantity
+		report.Value += v.Value
+
+		c, ok := categories[p.CategoryID]
+		if !ok {
+			c = &domain.CategoryValuation{CategoryID: p.CategoryID}
+			categories[p.CategoryID] = c
+		}
+		c.Quantity += v.Quantity
+		c.Value += v.Value
+
+		if !current {
+			continue
+		}
+		for _, s := range v.SplitByLocation(locations[p.ID]) {
+			w, ok := warehouses[s.Location]
+			if !ok {
+				w = &domain.WarehouseValuation{Location: s.Location}
+				warehouses[s.Location] = w
+			}
+			w.Quantity += s.Quantity
+			w.Value += s.Value
+		}
+	}
+	for _, c := range categories {
+		report.Categories = append(report.Categories, *c)
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		return report.Categories[i].CategoryID < report.Categories[j].CategoryID
+	})
+	for _, w := range warehouses {
+		report.Warehouses = append(report.Warehouses, *w)
+	}
+	sort.Slice(report.Warehouses, func(i, j int) bool {
+		return report.Warehouses[i].Location < report.Warehouses[j].Location
+	})
+	span.SetAttributes(attribute.Int("valuation.products", len(report.Products)))
+	return report, nil
+}
+
+// CostLayers returns the cost layers of a product's stock at asOf, or now
+// when asOf is zero, together with the number of uncosted units.
+func (uc *ValuationUseCase) CostLayers(ctx context.Context, productID string, method domain.ValuationMethod, asOf time.Time) ([]domain.CostLayer, int32, error) {
+	ctx, span := tracer.Start(ctx, "ValuationUseCase.CostLayers",
+		trace.WithAttributes(attribute.String("product.id", productID), attribute.String("valuation.method", string(method))))
+	defer span.End()
+
+	if err := method.Validate(); err != nil {
+		tracing.RecordError(span, err)
+		return nil, 0, err
+	}
+	if asOf.IsZero() {
+		asOf = time.Now().UTC()
+	}
+	p, err := uc.products.GetByIDIncludingDeleted(ctx, productID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, 0, err
+	}
+	movements, err := uc.movements.ListByProducts(ctx, []string{p.ID})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, 0, err
+	}
+	book := domain.ReplayCostBook(p, movements, method, asOf)
+	return book.Layers(), book.Uncosted(), nil
+}
+
+func (uc *ValuationUseCase) listProducts(ctx context.Context, ids []string) ([]*domain.Product, error) {
+	if len(ids) == 0 {
+		return uc.products.List(ctx, true)
+	}
+	ids = dedupe(ids)
+	products := make([]*domain.Product, 0, len(ids))
+	for _, id := range ids {
+		p, err := uc.products.GetByIDIncludingDeleted(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("product %s: %w", id, err)
+		}
+		products = append(products, p)
+	}
+	return products, nil
+}
+
+func (uc *ValuationUseCase) movementsOf(ctx context.Context, products []*domain.Product) (map[string][]*domain.StockMovement, error) {
+	byProduct := make(map[string][]*domain.StockMovement, len(products))
+	if len(products) == 0 {
+		return byProduct, nil
+	}
+	ids := make([]string, 0, len(products))
+	for _, p := range products {
+		ids = append(ids, p.ID)
+	}
+	movements, err := uc.movements.ListByProducts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range movements {
+		byProduct[m.ProductID] = append(byProduct[m.ProductID], m)
+	}
+	return byProduct, nil
+}
+
+// locationsOf counts where the units of the serialized products among the
+// given ones are held.
+func (uc *ValuationUseCase) locationsOf(ctx context.Context, products []*domain.Product) (map[string]map[string]int32, error) {
+	var ids []string
+	for _, p := range products {
+		if p.Serialized {
+			ids = append(ids, p.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return uc.serials.CountAvailableByLocation(ctx, ids)
+}
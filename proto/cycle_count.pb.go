@@ -0,0 +1,957 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v6.30.2
+// source: proto/cycle_count.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CycleCountStatus int32
+
+const (
+	CycleCountStatus_CYCLE_COUNT_STATUS_UNSPECIFIED CycleCountStatus = 0
+	CycleCountStatus_CYCLE_COUNT_STATUS_OPEN        CycleCountStatus = 1
+	CycleCountStatus_CYCLE_COUNT_STATUS_APPROVED    CycleCountStatus = 2
+	CycleCountStatus_CYCLE_COUNT_STATUS_CANCELLED   CycleCountStatus = 3
+)
+
+// Enum value maps for CycleCountStatus.
+var (
+	CycleCountStatus_name = map[int32]string{
+		0: "CYCLE_COUNT_STATUS_UNSPECIFIED",
+		1: "CYCLE_COUNT_STATUS_OPEN",
+		2: "CYCLE_COUNT_STATUS_APPROVED",
+		3: "CYCLE_COUNT_STATUS_CANCELLED",
+	}
+	CycleCountStatus_value = map[string]int32{
+		"CYCLE_COUNT_STATUS_UNSPECIFIED": 0,
+		"CYCLE_COUNT_STATUS_OPEN":        1,
+		"CYCLE_COUNT_STATUS_APPROVED":    2,
+		"CYCLE_COUNT_STATUS_CANCELLED":   3,
+	}
+)
+
+func (x CycleCountStatus) Enum() *CycleCountStatus {
+	p := new(CycleCountStatus)
+	*p = x
+	return p
+}
+
+func (x CycleCountStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CycleCountStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_cycle_count_proto_enumTypes[0].Descriptor()
+}
+
+func (CycleCountStatus) Type() protoreflect.EnumType {
+	return &file_proto_cycle_count_proto_enumTypes[0]
+}
+
+func (x CycleCountStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CycleCountStatus.Descriptor instead.
+func (CycleCountStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_cycle_count_proto_rawDescGZIP(), []int{0}
+}
+
+type CycleCountLine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProductId       string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Counted         bool   `protobuf:"varint,2,opt,name=counted,proto3" json:"counted,omitempty"`
+	CountedQuantity int32  `protobuf:"varint,3,opt,name=counted_quantity,json=countedQuantity,proto3" json:"counted_quantity,omitempty"`
+	// System stock when the count was submitted.
+	SystemStock int32                  `protobuf:"varint,4,opt,name=system_stock,json=systemStock,proto3" json:"system_stock,omitempty"`
+	Variance    int32                  `protobuf:"varint,5,opt,name=variance,proto3" json:"variance,omitempty"`
+	Reason      string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Applied     bool                   `protobuf:"varint,7,opt,name=applied,proto3" json:"applied,omitempty"`
+	CountedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=counted_at,json=countedAt,proto3" json:"counted_at,omitempty"`
+}
+
+func (x *CycleCountLine) Reset() {
+	*x = CycleCountLine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cycle_count_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CycleCountLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CycleCountLine) ProtoMessage() {}
+
+func (x *CycleCountLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cycle_count_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CycleCountLine.ProtoReflect.Descriptor instead.
+func (*CycleCountLine) Descriptor() ([]byte, []int) {
+	return file_proto_cycle_count_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CycleCountLine) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CycleCountLine) GetCounted() bool {
+	if x != nil {
+		return x.Counted
+	}
+	return false
+}
+
+func (x *CycleCountLine) GetCountedQuantity() int32 {
+	if x != nil {
+		return x.CountedQuantity
+	}
+	return 0
+}
+
+func (x *CycleCountLine) GetSystemStock() int32 {
+	if x != nil {
+		return x.SystemStock
+	}
+	return 0
+}
+
+func (x *CycleCountLine) GetVariance() int32 {
+	if x != nil {
+		return x.Variance
+	}
+	return 0
+}
+
+func (x *CycleCountLine) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CycleCountLine) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *CycleCountLine) GetCountedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CountedAt
+	}
+	return nil
+}
+
+type CycleCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status     CycleCountStatus       `protobuf:"varint,2,opt,name=status,proto3,enum=inventory.CycleCountStatus" json:"status,omitempty"`
+	Lines      []*CycleCountLine      `protobuf:"bytes,3,rep,name=lines,proto3" json:"lines,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy  string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	ApprovedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=approved_at,json=approvedAt,proto3" json:"approved_at,omitempty"`
+	ApprovedBy string                 `protobuf:"bytes,7,opt,name=approved_by,json=approvedBy,proto3" json:"approved_by,omitempty"`
+}
+
+func (x *CycleCount) Reset() {
+	*x = CycleCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cycle_count_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CycleCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CycleCount) ProtoMessage() {}
+
+func (x *CycleCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cycle_count_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CycleCount.ProtoReflect.Descriptor instead.
+func (*CycleCount) Descriptor() ([]byte, []int) {
+	return file_proto_cycle_count_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CycleCount) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CycleCount) GetStatus() CycleCountStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CycleCountStatus_CYCLE_COUNT_STATUS_UNSPECIFIED
+}
+
+func (x *CycleCount) GetLines() []*CycleCountLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *CycleCount) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CycleCount) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *CycleCount) GetApprovedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ApprovedAt
+	}
+	return nil
+}
+
+func (x *CycleCount) GetApprovedBy() string {
+	if x != nil {
+		return x.ApprovedBy
+	}
+	return ""
+}
+
+type CycleCountID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CycleCountID) Reset() {
+	*x = CycleCountID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cycle_count_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CycleCountID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CycleCountID) ProtoMessage() {}
+
+func (x *CycleCountID) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cycle_count_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CycleCountID.ProtoReflect.Descriptor instead.
+func (*CycleCountID) Descriptor() ([]byte, []int) {
+	return file_proto_cycle_count_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CycleCountID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type OpenCycleCountRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProductIds []string `protobuf:"bytes,1,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+}
+
+func (x *OpenCycleCountRequest) Reset() {
+	*x = OpenCycleCountRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cycle_count_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenCycleCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenCycleCountRequest) ProtoMessage() {}
+
+func (x *OpenCycleCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cycle_count_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenCycleCountRequest.ProtoReflect.Descriptor instead.
+func (*OpenCycleCountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cycle_count_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OpenCycleCountRequest) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+type CountEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProductId       string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CountedQuantity int32  `protobuf:"varint,2,opt,name=counted_quantity,json=countedQuantity,proto3" json:"counted_quantity,omitempty"`
+	// Reason code for the correction; cycle_count when empty.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *CountEntry) Reset() {
+	*x = CountEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cycle_count_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountEntry) ProtoMessage() {}
+
+func (x *CountEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cycle_count_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountEntry.ProtoReflect.Descriptor instead.
+func (*CountEntry) Descriptor() ([]byte, []int) {
+	return file_proto_cycle_count_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CountEntry) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CountEntry) GetCountedQuantity() int32 {
+	if x != nil {
+		return x.CountedQuantity
+	}
+	return 0
+}
+
+func (x *CountEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SubmitCountsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Counts []*CountEntry `protobuf:"bytes,2,rep,name=counts,proto3" json:"counts,omitempty"`
+}
+
+func (x *SubmitCountsRequest) Reset() {
+	*x = SubmitCountsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cycle_count_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitCountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitCountsRequest) ProtoMessage() {}
+
+func (x *SubmitCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cycle_count_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitCountsRequest.ProtoReflect.Descriptor instead.
+func (*SubmitCountsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cycle_count_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SubmitCountsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubmitCountsRequest) GetCounts() []*CountEntry {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+type VariancePreview struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProductId       string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Counted         bool   `protobuf:"varint,2,opt,name=counted,proto3" json:"counted,omitempty"`
+	CountedQuantity int32  `protobuf:"varint,3,opt,name=counted_quantity,json=countedQuantity,proto3" json:"counted_quantity,omitempty"`
+	SystemStock     int32  `protobuf:"varint,4,opt,name=system_stock,json=systemStock,proto3" json:"system_stock,omitempty"`
+	Variance        int32  `protobuf:"varint,5,opt,name=variance,proto3" json:"variance,omitempty"`
+	CurrentStock    int32  `protobuf:"varint,6,opt,name=current_stock,json=currentStock,proto3" json:"current_stock,omitempty"`
+	// Stock after approval: the current stock plus the variance.
+	ResultingStock int32 `protobuf:"varint,7,opt,name=resulting_stock,json=resultingStock,proto3" json:"resulting_stock,omitempty"`
+	Applied        bool  `protobuf:"varint,8,opt,name=applied,proto3" json:"applied,omitempty"`
+}
+
+func (x *VariancePreview) Reset() {
+	*x = VariancePreview{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cycle_count_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VariancePreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VariancePreview) ProtoMessage() {}
+
+func (x *VariancePreview) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cycle_count_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VariancePreview.ProtoReflect.Descriptor instead.
+func (*VariancePreview) Descriptor() ([]byte, []int) {
+	return file_proto_cycle_count_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VariancePreview) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *VariancePreview) GetCounted() bool {
+	if x != nil {
+		return x.Counted
+	}
+	return false
+}
+
+func (x *VariancePreview) GetCountedQuantity() int32 {
+	if x != nil {
+		return x.CountedQuantity
+	}
+	return 0
+}
+
+func (x *VariancePreview) GetSystemStock() int32 {
+	if x != nil {
+		return x.SystemStock
+	}
+	return 0
+}
+
+func (x *VariancePreview) GetVariance() int32 {
+	if x != nil {
+		return x.Variance
+	}
+	return 0
+}
+
+func (x *VariancePreview) GetCurrentStock() int32 {
+	if x != nil {
+		return x.CurrentStock
+	}
+	return 0
+}
+
+func (x *VariancePreview) GetResultingStock() int32 {
+	if x != nil {
+		return x.ResultingStock
+	}
+	return 0
+}
+
+func (x *VariancePreview) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+type CycleCountPreview struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CycleCount *CycleCount        `protobuf:"bytes,1,opt,name=cycle_count,json=cycleCount,proto3" json:"cycle_count,omitempty"`
+	Variances  []*VariancePreview `protobuf:"bytes,2,rep,name=variances,proto3" json:"variances,omitempty"`
+}
+
+func (x *CycleCountPreview) Reset() {
+	*x = CycleCountPreview{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cycle_count_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CycleCountPreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CycleCountPreview) ProtoMessage() {}
+
+func (x *CycleCountPreview) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cycle_count_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CycleCountPreview.ProtoReflect.Descriptor instead.
+func (*CycleCountPreview) Descriptor() ([]byte, []int) {
+	return file_proto_cycle_count_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CycleCountPreview) GetCycleCount() *CycleCount {
+	if x != nil {
+		return x.CycleCount
+	}
+	return nil
+}
+
+func (x *CycleCountPreview) GetVariances() []*VariancePreview {
+	if x != nil {
+		return x.Variances
+	}
+	return nil
+}
+
+var File_proto_cycle_count_proto protoreflect.FileDescriptor
+
+var file_proto_cycle_count_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x69, 0x6e, 0x76, 0x65, 0x6e,
+	0x74, 0x6f, 0x72, 0x79, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa0, 0x02, 0x0a, 0x0e, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65,
+	0x64, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x5f, 0x71, 0x75, 0x61,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x65, 0x64, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x21, 0x0a, 0x0c,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0b, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12,
+	0x1a, 0x0a, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x12, 0x39, 0x0a,
+	0x0a, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xba, 0x02, 0x0a, 0x0a, 0x43, 0x79, 0x63,
+	0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x33, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74,
+	0x6f, 0x72, 0x79, 0x2e, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2f, 0x0a, 0x05,
+	0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6e,
+	0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x39, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x12, 0x3b, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x72, 0x6f,
+	0x76, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x64,
+	0x5f, 0x62, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x72, 0x6f,
+	0x76, 0x65, 0x64, 0x42, 0x79, 0x22, 0x1e, 0x0a, 0x0c, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x38, 0x0a, 0x15, 0x4f, 0x70, 0x65, 0x6e, 0x43, 0x79, 0x63,
+	0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x73, 0x22,
+	0x6e, 0x0a, 0x0a, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1d, 0x0a,
+	0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x5f, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x51,
+	0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0x54, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2d, 0x0a, 0x06, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f,
+	0x72, 0x79, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x22, 0x9c, 0x02, 0x0a, 0x0f, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e,
+	0x63, 0x65, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x5f, 0x71, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x65, 0x64, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x21, 0x0a,
+	0x0c, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x6f, 0x63, 0x6b,
+	0x12, 0x1a, 0x0a, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x6f, 0x63,
+	0x6b, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x73,
+	0x74, 0x6f, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x70,
+	0x70, 0x6c, 0x69, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x70, 0x70,
+	0x6c, 0x69, 0x65, 0x64, 0x22, 0x85, 0x01, 0x0a, 0x11, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x36, 0x0a, 0x0b, 0x63, 0x79,
+	0x63, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x43, 0x79, 0x63, 0x6c,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0a, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72,
+	0x79, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65,
+	0x77, 0x52, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x2a, 0x96, 0x01, 0x0a,
+	0x10, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x22, 0x0a, 0x1e, 0x43, 0x59, 0x43, 0x4c, 0x45, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x59, 0x43, 0x4c, 0x45, 0x5f, 0x43,
+	0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4f, 0x50, 0x45, 0x4e,
+	0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x43, 0x59, 0x43, 0x4c, 0x45, 0x5f, 0x43, 0x4f, 0x55, 0x4e,
+	0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x41, 0x50, 0x50, 0x52, 0x4f, 0x56, 0x45,
+	0x44, 0x10, 0x02, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x59, 0x43, 0x4c, 0x45, 0x5f, 0x43, 0x4f, 0x55,
+	0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c,
+	0x4c, 0x45, 0x44, 0x10, 0x03, 0x32, 0xbb, 0x03, 0x0a, 0x11, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x49, 0x0a, 0x0e, 0x4f,
+	0x70, 0x65, 0x6e, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x2e,
+	0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x43, 0x79,
+	0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x15, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x43, 0x79, 0x63, 0x6c,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3f, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x43, 0x79, 0x63,
+	0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x17, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74,
+	0x6f, 0x72, 0x79, 0x2e, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x44,
+	0x1a, 0x15, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x43, 0x79, 0x63,
+	0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x45, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x6d, 0x69,
+	0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x1e, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74,
+	0x6f, 0x72, 0x79, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74,
+	0x6f, 0x72, 0x79, 0x2e, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x4a,
+	0x0a, 0x11, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x17, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e,
+	0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x44, 0x1a, 0x1c, 0x2e, 0x69,
+	0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x43, 0x0a, 0x11, 0x41, 0x70,
+	0x70, 0x72, 0x6f, 0x76, 0x65, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x17, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x43, 0x79, 0x63, 0x6c,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x44, 0x1a, 0x15, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e,
+	0x74, 0x6f, 0x72, 0x79, 0x2e, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x42, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x17, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e,
+	0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x44, 0x1a, 0x15, 0x2e, 0x69,
+	0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x66, 0x61, 0x63, 0x65, 0x6c, 0x65, 0x73, 0x73, 0x45, 0x6d, 0x70, 0x74, 0x69, 0x6e,
+	0x65, 0x73, 0x73, 0x2f, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_cycle_count_proto_rawDescOnce sync.Once
+	file_proto_cycle_count_proto_rawDescData = file_proto_cycle_count_proto_rawDesc
+)
+
+func file_proto_cycle_count_proto_rawDescGZIP() []byte {
+	file_proto_cycle_count_proto_rawDescOnce.Do(func() {
+		file_proto_cycle_count_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_cycle_count_proto_rawDescData)
+	})
+	return file_proto_cycle_count_proto_rawDescData
+}
+
+var file_proto_cycle_count_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_cycle_count_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_cycle_count_proto_goTypes = []interface{}{
+	(CycleCountStatus)(0),         // 0: inventory.CycleCountStatus
+	(*CycleCountLine)(nil),        // 1: inventory.CycleCountLine
+	(*CycleCount)(nil),            // 2: inventory.CycleCount
+	(*CycleCountID)(nil),          // 3: inventory.CycleCountID
+	(*OpenCycleCountRequest)(nil), // 4: inventory.OpenCycleCountRequest
+	(*CountEntry)(nil),            // 5: inventory.CountEntry
+	(*SubmitCountsRequest)(nil),   // 6: inventory.SubmitCountsRequest
+	(*VariancePreview)(nil),       // 7: inventory.VariancePreview
+	(*CycleCountPreview)(nil),     // 8: inventory.CycleCountPreview
+	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+}
+var file_proto_cycle_count_proto_depIdxs = []int32{
+	9,  // 0: inventory.CycleCountLine.counted_at:type_name -> google.protobuf.Timestamp
+	0,  // 1: inventory.CycleCount.status:type_name -> inventory.CycleCountStatus
+	1,  // 2: inventory.CycleCount.lines:type_name -> inventory.CycleCountLine
+	9,  // 3: inventory.CycleCount.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 4: inventory.CycleCount.approved_at:type_name -> google.protobuf.Timestamp
+	5,  // 5: inventory.SubmitCountsRequest.counts:type_name -> inventory.CountEntry
+	2,  // 6: inventory.CycleCountPreview.cycle_count:type_name -> inventory.CycleCount
+	7,  // 7: inventory.CycleCountPreview.variances:type_name -> inventory.VariancePreview
+	4,  // 8: inventory.CycleCountService.OpenCycleCount:input_type -> inventory.OpenCycleCountRequest
+	3,  // 9: inventory.CycleCountService.GetCycleCount:input_type -> inventory.CycleCountID
+	6,  // 10: inventory.CycleCountService.SubmitCounts:input_type -> inventory.SubmitCountsRequest
+	3,  // 11: inventory.CycleCountService.PreviewCycleCount:input_type -> inventory.CycleCountID
+	3,  // 12: inventory.CycleCountService.ApproveCycleCount:input_type -> inventory.CycleCountID
+	3,  // 13: inventory.CycleCountService.CancelCycleCount:input_type -> inventory.CycleCountID
+	2,  // 14: inventory.CycleCountService.OpenCycleCount:output_type -> inventory.CycleCount
+	2,  // 15: inventory.CycleCountService.GetCycleCount:output_type -> inventory.CycleCount
+	2,  // 16: inventory.CycleCountService.SubmitCounts:output_type -> inventory.CycleCount
+	8,  // 17: inventory.CycleCountService.PreviewCycleCount:output_type -> inventory.CycleCountPreview
+	2,  // 18: inventory.CycleCountService.ApproveCycleCount:output_type -> inventory.CycleCount
+	2,  // 19: inventory.CycleCountService.CancelCycleCount:output_type -> inventory.CycleCount
+	14, // [14:20] is the sub-list for method output_type
+	8,  // [8:14] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_proto_cycle_count_proto_init() }
+func file_proto_cycle_count_proto_init() {
+	if File_proto_cycle_count_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_cycle_count_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CycleCountLine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cycle_count_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CycleCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cycle_count_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CycleCountID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cycle_count_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenCycleCountRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cycle_count_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cycle_count_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubmitCountsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cycle_count_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VariancePreview); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cycle_count_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CycleCountPreview); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_cycle_count_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_cycle_count_proto_goTypes,
+		DependencyIndexes: file_proto_cycle_count_proto_depIdxs,
+		EnumInfos:         file_proto_cycle_count_proto_enumTypes,
+		MessageInfos:      file_proto_cycle_count_proto_msgTypes,
+	}.Build()
+	File_proto_cycle_count_proto = out.File
+	file_proto_cycle_count_proto_rawDesc = nil
+	file_proto_cycle_count_proto_goTypes = nil
+	file_proto_cycle_count_proto_depIdxs = nil
+}
@@ -0,0 +1,296 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v6.30.2
+// source: proto/cycle_count.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CycleCountService_OpenCycleCount_FullMethodName    = "/inventory.CycleCountService/OpenCycleCount"
+	CycleCountService_GetCycleCount_FullMethodName     = "/inventory.CycleCountService/GetCycleCount"
+	CycleCountService_SubmitCounts_FullMethodName      = "/inventory.CycleCountService/SubmitCounts"
+	CycleCountService_PreviewCycleCount_FullMethodName = "/inventory.CycleCountService/PreviewCycleCount"
+	CycleCountService_ApproveCycleCount_FullMethodName = "/inventory.CycleCountService/ApproveCycleCount"
+	CycleCountService_CancelCycleCount_FullMethodName  = "/inventory.CycleCountService/CancelCycleCount"
+)
+
+// CycleCountServiceClient is the client API for CycleCountService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CycleCountServiceClient interface {
+	OpenCycleCount(ctx context.Context, in *OpenCycleCountRequest, opts ...grpc.CallOption) (*CycleCount, error)
+	GetCycleCount(ctx context.Context, in *CycleCountID, opts ...grpc.CallOption) (*CycleCount, error)
+	SubmitCounts(ctx context.Context, in *SubmitCountsRequest, opts ...grpc.CallOption) (*CycleCount, error)
+	// PreviewCycleCount shows the variances approving the session would book.
+	PreviewCycleCount(ctx context.Context, in *CycleCountID, opts ...grpc.CallOption) (*CycleCountPreview, error)
+	ApproveCycleCount(ctx context.Context, in *CycleCountID, opts ...grpc.CallOption) (*CycleCount, error)
+	CancelCycleCount(ctx context.Context, in *CycleCountID, opts ...grpc.CallOption) (*CycleCount, error)
+}
+
+type cycleCountServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCycleCountServiceClient(cc grpc.ClientConnInterface) CycleCountServiceClient {
+	return &cycleCountServiceClient{cc}
+}
+
+func (c *cycleCountServiceClient) OpenCycleCount(ctx context.Context, in *OpenCycleCountRequest, opts ...grpc.CallOption) (*CycleCount, error) {
+	out := new(CycleCount)
+	err := c.cc.Invoke(ctx, CycleCountService_OpenCycleCount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cycleCountServiceClient) GetCycleCount(ctx context.Context, in *CycleCountID, opts ...grpc.CallOption) (*CycleCount, error) {
+	out := new(CycleCount)
+	err := c.cc.Invoke(ctx, CycleCountService_GetCycleCount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cycleCountServiceClient) SubmitCounts(ctx context.Context, in *SubmitCountsRequest, opts ...grpc.CallOption) (*CycleCount, error) {
+	out := new(CycleCount)
+	err := c.cc.Invoke(ctx, CycleCountService_SubmitCounts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cycleCountServiceClient) PreviewCycleCount(ctx context.Context, in *CycleCountID, opts ...grpc.CallOption) (*CycleCountPreview, error) {
+	out := new(CycleCountPreview)
+	err := c.cc.Invoke(ctx, CycleCountService_PreviewCycleCount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cycleCountServiceClient) ApproveCycleCount(ctx context.Context, in *CycleCountID, opts ...grpc.CallOption) (*CycleCount, error) {
+	out := new(CycleCount)
+	err := c.cc.Invoke(ctx, CycleCountService_ApproveCycleCount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cycleCountServiceClient) CancelCycleCount(ctx context.Context, in *CycleCountID, opts ...grpc.CallOption) (*CycleCount, error) {
+	out := new(CycleCount)
+	err := c.cc.Invoke(ctx, CycleCountService_CancelCycleCount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CycleCountServiceServer is the server API for CycleCountService service.
+// All implementations must embed UnimplementedCycleCountServiceServer
+// for forward compatibility
+type CycleCountServiceServer interface {
+	OpenCycleCount(context.Context, *OpenCycleCountRequest) (*CycleCount, error)
+	GetCycleCount(context.Context, *CycleCountID) (*CycleCount, error)
+	SubmitCounts(context.Context, *SubmitCountsRequest) (*CycleCount, error)
+	// PreviewCycleCount shows the variances approving the session would book.
+	PreviewCycleCount(context.Context, *CycleCountID) (*CycleCountPreview, error)
+	ApproveCycleCount(context.Context, *CycleCountID) (*CycleCount, error)
+	CancelCycleCount(context.Context, *CycleCountID) (*CycleCount, error)
+	mustEmbedUnimplementedCycleCountServiceServer()
+}
+
+// UnimplementedCycleCountServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCycleCountServiceServer struct {
+}
+
+func (UnimplementedCycleCountServiceServer) OpenCycleCount(context.Context, *OpenCycleCountRequest) (*CycleCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenCycleCount not implemented")
+}
+func (UnimplementedCycleCountServiceServer) GetCycleCount(context.Context, *CycleCountID) (*CycleCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCycleCount not implemented")
+}
+func (UnimplementedCycleCountServiceServer) SubmitCounts(context.Context, *SubmitCountsRequest) (*CycleCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitCounts not implemented")
+}
+func (UnimplementedCycleCountServiceServer) PreviewCycleCount(context.Context, *CycleCountID) (*CycleCountPreview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewCycleCount not implemented")
+}
+func (UnimplementedCycleCountServiceServer) ApproveCycleCount(context.Context, *CycleCountID) (*CycleCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveCycleCount not implemented")
+}
+func (UnimplementedCycleCountServiceServer) CancelCycleCount(context.Context, *CycleCountID) (*CycleCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelCycleCount not implemented")
+}
+func (UnimplementedCycleCountServiceServer) mustEmbedUnimplementedCycleCountServiceServer() {}
+
+// UnsafeCycleCountServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CycleCountServiceServer will
+// result in compilation errors.
+type UnsafeCycleCountServiceServer interface {
+	mustEmbedUnimplementedCycleCountServiceServer()
+}
+
+func RegisterCycleCountServiceServer(s grpc.ServiceRegistrar, srv CycleCountServiceServer) {
+	s.RegisterService(&CycleCountService_ServiceDesc, srv)
+}
+
+func _CycleCountService_OpenCycleCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenCycleCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CycleCountServiceServer).OpenCycleCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CycleCountService_OpenCycleCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CycleCountServiceServer).OpenCycleCount(ctx, req.(*OpenCycleCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CycleCountService_GetCycleCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CycleCountID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CycleCountServiceServer).GetCycleCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CycleCountService_GetCycleCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CycleCountServiceServer).GetCycleCount(ctx, req.(*CycleCountID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CycleCountService_SubmitCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CycleCountServiceServer).SubmitCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CycleCountService_SubmitCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CycleCountServiceServer).SubmitCounts(ctx, req.(*SubmitCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CycleCountService_PreviewCycleCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CycleCountID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CycleCountServiceServer).PreviewCycleCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CycleCountService_PreviewCycleCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CycleCountServiceServer).PreviewCycleCount(ctx, req.(*CycleCountID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CycleCountService_ApproveCycleCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CycleCountID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CycleCountServiceServer).ApproveCycleCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CycleCountService_ApproveCycleCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CycleCountServiceServer).ApproveCycleCount(ctx, req.(*CycleCountID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CycleCountService_CancelCycleCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CycleCountID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CycleCountServiceServer).CancelCycleCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CycleCountService_CancelCycleCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CycleCountServiceServer).CancelCycleCount(ctx, req.(*CycleCountID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CycleCountService_ServiceDesc is the grpc.ServiceDesc for CycleCountService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CycleCountService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inventory.CycleCountService",
+	HandlerType: (*CycleCountServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "OpenCycleCount",
+			Handler:    _CycleCountService_OpenCycleCount_Handler,
+		},
+		{
+			MethodName: "GetCycleCount",
+			Handler:    _CycleCountService_GetCycleCount_Handler,
+		},
+		{
+			MethodName: "SubmitCounts",
+			Handler:    _CycleCountService_SubmitCounts_Handler,
+		},
+		{
+			MethodName: "PreviewCycleCount",
+			Handler:    _CycleCountService_PreviewCycleCount_Handler,
+		},
+		{
+			MethodName: "ApproveCycleCount",
+			Handler:    _CycleCountService_ApproveCycleCount_Handler,
+		},
+		{
+			MethodName: "CancelCycleCount",
+			Handler:    _CycleCountService_CancelCycleCount_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/cycle_count.proto",
+}
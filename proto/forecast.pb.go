@@ -0,0 +1,791 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v6.30.2
+// source: proto/forecast.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ForecastMethod int32
+
+const (
+	ForecastMethod_FORECAST_METHOD_UNSPECIFIED           ForecastMethod = 0
+	ForecastMethod_FORECAST_METHOD_MOVING_AVERAGE        ForecastMethod = 1
+	ForecastMethod_FORECAST_METHOD_EXPONENTIAL_SMOOTHING ForecastMethod = 2
+)
+
+// Enum value maps for ForecastMethod.
+var (
+	ForecastMethod_name = map[int32]string{
+		0: "FORECAST_METHOD_UNSPECIFIED",
+		1: "FORECAST_METHOD_MOVING_AVERAGE",
+		2: "FORECAST_METHOD_EXPONENTIAL_SMOOTHING",
+	}
+	ForecastMethod_value = map[string]int32{
+		"FORECAST_METHOD_UNSPECIFIED":           0,
+		"FORECAST_METHOD_MOVING_AVERAGE":        1,
+		"FORECAST_METHOD_EXPONENTIAL_SMOOTHING": 2,
+	}
+)
+
+func (x ForecastMethod) Enum() *ForecastMethod {
+	p := new(ForecastMethod)
+	*p = x
+	return p
+}
+
+func (x ForecastMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ForecastMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_forecast_proto_enumTypes[0].Descriptor()
+}
+
+func (ForecastMethod) Type() protoreflect.EnumType {
+	return &file_proto_forecast_proto_enumTypes[0]
+}
+
+func (x ForecastMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ForecastMethod.Descriptor instead.
+func (ForecastMethod) EnumDescriptor() ([]byte, []int) {
+	return file_proto_forecast_proto_rawDescGZIP(), []int{0}
+}
+
+// ForecastParams override the service defaults; unset fields keep them.
+type ForecastParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Method ForecastMethod `protobuf:"varint,1,opt,name=method,proto3,enum=inventory.ForecastMethod" json:"method,omitempty"`
+	// Days of sales history to look at.
+	HistoryDays int32 `protobuf:"varint,2,opt,name=history_days,json=historyDays,proto3" json:"history_days,omitempty"`
+	// Days averaged by the moving average.
+	WindowDays int32 `protobuf:"varint,3,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	// Smoothing factor of exponential smoothing, in (0, 1].
+	Alpha float64 `protobuf:"fixed64,4,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	// Target probability of not running out between replenishments.
+	ServiceLevel float64 `protobuf:"fixed64,5,opt,name=service_level,json=serviceLevel,proto3" json:"service_level,omitempty"`
+	// Replaces the supplier lead time of every product.
+	LeadTimeDays float64 `protobuf:"fixed64,6,opt,name=lead_time_days,json=leadTimeDays,proto3" json:"lead_time_days,omitempty"`
+	// Days of demand an order covers beyond the reorder point.
+	ReviewDays float64 `protobuf:"fixed64,7,opt,name=review_days,json=reviewDays,proto3" json:"review_days,omitempty"`
+}
+
+func (x *ForecastParams) Reset() {
+	*x = ForecastParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_forecast_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForecastParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastParams) ProtoMessage() {}
+
+func (x *ForecastParams) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_forecast_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastParams.ProtoReflect.Descriptor instead.
+func (*ForecastParams) Descriptor() ([]byte, []int) {
+	return file_proto_forecast_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ForecastParams) GetMethod() ForecastMethod {
+	if x != nil {
+		return x.Method
+	}
+	return ForecastMethod_FORECAST_METHOD_UNSPECIFIED
+}
+
+func (x *ForecastParams) GetHistoryDays() int32 {
+	if x != nil {
+		return x.HistoryDays
+	}
+	return 0
+}
+
+func (x *ForecastParams) GetWindowDays() int32 {
+	if x != nil {
+		return x.WindowDays
+	}
+	return 0
+}
+
+func (x *ForecastParams) GetAlpha() float64 {
+	if x != nil {
+		return x.Alpha
+	}
+	return 0
+}
+
+func (x *ForecastParams) GetServiceLevel() float64 {
+	if x != nil {
+		return x.ServiceLevel
+	}
+	return 0
+}
+
+func (x *ForecastParams) GetLeadTimeDays() float64 {
+	if x != nil {
+		return x.LeadTimeDays
+	}
+	return 0
+}
+
+func (x *ForecastParams) GetReviewDays() float64 {
+	if x != nil {
+		return x.ReviewDays
+	}
+	return 0
+}
+
+type DemandForecastRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProductId string          `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Params    *ForecastParams `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
+}
+
+func (x *DemandForecastRequest) Reset() {
+	*x = DemandForecastRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_forecast_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DemandForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DemandForecastRequest) ProtoMessage() {}
+
+func (x *DemandForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_forecast_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DemandForecastRequest.ProtoReflect.Descriptor instead.
+func (*DemandForecastRequest) Descriptor() ([]byte, []int) {
+	return file_proto_forecast_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DemandForecastRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *DemandForecastRequest) GetParams() *ForecastParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type DemandForecast struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProductId   string         `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Method      ForecastMethod `protobuf:"varint,2,opt,name=method,proto3,enum=inventory.ForecastMethod" json:"method,omitempty"`
+	DailyDemand float64        `protobuf:"fixed64,3,opt,name=daily_demand,json=dailyDemand,proto3" json:"daily_demand,omitempty"`
+	DailyStdDev float64        `protobuf:"fixed64,4,opt,name=daily_std_dev,json=dailyStdDev,proto3" json:"daily_std_dev,omitempty"`
+	HistoryDays int32          `protobuf:"varint,5,opt,name=history_days,json=historyDays,proto3" json:"history_days,omitempty"`
+}
+
+func (x *DemandForecast) Reset() {
+	*x = DemandForecast{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_forecast_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DemandForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DemandForecast) ProtoMessage() {}
+
+func (x *DemandForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_forecast_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DemandForecast.ProtoReflect.Descriptor instead.
+func (*DemandForecast) Descriptor() ([]byte, []int) {
+	return file_proto_forecast_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DemandForecast) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *DemandForecast) GetMethod() ForecastMethod {
+	if x != nil {
+		return x.Method
+	}
+	return ForecastMethod_FORECAST_METHOD_UNSPECIFIED
+}
+
+func (x *DemandForecast) GetDailyDemand() float64 {
+	if x != nil {
+		return x.DailyDemand
+	}
+	return 0
+}
+
+func (x *DemandForecast) GetDailyStdDev() float64 {
+	if x != nil {
+		return x.DailyStdDev
+	}
+	return 0
+}
+
+func (x *DemandForecast) GetHistoryDays() int32 {
+	if x != nil {
+		return x.HistoryDays
+	}
+	return 0
+}
+
+type SuggestReordersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Products to plan for; every product when empty.
+	ProductIds []string        `protobuf:"bytes,1,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	Params     *ForecastParams `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
+}
+
+func (x *SuggestReordersRequest) Reset() {
+	*x = SuggestReordersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_forecast_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuggestReordersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestReordersRequest) ProtoMessage() {}
+
+func (x *SuggestReordersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_forecast_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestReordersRequest.ProtoReflect.Descriptor instead.
+func (*SuggestReordersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_forecast_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SuggestReordersRequest) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *SuggestReordersRequest) GetParams() *ForecastParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type ReorderSuggestion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProductId string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Sku       string `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name      string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Stock     int32  `protobuf:"varint,4,opt,name=stock,proto3" json:"stock,omitempty"`
+	// Quantity still to be delivered by open purchase orders.
+	OnOrder           int32   `protobuf:"varint,5,opt,name=on_order,json=onOrder,proto3" json:"on_order,omitempty"`
+	DailyDemand       float64 `protobuf:"fixed64,6,opt,name=daily_demand,json=dailyDemand,proto3" json:"daily_demand,omitempty"`
+	DailyStdDev       float64 `protobuf:"fixed64,7,opt,name=daily_std_dev,json=dailyStdDev,proto3" json:"daily_std_dev,omitempty"`
+	LeadTimeDays      float64 `protobuf:"fixed64,8,opt,name=lead_time_days,json=leadTimeDays,proto3" json:"lead_time_days,omitempty"`
+	SafetyStock       int32   `protobuf:"varint,9,opt,name=safety_stock,json=safetyStock,proto3" json:"safety_stock,omitempty"`
+	ReorderPoint      int32   `protobuf:"varint,10,opt,name=reorder_point,json=reorderPoint,proto3" json:"reorder_point,omitempty"`
+	SuggestedQuantity int32   `protobuf:"varint,11,opt,name=suggested_quantity,json=suggestedQuantity,proto3" json:"suggested_quantity,omitempty"`
+	// Cheapest supplier of the product, if it has one.
+	SupplierId string `protobuf:"bytes,12,opt,name=supplier_id,json=supplierId,proto3" json:"supplier_id,omitempty"`
+}
+
+func (x *ReorderSuggestion) Reset() {
+	*x = ReorderSuggestion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_forecast_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReorderSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderSuggestion) ProtoMessage() {}
+
+func (x *ReorderSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_forecast_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderSuggestion.ProtoReflect.Descriptor instead.
+func (*ReorderSuggestion) Descriptor() ([]byte, []int) {
+	return file_proto_forecast_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReorderSuggestion) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReorderSuggestion) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ReorderSuggestion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReorderSuggestion) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetOnOrder() int32 {
+	if x != nil {
+		return x.OnOrder
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetDailyDemand() float64 {
+	if x != nil {
+		return x.DailyDemand
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetDailyStdDev() float64 {
+	if x != nil {
+		return x.DailyStdDev
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetLeadTimeDays() float64 {
+	if x != nil {
+		return x.LeadTimeDays
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetSafetyStock() int32 {
+	if x != nil {
+		return x.SafetyStock
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetReorderPoint() int32 {
+	if x != nil {
+		return x.ReorderPoint
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetSuggestedQuantity() int32 {
+	if x != nil {
+		return x.SuggestedQuantity
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetSupplierId() string {
+	if x != nil {
+		return x.SupplierId
+	}
+	return ""
+}
+
+type ReorderSuggestions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Suggestions []*ReorderSuggestion `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+}
+
+func (x *ReorderSuggestions) Reset() {
+	*x = ReorderSuggestions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_forecast_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReorderSuggestions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderSuggestions) ProtoMessage() {}
+
+func (x *ReorderSuggestions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_forecast_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderSuggestions.ProtoReflect.Descriptor instead.
+func (*ReorderSuggestions) Descriptor() ([]byte, []int) {
+	return file_proto_forecast_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReorderSuggestions) GetSuggestions() []*ReorderSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+var File_proto_forecast_proto protoreflect.FileDescriptor
+
+var file_proto_forecast_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72,
+	0x79, 0x22, 0x89, 0x02, 0x0a, 0x0e, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79,
+	0x2e, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52,
+	0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x68,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x44, 0x61, 0x79, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x44, 0x61, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x65, 0x61, 0x64, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c,
+	0x6c, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x44, 0x61, 0x79, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0a, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x44, 0x61, 0x79, 0x73, 0x22, 0x69, 0x0a,
+	0x15, 0x44, 0x65, 0x6d, 0x61, 0x6e, 0x64, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72,
+	0x79, 0x2e, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0xcc, 0x01, 0x0a, 0x0e, 0x44, 0x65, 0x6d,
+	0x61, 0x6e, 0x64, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x06, 0x6d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x69, 0x6e, 0x76,
+	0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x4d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x21, 0x0a,
+	0x0c, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x64, 0x65, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0b, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x44, 0x65, 0x6d, 0x61, 0x6e, 0x64,
+	0x12, 0x22, 0x0a, 0x0d, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x73, 0x74, 0x64, 0x5f, 0x64, 0x65,
+	0x76, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x53, 0x74,
+	0x64, 0x44, 0x65, 0x76, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x5f,
+	0x64, 0x61, 0x79, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x68, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x44, 0x61, 0x79, 0x73, 0x22, 0x6c, 0x0a, 0x16, 0x53, 0x75, 0x67, 0x67, 0x65,
+	0x73, 0x74, 0x52, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49,
+	0x64, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x46,
+	0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x8e, 0x03, 0x0a, 0x11, 0x52, 0x65, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x6b,
+	0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x6b, 0x75, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x6e, 0x5f, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x6e, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x64, 0x65, 0x6d, 0x61, 0x6e,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x44, 0x65,
+	0x6d, 0x61, 0x6e, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x73, 0x74,
+	0x64, 0x5f, 0x64, 0x65, 0x76, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x64, 0x61, 0x69,
+	0x6c, 0x79, 0x53, 0x74, 0x64, 0x44, 0x65, 0x76, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x65, 0x61, 0x64,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0c, 0x6c, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x44, 0x61, 0x79, 0x73, 0x12, 0x21,
+	0x0a, 0x0c, 0x73, 0x61, 0x66, 0x65, 0x74, 0x79, 0x5f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x61, 0x66, 0x65, 0x74, 0x79, 0x53, 0x74, 0x6f, 0x63,
+	0x6b, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x72, 0x65, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x5f, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x11, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x51, 0x75, 0x61,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x75, 0x70, 0x70,
+	0x6c, 0x69, 0x65, 0x72, 0x49, 0x64, 0x22, 0x54, 0x0a, 0x12, 0x52, 0x65, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3e, 0x0a, 0x0b,
+	0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x52, 0x65,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0b, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2a, 0x80, 0x01, 0x0a,
+	0x0e, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12,
+	0x1f, 0x0a, 0x1b, 0x46, 0x4f, 0x52, 0x45, 0x43, 0x41, 0x53, 0x54, 0x5f, 0x4d, 0x45, 0x54, 0x48,
+	0x4f, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x22, 0x0a, 0x1e, 0x46, 0x4f, 0x52, 0x45, 0x43, 0x41, 0x53, 0x54, 0x5f, 0x4d, 0x45, 0x54,
+	0x48, 0x4f, 0x44, 0x5f, 0x4d, 0x4f, 0x56, 0x49, 0x4e, 0x47, 0x5f, 0x41, 0x56, 0x45, 0x52, 0x41,
+	0x47, 0x45, 0x10, 0x01, 0x12, 0x29, 0x0a, 0x25, 0x46, 0x4f, 0x52, 0x45, 0x43, 0x41, 0x53, 0x54,
+	0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x45, 0x58, 0x50, 0x4f, 0x4e, 0x45, 0x4e, 0x54,
+	0x49, 0x41, 0x4c, 0x5f, 0x53, 0x4d, 0x4f, 0x4f, 0x54, 0x48, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x32,
+	0xb8, 0x01, 0x0a, 0x0f, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x44, 0x65, 0x6d, 0x61, 0x6e, 0x64,
+	0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x12, 0x20, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e,
+	0x74, 0x6f, 0x72, 0x79, 0x2e, 0x44, 0x65, 0x6d, 0x61, 0x6e, 0x64, 0x46, 0x6f, 0x72, 0x65, 0x63,
+	0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x69, 0x6e, 0x76,
+	0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x44, 0x65, 0x6d, 0x61, 0x6e, 0x64, 0x46, 0x6f, 0x72,
+	0x65, 0x63, 0x61, 0x73, 0x74, 0x12, 0x53, 0x0a, 0x0f, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74,
+	0x52, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12, 0x21, 0x2e, 0x69, 0x6e, 0x76, 0x65, 0x6e,
+	0x74, 0x6f, 0x72, 0x79, 0x2e, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x69, 0x6e,
+	0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x52, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x53,
+	0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x61, 0x63, 0x65, 0x6c, 0x65, 0x73,
+	0x73, 0x45, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x2f, 0x69, 0x6e, 0x76, 0x65, 0x6e,
+	0x74, 0x6f, 0x72, 0x79, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_forecast_proto_rawDescOnce sync.Once
+	file_proto_forecast_proto_rawDescData = file_proto_forecast_proto_rawDesc
+)
+
+func file_proto_forecast_proto_rawDescGZIP() []byte {
+	file_proto_forecast_proto_rawDescOnce.Do(func() {
+		file_proto_forecast_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_forecast_proto_rawDescData)
+	})
+	return file_proto_forecast_proto_rawDescData
+}
+
+var file_proto_forecast_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_forecast_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_forecast_proto_goTypes = []interface{}{
+	(ForecastMethod)(0),            // 0: inventory.ForecastMethod
+	(*ForecastParams)(nil),         // 1: inventory.ForecastParams
+	(*DemandForecastRequest)(nil),  // 2: inventory.DemandForecastRequest
+	(*DemandForecast)(nil),         // 3: inventory.DemandForecast
+	(*SuggestReordersRequest)(nil), // 4: inventory.SuggestReordersRequest
+	(*ReorderSuggestion)(nil),      // 5: inventory.ReorderSuggestion
+	(*ReorderSuggestions)(nil),     // 6: inventory.ReorderSuggestions
+}
+var file_proto_forecast_proto_depIdxs = []int32{
+	0, // 0: inventory.ForecastParams.method:type_name -> inventory.ForecastMethod
+	1, // 1: inventory.DemandForecastRequest.params:type_name -> inventory.ForecastParams
+	0, // 2: inventory.DemandForecast.method:type_name -> inventory.ForecastMethod
+	1, // 3: inventory.SuggestReordersRequest.params:type_name -> inventory.ForecastParams
+	5, // 4: inventory.ReorderSuggestions.suggestions:type_name -> inventory.ReorderSuggestion
+	2, // 5: inventory.ForecastService.GetDemandForecast:input_type -> inventory.DemandForecastRequest
+	4, // 6: inventory.ForecastService.SuggestReorders:input_type -> inventory.SuggestReordersRequest
+	3, // 7: inventory.ForecastService.GetDemandForecast:output_type -> inventory.DemandForecast
+	6, // 8: inventory.ForecastService.SuggestReorders:output_type -> inventory.ReorderSuggestions
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_proto_forecast_proto_init() }
+func file_proto_forecast_proto_init() {
+	if File_proto_forecast_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_forecast_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForecastParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_forecast_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DemandForecastRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_forecast_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DemandForecast); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_forecast_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SuggestReordersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_forecast_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReorderSuggestion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_forecast_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReorderSuggestions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_forecast_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_forecast_proto_goTypes,
+		DependencyIndexes: file_proto_forecast_proto_depIdxs,
+		EnumInfos:         file_proto_forecast_proto_enumTypes,
+		MessageInfos:      file_proto_forecast_proto_msgTypes,
+	}.Build()
+	File_proto_forecast_proto = out.File
+	file_proto_forecast_proto_rawDesc = nil
+	file_proto_forecast_proto_goTypes = nil
+	file_proto_forecast_proto_depIdxs = nil
+}
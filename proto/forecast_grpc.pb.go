@@ -0,0 +1,152 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v6.30.2
+// source: proto/forecast.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ForecastService_GetDemandForecast_FullMethodName = "/inventory.ForecastService/GetDemandForecast"
+	ForecastService_SuggestReorders_FullMethodName   = "/inventory.ForecastService/SuggestReorders"
+)
+
+// ForecastServiceClient is the client API for ForecastService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ForecastServiceClient interface {
+	// GetDemandForecast estimates a product's daily demand from its sales.
+	GetDemandForecast(ctx context.Context, in *DemandForecastRequest, opts ...grpc.CallOption) (*DemandForecast, error)
+	// SuggestReorders proposes order quantities for the products whose stock
+	// position has fallen to their forecast reorder point.
+	SuggestReorders(ctx context.Context, in *SuggestReordersRequest, opts ...grpc.CallOption) (*ReorderSuggestions, error)
+}
+
+type forecastServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewForecastServiceClient(cc grpc.ClientConnInterface) ForecastServiceClient {
+	return &forecastServiceClient{cc}
+}
+
+func (c *forecastServiceClient) GetDemandForecast(ctx context.Context, in *DemandForecastRequest, opts ...grpc.CallOption) (*DemandForecast, error) {
+	out := new(DemandForecast)
+	err := c.cc.Invoke(ctx, ForecastService_GetDemandForecast_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forecastServiceClient) SuggestReorders(ctx context.Context, in *SuggestReordersRequest, opts ...grpc.CallOption) (*ReorderSuggestions, error) {
+	out := new(ReorderSuggestions)
+	err := c.cc.Invoke(ctx, ForecastService_SuggestReorders_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ForecastServiceServer is the server API for ForecastService service.
+// All implementations must embed UnimplementedForecastServiceServer
+// for forward compatibility
+type ForecastServiceServer interface {
+	// GetDemandForecast estimates a product's daily demand from its sales.
+	GetDemandForecast(context.Context, *DemandForecastRequest) (*DemandForecast, error)
+	// SuggestReorders proposes order quantities for the products whose stock
+	// position has fallen to their forecast reorder point.
+	SuggestReorders(context.Context, *SuggestReordersRequest) (*ReorderSuggestions, error)
+	mustEmbedUnimplementedForecastServiceServer()
+}
+
+// UnimplementedForecastServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedForecastServiceServer struct {
+}
+
+func (UnimplementedForecastServiceServer) GetDemandForecast(context.Context, *DemandForecastRequest) (*DemandForecast, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDemandForecast not implemented")
+}
+func (UnimplementedForecastServiceServer) SuggestReorders(context.Context, *SuggestReordersRequest) (*ReorderSuggestions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestReorders not implemented")
+}
+func (UnimplementedForecastServiceServer) mustEmbedUnimplementedForecastServiceServer() {}
+
+// UnsafeForecastServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ForecastServiceServer will
+// result in compilation errors.
+type UnsafeForecastServiceServer interface {
+	mustEmbedUnimplementedForecastServiceServer()
+}
+
+func RegisterForecastServiceServer(s grpc.ServiceRegistrar, srv ForecastServiceServer) {
+	s.RegisterService(&ForecastService_ServiceDesc, srv)
+}
+
+func _ForecastService_GetDemandForecast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DemandForecastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForecastServiceServer).GetDemandForecast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForecastService_GetDemandForecast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForecastServiceServer).GetDemandForecast(ctx, req.(*DemandForecastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForecastService_SuggestReorders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestReordersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForecastServiceServer).SuggestReorders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForecastService_SuggestReorders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForecastServiceServer).SuggestReorders(ctx, req.(*SuggestReordersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ForecastService_ServiceDesc is the grpc.ServiceDesc for ForecastService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ForecastService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inventory.ForecastService",
+	HandlerType: (*ForecastServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDemandForecast",
+			Handler:    _ForecastService_GetDemandForecast_Handler,
+		},
+		{
+			MethodName: "SuggestReorders",
+			Handler:    _ForecastService_SuggestReorders_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/forecast.proto",
+}
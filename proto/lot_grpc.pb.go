@@ -0,0 +1,183 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v6.30.2
+// source: proto/lot.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	LotService_ReceiveLot_FullMethodName       = "/inventory.LotService/ReceiveLot"
+	LotService_ListLots_FullMethodName         = "/inventory.LotService/ListLots"
+	LotService_ListExpiringLots_FullMethodName = "/inventory.LotService/ListExpiringLots"
+)
+
+// LotServiceClient is the client API for LotService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LotServiceClient interface {
+	ReceiveLot(ctx context.Context, in *ReceiveLotRequest, opts ...grpc.CallOption) (*Lot, error)
+	ListLots(ctx context.Context, in *ProductID, opts ...grpc.CallOption) (*LotList, error)
+	ListExpiringLots(ctx context.Context, in *ListExpiringLotsRequest, opts ...grpc.CallOption) (*LotList, error)
+}
+
+type lotServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLotServiceClient(cc grpc.ClientConnInterface) LotServiceClient {
+	return &lotServiceClient{cc}
+}
+
+func (c *lotServiceClient) ReceiveLot(ctx context.Context, in *ReceiveLotRequest, opts ...grpc.CallOption) (*Lot, error) {
+	out := new(Lot)
+	err := c.cc.Invoke(ctx, LotService_ReceiveLot_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotServiceClient) ListLots(ctx context.Context, in *ProductID, opts ...grpc.CallOption) (*LotList, error) {
+	out := new(LotList)
+	err := c.cc.Invoke(ctx, LotService_ListLots_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotServiceClient) ListExpiringLots(ctx context.Context, in *ListExpiringLotsRequest, opts ...grpc.CallOption) (*LotList, error) {
+	out := new(LotList)
+	err := c.cc.Invoke(ctx, LotService_ListExpiringLots_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LotServiceServer is the server API for LotService service.
+// All implementations must embed UnimplementedLotServiceServer
+// for forward compatibility
+type LotServiceServer interface {
+	ReceiveLot(context.Context, *ReceiveLotRequest) (*Lot, error)
+	ListLots(context.Context, *ProductID) (*LotList, error)
+	ListExpiringLots(context.Context, *ListExpiringLotsRequest) (*LotList, error)
+	mustEmbedUnimplementedLotServiceServer()
+}
+
+// UnimplementedLotServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedLotServiceServer struct {
+}
+
+func (UnimplementedLotServiceServer) ReceiveLot(context.Context, *ReceiveLotRequest) (*Lot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveLot not implemented")
+}
+func (UnimplementedLotServiceServer) ListLots(context.Context, *ProductID) (*LotList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLots not implemented")
+}
+func (UnimplementedLotServiceServer) ListExpiringLots(context.Context, *ListExpiringLotsRequest) (*LotList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExpiringLots not implemented")
+}
+func (UnimplementedLotServiceServer) mustEmbedUnimplementedLotServiceServer() {}
+
+// UnsafeLotServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LotServiceServer will
+// result in compilation errors.
+type UnsafeLotServiceServer interface {
+	mustEmbedUnimplementedLotServiceServer()
+}
+
+func RegisterLotServiceServer(s grpc.ServiceRegistrar, srv LotServiceServer) {
+	s.RegisterService(&LotService_ServiceDesc, srv)
+}
+
+func _LotService_ReceiveLot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveLotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotServiceServer).ReceiveLot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotService_ReceiveLot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotServiceServer).ReceiveLot(ctx, req.(*ReceiveLotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotService_ListLots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotServiceServer).ListLots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotService_ListLots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotServiceServer).ListLots(ctx, req.(*ProductID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotService_ListExpiringLots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExpiringLotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotServiceServer).ListExpiringLots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotService_ListExpiringLots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotServiceServer).ListExpiringLots(ctx, req.(*ListExpiringLotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LotService_ServiceDesc is the grpc.ServiceDesc for LotService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LotService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inventory.LotService",
+	HandlerType: (*LotServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReceiveLot",
+			Handler:    _LotService_ReceiveLot_Handler,
+		},
+		{
+			MethodName: "ListLots",
+			Handler:    _LotService_ListLots_Handler,
+		},
+		{
+			MethodName: "ListExpiringLots",
+			Handler:    _LotService_ListExpiringLots_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/lot.proto",
+}
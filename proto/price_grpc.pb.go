@@ -0,0 +1,261 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v6.30.2
+// source: proto/price.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PriceService_SchedulePriceChange_FullMethodName        = "/inventory.PriceService/SchedulePriceChange"
+	PriceService_CancelScheduledPriceChange_FullMethodName = "/inventory.PriceService/CancelScheduledPriceChange"
+	PriceService_ListScheduledPriceChanges_FullMethodName  = "/inventory.PriceService/ListScheduledPriceChanges"
+	PriceService_GetPriceHistory_FullMethodName            = "/inventory.PriceService/GetPriceHistory"
+	PriceService_GetPriceAt_FullMethodName                 = "/inventory.PriceService/GetPriceAt"
+)
+
+// PriceServiceClient is the client API for PriceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PriceServiceClient interface {
+	SchedulePriceChange(ctx context.Context, in *SchedulePriceChangeRequest, opts ...grpc.CallOption) (*ScheduledPriceChange, error)
+	CancelScheduledPriceChange(ctx context.Context, in *ScheduledPriceChangeID, opts ...grpc.CallOption) (*ScheduledPriceChange, error)
+	ListScheduledPriceChanges(ctx context.Context, in *ProductID, opts ...grpc.CallOption) (*ScheduledPriceChangeList, error)
+	GetPriceHistory(ctx context.Context, in *ProductID, opts ...grpc.CallOption) (*PriceHistory, error)
+	// GetPriceAt returns the price a product had at a point in time, e.g. to
+	// reconcile a past order.
+	GetPriceAt(ctx context.Context, in *GetPriceAtRequest, opts ...grpc.CallOption) (*PriceRecord, error)
+}
+
+type priceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPriceServiceClient(cc grpc.ClientConnInterface) PriceServiceClient {
+	return &priceServiceClient{cc}
+}
+
+func (c *priceServiceClient) SchedulePriceChange(ctx context.Context, in *SchedulePriceChangeRequest, opts ...grpc.CallOption) (*ScheduledPriceChange, error) {
+	out := new(ScheduledPriceChange)
+	err := c.cc.Invoke(ctx, PriceService_SchedulePriceChange_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceServiceClient) CancelScheduledPriceChange(ctx context.Context, in *ScheduledPriceChangeID, opts ...grpc.CallOption) (*ScheduledPriceChange, error) {
+	out := new(ScheduledPriceChange)
+	err := c.cc.Invoke(ctx, PriceService_CancelScheduledPriceChange_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceServiceClient) ListScheduledPriceChanges(ctx context.Context, in *ProductID, opts ...grpc.CallOption) (*ScheduledPriceChangeList, error) {
+	out := new(ScheduledPriceChangeList)
+	err := c.cc.Invoke(ctx, PriceService_ListScheduledPriceChanges_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceServiceClient) GetPriceHistory(ctx context.Context, in *ProductID, opts ...grpc.CallOption) (*PriceHistory, error) {
+	out := new(PriceHistory)
+	err := c.cc.Invoke(ctx, PriceService_GetPriceHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceServiceClient) GetPriceAt(ctx context.Context, in *GetPriceAtRequest, opts ...grpc.CallOption) (*PriceRecord, error) {
+	out := new(PriceRecord)
+	err := c.cc.Invoke(ctx, PriceService_GetPriceAt_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PriceServiceServer is the server API for PriceService service.
+// All implementations must embed UnimplementedPriceServiceServer
+// for forward compatibility
+type PriceServiceServer interface {
+	SchedulePriceChange(context.Context, *SchedulePriceChangeRequest) (*ScheduledPriceChange, error)
+	CancelScheduledPriceChange(context.Context, *ScheduledPriceChangeID) (*ScheduledPriceChange, error)
+	ListScheduledPriceChanges(context.Context, *ProductID) (*ScheduledPriceChangeList, error)
+	GetPriceHistory(context.Context, *ProductID) (*PriceHistory, error)
+	// GetPriceAt returns the price a product had at a point in time, e.g. to
+	// reconcile a past order.
+	GetPriceAt(context.Context, *GetPriceAtRequest) (*PriceRecord, error)
+	mustEmbedUnimplementedPriceServiceServer()
+}
+
+// UnimplementedPriceServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPriceServiceServer struct {
+}
+
+func (UnimplementedPriceServiceServer) SchedulePriceChange(context.Context, *SchedulePriceChangeRequest) (*ScheduledPriceChange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SchedulePriceChange not implemented")
+}
+func (UnimplementedPriceServiceServer) CancelScheduledPriceChange(context.Context, *ScheduledPriceChangeID) (*ScheduledPriceChange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledPriceChange not implemented")
+}
+func (UnimplementedPriceServiceServer) ListScheduledPriceChanges(context.Context, *ProductID) (*ScheduledPriceChangeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledPriceChanges not implemented")
+}
+func (UnimplementedPriceServiceServer) GetPriceHistory(context.Context, *ProductID) (*PriceHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceHistory not implemented")
+}
+func (UnimplementedPriceServiceServer) GetPriceAt(context.Context, *GetPriceAtRequest) (*PriceRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceAt not implemented")
+}
+func (UnimplementedPriceServiceServer) mustEmbedUnimplementedPriceServiceServer() {}
+
+// UnsafePriceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PriceServiceServer will
+// result in compilation errors.
+type UnsafePriceServiceServer interface {
+	mustEmbedUnimplementedPriceServiceServer()
+}
+
+func RegisterPriceServiceServer(s grpc.ServiceRegistrar, srv PriceServiceServer) {
+	s.RegisterService(&PriceService_ServiceDesc, srv)
+}
+
+func _PriceService_SchedulePriceChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulePriceChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServiceServer).SchedulePriceChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceService_SchedulePriceChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServiceServer).SchedulePriceChange(ctx, req.(*SchedulePriceChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PriceService_CancelScheduledPriceChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduledPriceChangeID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServiceServer).CancelScheduledPriceChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceService_CancelScheduledPriceChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServiceServer).CancelScheduledPriceChange(ctx, req.(*ScheduledPriceChangeID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PriceService_ListScheduledPriceChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServiceServer).ListScheduledPriceChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceService_ListScheduledPriceChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServiceServer).ListScheduledPriceChanges(ctx, req.(*ProductID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PriceService_GetPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServiceServer).GetPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceService_GetPriceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServiceServer).GetPriceHistory(ctx, req.(*ProductID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PriceService_GetPriceAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServiceServer).GetPriceAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceService_GetPriceAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServiceServer).GetPriceAt(ctx, req.(*GetPriceAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PriceService_ServiceDesc is the grpc.ServiceDesc for PriceService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PriceService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inventory.PriceService",
+	HandlerType: (*PriceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SchedulePriceChange",
+			Handler:    _PriceService_SchedulePriceChange_Handler,
+		},
+		{
+			MethodName: "CancelScheduledPriceChange",
+			Handler:    _PriceService_CancelScheduledPriceChange_Handler,
+		},
+		{
+			MethodName: "ListScheduledPriceChanges",
+			Handler:    _PriceService_ListScheduledPriceChanges_Handler,
+		},
+		{
+			MethodName: "GetPriceHistory",
+			Handler:    _PriceService_GetPriceHistory_Handler,
+		},
+		{
+			MethodName: "GetPriceAt",
+			Handler:    _PriceService_GetPriceAt_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/price.proto",
+}
@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v6.30.2
+// source: proto/purchase_order.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PurchaseOrderService_CreatePurchaseOrder_FullMethodName = "/inventory.PurchaseOrderService/CreatePurchaseOrder"
+	PurchaseOrderService_GetPurchaseOrder_FullMethodName    = "/inventory.PurchaseOrderService/GetPurchaseOrder"
+	PurchaseOrderService_SendPurchaseOrder_FullMethodName   = "/inventory.PurchaseOrderService/SendPurchaseOrder"
+	PurchaseOrderService_CancelPurchaseOrder_FullMethodName = "/inventory.PurchaseOrderService/CancelPurchaseOrder"
+	PurchaseOrderService_ReceiveGoods_FullMethodName        = "/inventory.PurchaseOrderService/ReceiveGoods"
+)
+
+// PurchaseOrderServiceClient is the client API for PurchaseOrderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PurchaseOrderServiceClient interface {
+	CreatePurchaseOrder(ctx context.Context, in *CreatePurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error)
+	GetPurchaseOrder(ctx context.Context, in *PurchaseOrderID, opts ...grpc.CallOption) (*PurchaseOrder, error)
+	SendPurchaseOrder(ctx context.Context, in *PurchaseOrderID, opts ...grpc.CallOption) (*PurchaseOrder, error)
+	CancelPurchaseOrder(ctx context.Context, in *PurchaseOrderID, opts ...grpc.CallOption) (*PurchaseOrder, error)
+	ReceiveGoods(ctx context.Context, in *ReceiveGoodsRequest, opts ...grpc.CallOption) (*PurchaseOrder, error)
+}
+
+type purchaseOrderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPurchaseOrderServiceClient(cc grpc.ClientConnInterface) PurchaseOrderServiceClient {
+	return &purchaseOrderServiceClient{cc}
+}
+
+func (c *purchaseOrderServiceClient) CreatePurchaseOrder(ctx context.Context, in *CreatePurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error) {
+	out := new(PurchaseOrder)
+	err := c.cc.Invoke(ctx, PurchaseOrderService_CreatePurchaseOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purchaseOrderServiceClient) GetPurchaseOrder(ctx context.Context, in *PurchaseOrderID, opts ...grpc.CallOption) (*PurchaseOrder, error) {
+	out := new(PurchaseOrder)
+	err := c.cc.Invoke(ctx, PurchaseOrderService_GetPurchaseOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purchaseOrderServiceClient) SendPurchaseOrder(ctx context.Context, in *PurchaseOrderID, opts ...grpc.CallOption) (*PurchaseOrder, error) {
+	out := new(PurchaseOrder)
+	err := c.cc.Invoke(ctx, PurchaseOrderService_SendPurchaseOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purchaseOrderServiceClient) CancelPurchaseOrder(ctx context.Context, in *PurchaseOrderID, opts ...grpc.CallOption) (*PurchaseOrder, error) {
+	out := new(PurchaseOrder)
+	err := c.cc.Invoke(ctx, PurchaseOrderService_CancelPurchaseOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purchaseOrderServiceClient) ReceiveGoods(ctx context.Context, in *ReceiveGoodsRequest, opts ...grpc.CallOption) (*PurchaseOrder, error) {
+	out := new(PurchaseOrder)
+	err := c.cc.Invoke(ctx, PurchaseOrderService_ReceiveGoods_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PurchaseOrderServiceServer is the server API for PurchaseOrderService service.
+// All implementations must embed UnimplementedPurchaseOrderServiceServer
+// for forward compatibility
+type PurchaseOrderServiceServer interface {
+	CreatePurchaseOrder(context.Context, *CreatePurchaseOrderRequest) (*PurchaseOrder, error)
+	GetPurchaseOrder(context.Context, *PurchaseOrderID) (*PurchaseOrder, error)
+	SendPurchaseOrder(context.Context, *PurchaseOrderID) (*PurchaseOrder, error)
+	CancelPurchaseOrder(context.Context, *PurchaseOrderID) (*PurchaseOrder, error)
+	ReceiveGoods(context.Context, *ReceiveGoodsRequest) (*PurchaseOrder, error)
+	mustEmbedUnimplementedPurchaseOrderServiceServer()
+}
+
+// UnimplementedPurchaseOrderServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPurchaseOrderServiceServer struct {
+}
+
+func (UnimplementedPurchaseOrderServiceServer) CreatePurchaseOrder(context.Context, *CreatePurchaseOrderRequest) (*PurchaseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePurchaseOrder not implemented")
+}
+func (UnimplementedPurchaseOrderServiceServer) GetPurchaseOrder(context.Context, *PurchaseOrderID) (*PurchaseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPurchaseOrder not implemented")
+}
+func (UnimplementedPurchaseOrderServiceServer) SendPurchaseOrder(context.Context, *PurchaseOrderID) (*PurchaseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendPurchaseOrder not implemented")
+}
+func (UnimplementedPurchaseOrderServiceServer) CancelPurchaseOrder(context.Context, *PurchaseOrderID) (*PurchaseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelPurchaseOrder not implemented")
+}
+func (UnimplementedPurchaseOrderServiceServer) ReceiveGoods(context.Context, *ReceiveGoodsRequest) (*PurchaseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveGoods not implemented")
+}
+func (UnimplementedPurchaseOrderServiceServer) mustEmbedUnimplementedPurchaseOrderServiceServer() {}
+
+// UnsafePurchaseOrderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PurchaseOrderServiceServer will
+// result in compilation errors.
+type UnsafePurchaseOrderServiceServer interface {
+	mustEmbedUnimplementedPurchaseOrderServiceServer()
+}
+
+func RegisterPurchaseOrderServiceServer(s grpc.ServiceRegistrar, srv PurchaseOrderServiceServer) {
+	s.RegisterService(&PurchaseOrderService_ServiceDesc, srv)
+}
+
+func _PurchaseOrderService_CreatePurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePurchaseOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurchaseOrderServiceServer).CreatePurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PurchaseOrderService_CreatePurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurchaseOrderServiceServer).CreatePurchaseOrder(ctx, req.(*CreatePurchaseOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PurchaseOrderService_GetPurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseOrderID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurchaseOrderServiceServer).GetPurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PurchaseOrderService_GetPurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurchaseOrderServiceServer).GetPurchaseOrder(ctx, req.(*PurchaseOrderID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PurchaseOrderService_SendPurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseOrderID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurchaseOrderServiceServer).SendPurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PurchaseOrderService_SendPurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurchaseOrderServiceServer).SendPurchaseOrder(ctx, req.(*PurchaseOrderID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PurchaseOrderService_CancelPurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseOrderID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurchaseOrderServiceServer).CancelPurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PurchaseOrderService_CancelPurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurchaseOrderServiceServer).CancelPurchaseOrder(ctx, req.(*PurchaseOrderID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PurchaseOrderService_ReceiveGoods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveGoodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurchaseOrderServiceServer).ReceiveGoods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PurchaseOrderService_ReceiveGoods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurchaseOrderServiceServer).ReceiveGoods(ctx, req.(*ReceiveGoodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PurchaseOrderService_ServiceDesc is the grpc.ServiceDesc for PurchaseOrderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PurchaseOrderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inventory.PurchaseOrderService",
+	HandlerType: (*PurchaseOrderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePurchaseOrder",
+			Handler:    _PurchaseOrderService_CreatePurchaseOrder_Handler,
+		},
+		{
+			MethodName: "GetPurchaseOrder",
+			Handler:    _PurchaseOrderService_GetPurchaseOrder_Handler,
+		},
+		{
+			MethodName: "SendPurchaseOrder",
+			Handler:    _PurchaseOrderService_SendPurchaseOrder_Handler,
+		},
+		{
+			MethodName: "CancelPurchaseOrder",
+			Handler:    _PurchaseOrderService_CancelPurchaseOrder_Handler,
+		},
+		{
+			MethodName: "ReceiveGoods",
+			Handler:    _PurchaseOrderService_ReceiveGoods_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/purchase_order.proto",
+}
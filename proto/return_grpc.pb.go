@@ -0,0 +1,224 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v6.30.2
+// source: proto/return.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ReturnService_CreateReturn_FullMethodName       = "/inventory.ReturnService/CreateReturn"
+	ReturnService_GetReturn_FullMethodName          = "/inventory.ReturnService/GetReturn"
+	ReturnService_ListReturnsByOrder_FullMethodName = "/inventory.ReturnService/ListReturnsByOrder"
+	ReturnService_InspectReturn_FullMethodName      = "/inventory.ReturnService/InspectReturn"
+)
+
+// ReturnServiceClient is the client API for ReturnService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReturnServiceClient interface {
+	CreateReturn(ctx context.Context, in *CreateReturnRequest, opts ...grpc.CallOption) (*Return, error)
+	GetReturn(ctx context.Context, in *ReturnID, opts ...grpc.CallOption) (*Return, error)
+	ListReturnsByOrder(ctx context.Context, in *ListReturnsByOrderRequest, opts ...grpc.CallOption) (*ReturnList, error)
+	// InspectReturn books inspected units as restocked, damaged or held for
+	// return to the vendor.
+	InspectReturn(ctx context.Context, in *InspectReturnRequest, opts ...grpc.CallOption) (*Return, error)
+}
+
+type returnServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReturnServiceClient(cc grpc.ClientConnInterface) ReturnServiceClient {
+	return &returnServiceClient{cc}
+}
+
+func (c *returnServiceClient) CreateReturn(ctx context.Context, in *CreateReturnRequest, opts ...grpc.CallOption) (*Return, error) {
+	out := new(Return)
+	err := c.cc.Invoke(ctx, ReturnService_CreateReturn_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *returnServiceClient) GetReturn(ctx context.Context, in *ReturnID, opts ...grpc.CallOption) (*Return, error) {
+	out := new(Return)
+	err := c.cc.Invoke(ctx, ReturnService_GetReturn_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *returnServiceClient) ListReturnsByOrder(ctx context.Context, in *ListReturnsByOrderRequest, opts ...grpc.CallOption) (*ReturnList, error) {
+	out := new(ReturnList)
+	err := c.cc.Invoke(ctx, ReturnService_ListReturnsByOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *returnServiceClient) InspectReturn(ctx context.Context, in *InspectReturnRequest, opts ...grpc.CallOption) (*Return, error) {
+	out := new(Return)
+	err := c.cc.Invoke(ctx, ReturnService_InspectReturn_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReturnServiceServer is the server API for ReturnService service.
+// All implementations must embed UnimplementedReturnServiceServer
+// for forward compatibility
+type ReturnServiceServer interface {
+	CreateReturn(context.Context, *CreateReturnRequest) (*Return, error)
+	GetReturn(context.Context, *ReturnID) (*Return, error)
+	ListReturnsByOrder(context.Context, *ListReturnsByOrderRequest) (*ReturnList, error)
+	// InspectReturn books inspected units as restocked, damaged or held for
+	// return to the vendor.
+	InspectReturn(context.Context, *InspectReturnRequest) (*Return, error)
+	mustEmbedUnimplementedReturnServiceServer()
+}
+
+// UnimplementedReturnServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedReturnServiceServer struct {
+}
+
+func (UnimplementedReturnServiceServer) CreateReturn(context.Context, *CreateReturnRequest) (*Return, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReturn not implemented")
+}
+func (UnimplementedReturnServiceServer) GetReturn(context.Context, *ReturnID) (*Return, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReturn not implemented")
+}
+func (UnimplementedReturnServiceServer) ListReturnsByOrder(context.Context, *ListReturnsByOrderRequest) (*ReturnList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReturnsByOrder not implemented")
+}
+func (UnimplementedReturnServiceServer) InspectReturn(context.Context, *InspectReturnRequest) (*Return, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InspectReturn not implemented")
+}
+func (UnimplementedReturnServiceServer) mustEmbedUnimplementedReturnServiceServer() {}
+
+// UnsafeReturnServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReturnServiceServer will
+// result in compilation errors.
+type UnsafeReturnServiceServer interface {
+	mustEmbedUnimplementedReturnServiceServer()
+}
+
+func RegisterReturnServiceServer(s grpc.ServiceRegistrar, srv ReturnServiceServer) {
+	s.RegisterService(&ReturnService_ServiceDesc, srv)
+}
+
+func _ReturnService_CreateReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReturnServiceServer).CreateReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReturnService_CreateReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReturnServiceServer).CreateReturn(ctx, req.(*CreateReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReturnService_GetReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReturnServiceServer).GetReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReturnService_GetReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReturnServiceServer).GetReturn(ctx, req.(*ReturnID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReturnService_ListReturnsByOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReturnsByOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReturnServiceServer).ListReturnsByOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReturnService_ListReturnsByOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReturnServiceServer).ListReturnsByOrder(ctx, req.(*ListReturnsByOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReturnService_InspectReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReturnServiceServer).InspectReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReturnService_InspectReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReturnServiceServer).InspectReturn(ctx, req.(*InspectReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReturnService_ServiceDesc is the grpc.ServiceDesc for ReturnService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReturnService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inventory.ReturnService",
+	HandlerType: (*ReturnServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateReturn",
+			Handler:    _ReturnService_CreateReturn_Handler,
+		},
+		{
+			MethodName: "GetReturn",
+			Handler:    _ReturnService_GetReturn_Handler,
+		},
+		{
+			MethodName: "ListReturnsByOrder",
+			Handler:    _ReturnService_ListReturnsByOrder_Handler,
+		},
+		{
+			MethodName: "InspectReturn",
+			Handler:    _ReturnService_InspectReturn_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/return.proto",
+}
@@ -0,0 +1,294 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v6.30.2
+// source: proto/serial.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	SerialService_ReceiveSerials_FullMethodName     = "/inventory.SerialService/ReceiveSerials"
+	SerialService_AllocateSerials_FullMethodName    = "/inventory.SerialService/AllocateSerials"
+	SerialService_GetSerial_FullMethodName          = "/inventory.SerialService/GetSerial"
+	SerialService_ListSerials_FullMethodName        = "/inventory.SerialService/ListSerials"
+	SerialService_UpdateSerialStatus_FullMethodName = "/inventory.SerialService/UpdateSerialStatus"
+	SerialService_CountSerials_FullMethodName       = "/inventory.SerialService/CountSerials"
+)
+
+// SerialServiceClient is the client API for SerialService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SerialServiceClient interface {
+	ReceiveSerials(ctx context.Context, in *ReceiveSerialsRequest, opts ...grpc.CallOption) (*SerialUnitList, error)
+	AllocateSerials(ctx context.Context, in *AllocateSerialsRequest, opts ...grpc.CallOption) (*SerialUnitList, error)
+	GetSerial(ctx context.Context, in *GetSerialRequest, opts ...grpc.CallOption) (*SerialUnit, error)
+	ListSerials(ctx context.Context, in *ListSerialsRequest, opts ...grpc.CallOption) (*SerialUnitList, error)
+	UpdateSerialStatus(ctx context.Context, in *UpdateSerialStatusRequest, opts ...grpc.CallOption) (*SerialUnit, error)
+	CountSerials(ctx context.Context, in *ProductID, opts ...grpc.CallOption) (*SerialCounts, error)
+}
+
+type serialServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSerialServiceClient(cc grpc.ClientConnInterface) SerialServiceClient {
+	return &serialServiceClient{cc}
+}
+
+func (c *serialServiceClient) ReceiveSerials(ctx context.Context, in *ReceiveSerialsRequest, opts ...grpc.CallOption) (*SerialUnitList, error) {
+	out := new(SerialUnitList)
+	err := c.cc.Invoke(ctx, SerialService_ReceiveSerials_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) AllocateSerials(ctx context.Context, in *AllocateSerialsRequest, opts ...grpc.CallOption) (*SerialUnitList, error) {
+	out := new(SerialUnitList)
+	err := c.cc.Invoke(ctx, SerialService_AllocateSerials_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) GetSerial(ctx context.Context, in *GetSerialRequest, opts ...grpc.CallOption) (*SerialUnit, error) {
+	out := new(SerialUnit)
+	err := c.cc.Invoke(ctx, SerialService_GetSerial_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) ListSerials(ctx context.Context, in *ListSerialsRequest, opts ...grpc.CallOption) (*SerialUnitList, error) {
+	out := new(SerialUnitList)
+	err := c.cc.Invoke(ctx, SerialService_ListSerials_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) UpdateSerialStatus(ctx context.Context, in *UpdateSerialStatusRequest, opts ...grpc.CallOption) (*SerialUnit, error) {
+	out := new(SerialUnit)
+	err := c.cc.Invoke(ctx, SerialService_UpdateSerialStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) CountSerials(ctx context.Context, in *ProductID, opts ...grpc.CallOption) (*SerialCounts, error) {
+	out := new(SerialCounts)
+	err := c.cc.Invoke(ctx, SerialService_CountSerials_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SerialServiceServer is the server API for SerialService service.
+// All implementations must embed UnimplementedSerialServiceServer
+// for forward compatibility
+type SerialServiceServer interface {
+	ReceiveSerials(context.Context, *ReceiveSerialsRequest) (*SerialUnitList, error)
+	AllocateSerials(context.Context, *AllocateSerialsRequest) (*SerialUnitList, error)
+	GetSerial(context.Context, *GetSerialRequest) (*SerialUnit, error)
+	ListSerials(context.Context, *ListSerialsRequest) (*SerialUnitList, error)
+	UpdateSerialStatus(context.Context, *UpdateSerialStatusRequest) (*SerialUnit, error)
+	CountSerials(context.Context, *ProductID) (*SerialCounts, error)
+	mustEmbedUnimplementedSerialServiceServer()
+}
+
+// UnimplementedSerialServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSerialServiceServer struct {
+}
+
+func (UnimplementedSerialServiceServer) ReceiveSerials(context.Context, *ReceiveSerialsRequest) (*SerialUnitList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveSerials not implemented")
+}
+func (UnimplementedSerialServiceServer) AllocateSerials(context.Context, *AllocateSerialsRequest) (*SerialUnitList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AllocateSerials not implemented")
+}
+func (UnimplementedSerialServiceServer) GetSerial(context.Context, *GetSerialRequest) (*SerialUnit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSerial not implemented")
+}
+func (UnimplementedSerialServiceServer) ListSerials(context.Context, *ListSerialsRequest) (*SerialUnitList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSerials not implemented")
+}
+func (UnimplementedSerialServiceServer) UpdateSerialStatus(context.Context, *UpdateSerialStatusRequest) (*SerialUnit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSerialStatus not implemented")
+}
+func (UnimplementedSerialServiceServer) CountSerials(context.Context, *ProductID) (*SerialCounts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountSerials not implemented")
+}
+func (UnimplementedSerialServiceServer) mustEmbedUnimplementedSerialServiceServer() {}
+
+// UnsafeSerialServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SerialServiceServer will
+// result in compilation errors.
+type UnsafeSerialServiceServer interface {
+	mustEmbedUnimplementedSerialServiceServer()
+}
+
+func RegisterSerialServiceServer(s grpc.ServiceRegistrar, srv SerialServiceServer) {
+	s.RegisterService(&SerialService_ServiceDesc, srv)
+}
+
+func _SerialService_ReceiveSerials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveSerialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).ReceiveSerials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_ReceiveSerials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).ReceiveSerials(ctx, req.(*ReceiveSerialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_AllocateSerials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AllocateSerialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).AllocateSerials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_AllocateSerials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).AllocateSerials(ctx, req.(*AllocateSerialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_GetSerial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSerialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).GetSerial(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_GetSerial_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).GetSerial(ctx, req.(*GetSerialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_ListSerials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSerialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).ListSerials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_ListSerials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).ListSerials(ctx, req.(*ListSerialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_UpdateSerialStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSerialStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).UpdateSerialStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_UpdateSerialStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).UpdateSerialStatus(ctx, req.(*UpdateSerialStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_CountSerials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).CountSerials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_CountSerials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).CountSerials(ctx, req.(*ProductID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SerialService_ServiceDesc is the grpc.ServiceDesc for SerialService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SerialService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inventory.SerialService",
+	HandlerType: (*SerialServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReceiveSerials",
+			Handler:    _SerialService_ReceiveSerials_Handler,
+		},
+		{
+			MethodName: "AllocateSerials",
+			Handler:    _SerialService_AllocateSerials_Handler,
+		},
+		{
+			MethodName: "GetSerial",
+			Handler:    _SerialService_GetSerial_Handler,
+		},
+		{
+			MethodName: "ListSerials",
+			Handler:    _SerialService_ListSerials_Handler,
+		},
+		{
+			MethodName: "UpdateSerialStatus",
+			Handler:    _SerialService_UpdateSerialStatus_Handler,
+		},
+		{
+			MethodName: "CountSerials",
+			Handler:    _SerialService_CountSerials_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/serial.proto",
+}
@@ -0,0 +1,368 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v6.30.2
+// source: proto/supplier.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	SupplierService_CreateSupplier_FullMethodName        = "/inventory.SupplierService/CreateSupplier"
+	SupplierService_GetSupplier_FullMethodName           = "/inventory.SupplierService/GetSupplier"
+	SupplierService_ListSuppliers_FullMethodName         = "/inventory.SupplierService/ListSuppliers"
+	SupplierService_LinkProductSupplier_FullMethodName   = "/inventory.SupplierService/LinkProductSupplier"
+	SupplierService_UnlinkProductSupplier_FullMethodName = "/inventory.SupplierService/UnlinkProductSupplier"
+	SupplierService_ListSupplierProducts_FullMethodName  = "/inventory.SupplierService/ListSupplierProducts"
+	SupplierService_ListProductSuppliers_FullMethodName  = "/inventory.SupplierService/ListProductSuppliers"
+	SupplierService_GetCheapestSupplier_FullMethodName   = "/inventory.SupplierService/GetCheapestSupplier"
+)
+
+// SupplierServiceClient is the client API for SupplierService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SupplierServiceClient interface {
+	CreateSupplier(ctx context.Context, in *CreateSupplierRequest, opts ...grpc.CallOption) (*Supplier, error)
+	GetSupplier(ctx context.Context, in *SupplierID, opts ...grpc.CallOption) (*Supplier, error)
+	ListSuppliers(ctx context.Context, in *ListSuppliersRequest, opts ...grpc.CallOption) (*SupplierList, error)
+	LinkProductSupplier(ctx context.Context, in *ProductSupplier, opts ...grpc.CallOption) (*ProductSupplier, error)
+	UnlinkProductSupplier(ctx context.Context, in *UnlinkProductSupplierRequest, opts ...grpc.CallOption) (*UnlinkProductSupplierResponse, error)
+	ListSupplierProducts(ctx context.Context, in *SupplierID, opts ...grpc.CallOption) (*SupplierProductList, error)
+	ListProductSuppliers(ctx context.Context, in *ProductID, opts ...grpc.CallOption) (*ProductSupplierList, error)
+	GetCheapestSupplier(ctx context.Context, in *GetCheapestSupplierRequest, opts ...grpc.CallOption) (*ProductSupplier, error)
+}
+
+type supplierServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSupplierServiceClient(cc grpc.ClientConnInterface) SupplierServiceClient {
+	return &supplierServiceClient{cc}
+}
+
+func (c *supplierServiceClient) CreateSupplier(ctx context.Context, in *CreateSupplierRequest, opts ...grpc.CallOption) (*Supplier, error) {
+	out := new(Supplier)
+	err := c.cc.Invoke(ctx, SupplierService_CreateSupplier_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplierServiceClient) GetSupplier(ctx context.Context, in *SupplierID, opts ...grpc.CallOption) (*Supplier, error) {
+	out := new(Supplier)
+	err := c.cc.Invoke(ctx, SupplierService_GetSupplier_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplierServiceClient) ListSuppliers(ctx context.Context, in *ListSuppliersRequest, opts ...grpc.CallOption) (*SupplierList, error) {
+	out := new(SupplierList)
+	err := c.cc.Invoke(ctx, SupplierService_ListSuppliers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplierServiceClient) LinkProductSupplier(ctx context.Context, in *ProductSupplier, opts ...grpc.CallOption) (*ProductSupplier, error) {
+	out := new(ProductSupplier)
+	err := c.cc.Invoke(ctx, SupplierService_LinkProductSupplier_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplierServiceClient) UnlinkProductSupplier(ctx context.Context, in *UnlinkProductSupplierRequest, opts ...grpc.CallOption) (*UnlinkProductSupplierResponse, error) {
+	out := new(UnlinkProductSupplierResponse)
+	err := c.cc.Invoke(ctx, SupplierService_UnlinkProductSupplier_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplierServiceClient) ListSupplierProducts(ctx context.Context, in *SupplierID, opts ...grpc.CallOption) (*SupplierProductList, error) {
+	out := new(SupplierProductList)
+	err := c.cc.Invoke(ctx, SupplierService_ListSupplierProducts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplierServiceClient) ListProductSuppliers(ctx context.Context, in *ProductID, opts ...grpc.CallOption) (*ProductSupplierList, error) {
+	out := new(ProductSupplierList)
+	err := c.cc.Invoke(ctx, SupplierService_ListProductSuppliers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplierServiceClient) GetCheapestSupplier(ctx context.Context, in *GetCheapestSupplierRequest, opts ...grpc.CallOption) (*ProductSupplier, error) {
+	out := new(ProductSupplier)
+	err := c.cc.Invoke(ctx, SupplierService_GetCheapestSupplier_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SupplierServiceServer is the server API for SupplierService service.
+// All implementations must embed UnimplementedSupplierServiceServer
+// for forward compatibility
+type SupplierServiceServer interface {
+	CreateSupplier(context.Context, *CreateSupplierRequest) (*Supplier, error)
+	GetSupplier(context.Context, *SupplierID) (*Supplier, error)
+	ListSuppliers(context.Context, *ListSuppliersRequest) (*SupplierList, error)
+	LinkProductSupplier(context.Context, *ProductSupplier) (*ProductSupplier, error)
+	UnlinkProductSupplier(context.Context, *UnlinkProductSupplierRequest) (*UnlinkProductSupplierResponse, error)
+	ListSupplierProducts(context.Context, *SupplierID) (*SupplierProductList, error)
+	ListProductSuppliers(context.Context, *ProductID) (*ProductSupplierList, error)
+	GetCheapestSupplier(context.Context, *GetCheapestSupplierRequest) (*ProductSupplier, error)
+	mustEmbedUnimplementedSupplierServiceServer()
+}
+
+// UnimplementedSupplierServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSupplierServiceServer struct {
+}
+
+func (UnimplementedSupplierServiceServer) CreateSupplier(context.Context, *CreateSupplierRequest) (*Supplier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSupplier not implemented")
+}
+func (UnimplementedSupplierServiceServer) GetSupplier(context.Context, *SupplierID) (*Supplier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupplier not implemented")
+}
+func (UnimplementedSupplierServiceServer) ListSuppliers(context.Context, *ListSuppliersRequest) (*SupplierList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSuppliers not implemented")
+}
+func (UnimplementedSupplierServiceServer) LinkProductSupplier(context.Context, *ProductSupplier) (*ProductSupplier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LinkProductSupplier not implemented")
+}
+func (UnimplementedSupplierServiceServer) UnlinkProductSupplier(context.Context, *UnlinkProductSupplierRequest) (*UnlinkProductSupplierResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlinkProductSupplier not implemented")
+}
+func (UnimplementedSupplierServiceServer) ListSupplierProducts(context.Context, *SupplierID) (*SupplierProductList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSupplierProducts not implemented")
+}
+func (UnimplementedSupplierServiceServer) ListProductSuppliers(context.Context, *ProductID) (*ProductSupplierList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProductSuppliers not implemented")
+}
+func (UnimplementedSupplierServiceServer) GetCheapestSupplier(context.Context, *GetCheapestSupplierRequest) (*ProductSupplier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCheapestSupplier not implemented")
+}
+func (UnimplementedSupplierServiceServer) mustEmbedUnimplementedSupplierServiceServer() {}
+
+// UnsafeSupplierServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SupplierServiceServer will
+// result in compilation errors.
+type UnsafeSupplierServiceServer interface {
+	mustEmbedUnimplementedSupplierServiceServer()
+}
+
+func RegisterSupplierServiceServer(s grpc.ServiceRegistrar, srv SupplierServiceServer) {
+	s.RegisterService(&SupplierService_ServiceDesc, srv)
+}
+
+func _SupplierService_CreateSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).CreateSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplierService_CreateSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).CreateSupplier(ctx, req.(*CreateSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplierService_GetSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SupplierID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).GetSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplierService_GetSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).GetSupplier(ctx, req.(*SupplierID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplierService_ListSuppliers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSuppliersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).ListSuppliers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplierService_ListSuppliers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).ListSuppliers(ctx, req.(*ListSuppliersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplierService_LinkProductSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductSupplier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).LinkProductSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplierService_LinkProductSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).LinkProductSupplier(ctx, req.(*ProductSupplier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplierService_UnlinkProductSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlinkProductSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).UnlinkProductSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplierService_UnlinkProductSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).UnlinkProductSupplier(ctx, req.(*UnlinkProductSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplierService_ListSupplierProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SupplierID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).ListSupplierProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplierService_ListSupplierProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).ListSupplierProducts(ctx, req.(*SupplierID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplierService_ListProductSuppliers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).ListProductSuppliers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplierService_ListProductSuppliers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).ListProductSuppliers(ctx, req.(*ProductID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplierService_GetCheapestSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCheapestSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).GetCheapestSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplierService_GetCheapestSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).GetCheapestSupplier(ctx, req.(*GetCheapestSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SupplierService_ServiceDesc is the grpc.ServiceDesc for SupplierService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SupplierService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inventory.SupplierService",
+	HandlerType: (*SupplierServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSupplier",
+			Handler:    _SupplierService_CreateSupplier_Handler,
+		},
+		{
+			MethodName: "GetSupplier",
+			Handler:    _SupplierService_GetSupplier_Handler,
+		},
+		{
+			MethodName: "ListSuppliers",
+			Handler:    _SupplierService_ListSuppliers_Handler,
+		},
+		{
+			MethodName: "LinkProductSupplier",
+			Handler:    _SupplierService_LinkProductSupplier_Handler,
+		},
+		{
+			MethodName: "UnlinkProductSupplier",
+			Handler:    _SupplierService_UnlinkProductSupplier_Handler,
+		},
+		{
+			MethodName: "ListSupplierProducts",
+			Handler:    _SupplierService_ListSupplierProducts_Handler,
+		},
+		{
+			MethodName: "ListProductSuppliers",
+			Handler:    _SupplierService_ListProductSuppliers_Handler,
+		},
+		{
+			MethodName: "GetCheapestSupplier",
+			Handler:    _SupplierService_GetCheapestSupplier_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/supplier.proto",
+}
@@ -0,0 +1,156 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v6.30.2
+// source: proto/valuation.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ValuationService_GetValuation_FullMethodName  = "/inventory.ValuationService/GetValuation"
+	ValuationService_GetCostLayers_FullMethodName = "/inventory.ValuationService/GetCostLayers"
+)
+
+// ValuationServiceClient is the client API for ValuationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ValuationServiceClient interface {
+	// GetValuation values the stock on hand per product, category and
+	// warehouse from the unit costs recorded on receipts. Only serial units
+	// record a location, so the warehouse breakdown puts every other unit under
+	// an empty location, and it is left out when as_of is set, as units keep
+	// no history of where they were held.
+	GetValuation(ctx context.Context, in *ValuationRequest, opts ...grpc.CallOption) (*ValuationReport, error)
+	GetCostLayers(ctx context.Context, in *CostLayersRequest, opts ...grpc.CallOption) (*CostLayers, error)
+}
+
+type valuationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewValuationServiceClient(cc grpc.ClientConnInterface) ValuationServiceClient {
+	return &valuationServiceClient{cc}
+}
+
+func (c *valuationServiceClient) GetValuation(ctx context.Context, in *ValuationRequest, opts ...grpc.CallOption) (*ValuationReport, error) {
+	out := new(ValuationReport)
+	err := c.cc.Invoke(ctx, ValuationService_GetValuation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *valuationServiceClient) GetCostLayers(ctx context.Context, in *CostLayersRequest, opts ...grpc.CallOption) (*CostLayers, error) {
+	out := new(CostLayers)
+	err := c.cc.Invoke(ctx, ValuationService_GetCostLayers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ValuationServiceServer is the server API for ValuationService service.
+// All implementations must embed UnimplementedValuationServiceServer
+// for forward compatibility
+type ValuationServiceServer interface {
+	// GetValuation values the stock on hand per product, category and
+	// warehouse from the unit costs recorded on receipts. Only serial units
+	// record a location, so the warehouse breakdown puts every other unit under
+	// an empty location, and it is left out when as_of is set, as units keep
+	// no history of where they were held.
+	GetValuation(context.Context, *ValuationRequest) (*ValuationReport, error)
+	GetCostLayers(context.Context, *CostLayersRequest) (*CostLayers, error)
+	mustEmbedUnimplementedValuationServiceServer()
+}
+
+// UnimplementedValuationServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedValuationServiceServer struct {
+}
+
+func (UnimplementedValuationServiceServer) GetValuation(context.Context, *ValuationRequest) (*ValuationReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValuation not implemented")
+}
+func (UnimplementedValuationServiceServer) GetCostLayers(context.Context, *CostLayersRequest) (*CostLayers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCostLayers not implemented")
+}
+func (UnimplementedValuationServiceServer) mustEmbedUnimplementedValuationServiceServer() {}
+
+// UnsafeValuationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ValuationServiceServer will
+// result in compilation errors.
+type UnsafeValuationServiceServer interface {
+	mustEmbedUnimplementedValuationServiceServer()
+}
+
+func RegisterValuationServiceServer(s grpc.ServiceRegistrar, srv ValuationServiceServer) {
+	s.RegisterService(&ValuationService_ServiceDesc, srv)
+}
+
+func _ValuationService_GetValuation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValuationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ValuationServiceServer).GetValuation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ValuationService_GetValuation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ValuationServiceServer).GetValuation(ctx, req.(*ValuationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ValuationService_GetCostLayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CostLayersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ValuationServiceServer).GetCostLayers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ValuationService_GetCostLayers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ValuationServiceServer).GetCostLayers(ctx, req.(*CostLayersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ValuationService_ServiceDesc is the grpc.ServiceDesc for ValuationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ValuationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inventory.ValuationService",
+	HandlerType: (*ValuationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetValuation",
+			Handler:    _ValuationService_GetValuation_Handler,
+		},
+		{
+			MethodName: "GetCostLayers",
+			Handler:    _ValuationService_GetCostLayers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/valuation.proto",
+}